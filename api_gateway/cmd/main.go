@@ -2,55 +2,58 @@ package main
 
 import (
 	"log"
-	"net/http"
-	"net/http/httputil"
-	"net/url"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/OshakbayAigerim/read_space/api_gateway/internal/config"
+	"github.com/OshakbayAigerim/read_space/api_gateway/internal/handler"
+	"github.com/OshakbayAigerim/read_space/api_gateway/internal/middleware"
+	bookpb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	orderpb "github.com/OshakbayAigerim/read_space/order_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+	userpb "github.com/OshakbayAigerim/read_space/user_service/proto"
 )
 
-// proxy возвращает gin.HandlerFunc, проксирующий запрос к target
-func proxy(target *url.URL) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		director := func(req *http.Request) {
-			req.URL.Scheme = target.Scheme
-			req.URL.Host = target.Host
-			req.URL.Path = c.Param("proxyPath")
-			if raw := c.Request.URL.RawQuery; raw != "" {
-				req.URL.RawQuery = raw
-			}
-			req.Header = c.Request.Header
-		}
-		p := &httputil.ReverseProxy{Director: director}
-		p.ServeHTTP(c.Writer, c.Request)
+// dial opens a lazy client connection; the backend does not have to be up yet.
+func dial(name, addr string) *grpc.ClientConn {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("cannot dial %s at %s: %v", name, addr, err)
 	}
+	return conn
 }
 
 func main() {
-	r := gin.Default()
+	cfg := config.Load()
 
-	// Список сервисов и их базовые URL
-	services := map[string]string{
-		"books":         "http://book_service:50051",
-		"users":         "http://user_service:50052",
-		"libraries":     "http://user_library_service:50053",
-		"exchange":      "http://exchange_service:50054",
-		"orders":        "http://order_service:50055",
-		"notifications": "http://notification_service:50056",
-	}
+	bookConn := dial("BookService", cfg.BookAddr)
+	defer bookConn.Close()
+	userConn := dial("UserService", cfg.UserAddr)
+	defer userConn.Close()
+	orderConn := dial("OrderService", cfg.OrderAddr)
+	defer orderConn.Close()
+	exchangeConn := dial("ExchangeService", cfg.ExchangeAddr)
+	defer exchangeConn.Close()
+	libConn := dial("UserLibraryService", cfg.LibraryAddr)
+	defer libConn.Close()
 
-	// Для каждого сервиса заводим маршрут вида /<service>/*proxyPath
-	for prefix, addr := range services {
-		target, err := url.Parse(addr)
-		if err != nil {
-			log.Fatalf("invalid URL for %s: %v", prefix, err)
-		}
-		group := r.Group("/" + prefix)
-		group.Any("/*proxyPath", proxy(target))
-	}
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+
+	r := gin.Default()
+	r.Use(middleware.Auth(tokens, middleware.PublicRoutes))
+
+	handler.NewBookHandler(bookpb.NewBookServiceClient(bookConn)).Register(r)
+	handler.NewUserHandler(userpb.NewUserServiceClient(userConn)).Register(r)
+	handler.NewOrderHandler(orderpb.NewOrderServiceClient(orderConn)).Register(r)
+	handler.NewExchangeHandler(exchangepb.NewExchangeServiceClient(exchangeConn)).Register(r)
+	handler.NewLibraryHandler(userlibpb.NewUserLibraryServiceClient(libConn)).Register(r)
 
-	log.Println("🚀 API Gateway running on :8080")
-	if err := r.Run(":8080"); err != nil {
+	log.Printf("🚀 API Gateway running on %s", cfg.HTTPAddr)
+	if err := r.Run(cfg.HTTPAddr); err != nil {
 		log.Fatalf("failed to run API Gateway: %v", err)
 	}
 }
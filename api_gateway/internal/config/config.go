@@ -0,0 +1,31 @@
+package config
+
+import "os"
+
+// Config holds the HTTP listen address and the gRPC addresses of the backends.
+type Config struct {
+	HTTPAddr     string
+	BookAddr     string
+	UserAddr     string
+	OrderAddr    string
+	ExchangeAddr string
+	LibraryAddr  string
+}
+
+func Load() Config {
+	return Config{
+		HTTPAddr:     getEnv("HTTP_ADDR", ":8080"),
+		BookAddr:     getEnv("BOOK_SERVICE_ADDR", "book_service:50051"),
+		UserAddr:     getEnv("USER_SERVICE_ADDR", "user_service:50052"),
+		OrderAddr:    getEnv("ORDER_SERVICE_ADDR", "order_service:50053"),
+		ExchangeAddr: getEnv("EXCHANGE_SERVICE_ADDR", "exchange_service:50054"),
+		LibraryAddr:  getEnv("USER_LIBRARY_SERVICE_ADDR", "user_library_service:50055"),
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
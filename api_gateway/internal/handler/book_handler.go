@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bookpb "github.com/OshakbayAigerim/read_space/book_service/proto"
+)
+
+type BookHandler struct {
+	client bookpb.BookServiceClient
+}
+
+func NewBookHandler(client bookpb.BookServiceClient) *BookHandler {
+	return &BookHandler{client: client}
+}
+
+func (h *BookHandler) Register(r gin.IRouter) {
+	g := r.Group("/books")
+	g.GET("", h.listAll)
+	g.POST("", h.create)
+	g.GET("/top-rated", h.listTopRated)
+	g.GET("/new-arrivals", h.listNewArrivals)
+	g.GET("/search", h.search)
+	g.GET("/suggest", h.suggest)
+	g.GET("/browse", h.browse)
+	g.GET("/genre/:genre", h.listByGenre)
+	g.GET("/author/:author", h.listByAuthor)
+	g.GET("/language/:language", h.listByLanguage)
+	g.GET("/isbn/:isbn", h.getByISBN)
+	g.GET("/:id", h.get)
+	g.PUT("/:id", h.update)
+	g.DELETE("/:id", h.delete)
+	g.GET("/:id/recommendations", h.recommend)
+	g.GET("/:id/reviews", h.listReviews)
+	g.POST("/:id/reviews", h.createReview)
+	g.PUT("/:id/reviews/:review_id", h.updateReview)
+	g.DELETE("/:id/reviews/:review_id", h.deleteReview)
+}
+
+func (h *BookHandler) create(c *gin.Context) {
+	book := &bookpb.Book{}
+	if err := bindProto(c, book); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.CreateBook(c.Request.Context(), &bookpb.CreateBookRequest{Book: book})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *BookHandler) get(c *gin.Context) {
+	resp, err := h.client.GetBook(c.Request.Context(), &bookpb.BookID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) getByISBN(c *gin.Context) {
+	resp, err := h.client.GetBookByISBN(c.Request.Context(), &bookpb.ISBNRequest{Isbn: c.Param("isbn")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) update(c *gin.Context) {
+	book := &bookpb.Book{}
+	if err := bindProto(c, book); err != nil {
+		renderError(c, err)
+		return
+	}
+	book.Id = c.Param("id")
+	resp, err := h.client.UpdateBook(c.Request.Context(), &bookpb.UpdateBookRequest{Book: book})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) delete(c *gin.Context) {
+	if _, err := h.client.DeleteBook(c.Request.Context(), &bookpb.BookID{Id: c.Param("id")}); err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// listAll pages through the catalog, filtered by ?genre=, ?author=,
+// ?language= and ?min_rating=.
+func (h *BookHandler) listAll(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	minRating, err := queryFloat(c, "min_rating")
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.ListAllBooks(c.Request.Context(), &bookpb.ListBooksRequest{
+		PageSize:  size,
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+		Genre:     c.Query("genre"),
+		Author:    c.Query("author"),
+		Language:  c.Query("language"),
+		MinRating: minRating,
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// suggest completes ?q= as the user types; ?limit= defaults to 10.
+func (h *BookHandler) suggest(c *gin.Context) {
+	prefix := c.Query("q")
+	if prefix == "" {
+		renderError(c, status.Error(codes.InvalidArgument, "query parameter q is required"))
+		return
+	}
+	req := &bookpb.SuggestRequest{Prefix: prefix}
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			renderError(c, status.Error(codes.InvalidArgument, "limit must be a number"))
+			return
+		}
+		req.Limit = int32(n)
+	}
+	resp, err := h.client.SuggestBooks(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) listByGenre(c *gin.Context) {
+	resp, err := h.client.ListBooksByGenre(c.Request.Context(), &bookpb.GenreRequest{Genre: c.Param("genre")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) listByAuthor(c *gin.Context) {
+	resp, err := h.client.ListBooksByAuthor(c.Request.Context(), &bookpb.AuthorRequest{Author: c.Param("author")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) listByLanguage(c *gin.Context) {
+	resp, err := h.client.ListBooksByLanguage(c.Request.Context(), &bookpb.LanguageRequest{Language: c.Param("language")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) search(c *gin.Context) {
+	keyword := c.Query("q")
+	if keyword == "" {
+		renderError(c, status.Error(codes.InvalidArgument, "query parameter q is required"))
+		return
+	}
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	minRating, err := queryFloat(c, "min_rating")
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.SearchBooks(c.Request.Context(), &bookpb.SearchRequest{
+		Keyword:   keyword,
+		PageSize:  size,
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+		Genre:     c.Query("genre"),
+		Author:    c.Query("author"),
+		Language:  c.Query("language"),
+		MinRating: minRating,
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) listTopRated(c *gin.Context) {
+	resp, err := h.client.ListTopRatedBooks(c.Request.Context(), &bookpb.Empty{})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) listNewArrivals(c *gin.Context) {
+	resp, err := h.client.ListNewArrivals(c.Request.Context(), &bookpb.Empty{})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) recommend(c *gin.Context) {
+	resp, err := h.client.RecommendBooks(c.Request.Context(), &bookpb.BookID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// listReviews pages through the reviews of a book, newest first unless
+// ?sort= says otherwise.
+func (h *BookHandler) listReviews(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.ListReviews(c.Request.Context(), &bookpb.ListReviewsRequest{
+		BookId:    c.Param("id"),
+		PageSize:  size,
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) createReview(c *gin.Context) {
+	req := &bookpb.CreateReviewRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.BookId = c.Param("id")
+	resp, err := h.client.CreateReview(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *BookHandler) updateReview(c *gin.Context) {
+	req := &bookpb.UpdateReviewRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.Id = c.Param("review_id")
+	resp, err := h.client.UpdateReview(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *BookHandler) deleteReview(c *gin.Context) {
+	if _, err := h.client.DeleteReview(c.Request.Context(), &bookpb.ReviewID{Id: c.Param("review_id")}); err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// browse combines the catalog filters and returns facet counts for the
+// filter sidebar. ?genre=, ?language= and ?author= may be repeated.
+func (h *BookHandler) browse(c *gin.Context) {
+	req := &bookpb.BrowseRequest{
+		Genres:    c.QueryArray("genre"),
+		Languages: c.QueryArray("language"),
+		Authors:   c.QueryArray("author"),
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+	}
+	var err error
+	for _, p := range []struct {
+		name string
+		dst  *float32
+	}{{"min_price", &req.MinPrice}, {"max_price", &req.MaxPrice}, {"min_rating", &req.MinRating}} {
+		if *p.dst, err = queryFloat(c, p.name); err != nil {
+			renderError(c, err)
+			return
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *int32
+	}{{"min_pages", &req.MinPages}, {"max_pages", &req.MaxPages}, {"published_from", &req.PublishedFrom}, {"published_to", &req.PublishedTo}} {
+		if *p.dst, err = queryInt(c, p.name); err != nil {
+			renderError(c, err)
+			return
+		}
+	}
+	if req.PageSize, err = pageSize(c); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.BrowseBooks(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// queryFloat reads an optional numeric query parameter.
+func queryFloat(c *gin.Context, name string) (float32, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a number", name)
+	}
+	return float32(f), nil
+}
+
+// queryInt reads an optional integer query parameter.
+func queryInt(c *gin.Context, name string) (int32, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be an integer", name)
+	}
+	return int32(n), nil
+}
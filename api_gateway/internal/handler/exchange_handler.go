@@ -0,0 +1,496 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+)
+
+type ExchangeHandler struct {
+	client exchangepb.ExchangeServiceClient
+}
+
+func NewExchangeHandler(client exchangepb.ExchangeServiceClient) *ExchangeHandler {
+	return &ExchangeHandler{client: client}
+}
+
+func (h *ExchangeHandler) Register(r gin.IRouter) {
+	g := r.Group("/exchange")
+	g.GET("", h.list)
+	g.POST("", h.create)
+	g.GET("/pending", h.listPending)
+	g.GET("/reputation/:user_id", h.reputation)
+	g.GET("/disputes/:dispute_id", h.getDispute)
+	g.POST("/disputes/:dispute_id/evidence", h.addDisputeEvidence)
+	g.PUT("/disputes/:dispute_id/resolve", h.resolveDispute)
+	g.GET("/wants/user/:user_id", h.listWants)
+	g.POST("/wants", h.addWant)
+	g.DELETE("/wants/:book_id", h.removeWant)
+	g.GET("/matches", h.findMatches)
+	g.POST("/matches/:partner_id/offer", h.offerFromMatch)
+	g.GET("/user/:user_id", h.listByUser)
+	g.GET("/:id", h.get)
+	g.PUT("/:id", h.update)
+	g.PATCH("/:id", h.patch)
+	g.DELETE("/:id", h.delete)
+	g.PUT("/:id/accept", h.accept)
+	g.PUT("/:id/decline", h.decline)
+	g.PUT("/:id/cancel", h.cancel)
+	g.PUT("/:id/complete", h.complete)
+	g.POST("/:id/counter", h.counter)
+	g.GET("/:id/negotiation", h.negotiation)
+	g.POST("/:id/rating", h.rate)
+	g.POST("/:id/dispute", h.openDispute)
+	g.POST("/:id/messages", h.sendMessage)
+	g.GET("/:id/messages", h.watchMessages)
+	g.POST("/:id/books/:book_id", h.addOfferedBook)
+	g.DELETE("/:id/books/:book_id", h.removeOfferedBook)
+	g.POST("/:id/requested/:book_id", h.addRequestedBook)
+	g.DELETE("/:id/requested/:book_id", h.removeRequestedBook)
+}
+
+func (h *ExchangeHandler) create(c *gin.Context) {
+	req := &exchangepb.CreateOfferRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.CreateOffer(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *ExchangeHandler) get(c *gin.Context) {
+	resp, err := h.client.GetOffer(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// list pages through all offers, filtered by ?status=, ?user_id=,
+// ?created_after= and ?created_before=.
+func (h *ExchangeHandler) list(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.ListAllOffers(c.Request.Context(), &exchangepb.ListOffersRequest{
+		PageSize:      size,
+		PageToken:     c.Query("page_token"),
+		Sort:          c.Query("sort"),
+		Status:        c.Query("status"),
+		UserId:        c.Query("user_id"),
+		CreatedAfter:  c.Query("created_after"),
+		CreatedBefore: c.Query("created_before"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// listPending returns pending offers; ?with_reputation=true adds each owner's reputation.
+func (h *ExchangeHandler) listPending(c *gin.Context) {
+	req := &exchangepb.ListPendingRequest{}
+	if v := c.Query("with_reputation"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			renderError(c, status.Error(codes.InvalidArgument, "with_reputation must be a boolean"))
+			return
+		}
+		req.IncludeReputation = b
+	}
+	resp, err := h.client.ListPendingOffers(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) listByUser(c *gin.Context) {
+	resp, err := h.client.ListOffersByUser(c.Request.Context(), &exchangepb.UserID{UserId: c.Param("user_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) update(c *gin.Context) {
+	offer := &exchangepb.ExchangeOffer{}
+	if err := bindProto(c, offer); err != nil {
+		renderError(c, err)
+		return
+	}
+	offer.Id = c.Param("id")
+	resp, err := h.client.UpdateOffer(c.Request.Context(), &exchangepb.UpdateOfferRequest{Offer: offer})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) delete(c *gin.Context) {
+	if _, err := h.client.DeleteOffer(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")}); err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ExchangeHandler) accept(c *gin.Context) {
+	req := &exchangepb.AcceptOfferRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.OfferId = c.Param("id")
+	resp, err := h.client.AcceptOffer(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) decline(c *gin.Context) {
+	resp, err := h.client.DeclineOffer(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) listWants(c *gin.Context) {
+	resp, err := h.client.ListWants(c.Request.Context(), &exchangepb.UserID{UserId: c.Param("user_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) addWant(c *gin.Context) {
+	req := &exchangepb.WantRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.AddWant(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) removeWant(c *gin.Context) {
+	resp, err := h.client.RemoveWant(c.Request.Context(), &exchangepb.WantRequest{BookId: c.Param("book_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// findMatches lists trading partners for the caller (?limit= caps the result).
+func (h *ExchangeHandler) findMatches(c *gin.Context) {
+	req := &exchangepb.FindMatchesRequest{}
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			renderError(c, status.Error(codes.InvalidArgument, "limit must be a number"))
+			return
+		}
+		req.Limit = int32(n)
+	}
+	resp, err := h.client.FindMatches(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) offerFromMatch(c *gin.Context) {
+	req := &exchangepb.MatchOfferRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.PartnerId = c.Param("partner_id")
+	resp, err := h.client.CreateOfferFromMatch(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *ExchangeHandler) cancel(c *gin.Context) {
+	resp, err := h.client.CancelOffer(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) complete(c *gin.Context) {
+	resp, err := h.client.CompleteOffer(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) counter(c *gin.Context) {
+	req := &exchangepb.CounterOfferRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.OfferId = c.Param("id")
+	resp, err := h.client.CounterOffer(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *ExchangeHandler) negotiation(c *gin.Context) {
+	resp, err := h.client.GetNegotiation(c.Request.Context(), &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) addOfferedBook(c *gin.Context) {
+	resp, err := h.client.AddOfferedBook(c.Request.Context(), &exchangepb.BookOpRequest{
+		OfferId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) removeOfferedBook(c *gin.Context) {
+	resp, err := h.client.RemoveOfferedBook(c.Request.Context(), &exchangepb.BookOpRequest{
+		OfferId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) addRequestedBook(c *gin.Context) {
+	resp, err := h.client.AddRequestedBook(c.Request.Context(), &exchangepb.BookOpRequest{
+		OfferId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) removeRequestedBook(c *gin.Context) {
+	resp, err := h.client.RemoveRequestedBook(c.Request.Context(), &exchangepb.BookOpRequest{
+		OfferId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// patch edits only the offer fields present in the JSON body; their keys
+// become the update mask.
+func (h *ExchangeHandler) patch(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		renderError(c, status.Errorf(codes.InvalidArgument, "cannot read body: %v", err))
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		renderError(c, status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err))
+		return
+	}
+	offer := &exchangepb.ExchangeOffer{}
+	if err := unmarshaler.Unmarshal(body, offer); err != nil {
+		renderError(c, status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err))
+		return
+	}
+	offer.Id = c.Param("id")
+	mask := &fieldmaskpb.FieldMask{}
+	for k := range fields {
+		mask.Paths = append(mask.Paths, k)
+	}
+	slices.Sort(mask.Paths)
+
+	resp, err := h.client.PatchOffer(c.Request.Context(), &exchangepb.PatchOfferRequest{Offer: offer, UpdateMask: mask})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) rate(c *gin.Context) {
+	req := &exchangepb.RateRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.OfferId = c.Param("id")
+	resp, err := h.client.RateExchangePartner(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *ExchangeHandler) reputation(c *gin.Context) {
+	resp, err := h.client.GetUserReputation(c.Request.Context(), &exchangepb.UserID{UserId: c.Param("user_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) openDispute(c *gin.Context) {
+	req := &exchangepb.OpenDisputeRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.OfferId = c.Param("id")
+	resp, err := h.client.OpenDispute(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *ExchangeHandler) getDispute(c *gin.Context) {
+	resp, err := h.client.GetDispute(c.Request.Context(), &exchangepb.DisputeID{Id: c.Param("dispute_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) addDisputeEvidence(c *gin.Context) {
+	req := &exchangepb.DisputeEvidenceRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.DisputeId = c.Param("dispute_id")
+	resp, err := h.client.AddDisputeEvidence(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) resolveDispute(c *gin.Context) {
+	req := &exchangepb.ResolveDisputeRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.DisputeId = c.Param("dispute_id")
+	resp, err := h.client.ResolveDispute(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *ExchangeHandler) sendMessage(c *gin.Context) {
+	req := &exchangepb.SendOfferMessageRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.OfferId = c.Param("id")
+	resp, err := h.client.SendOfferMessage(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+// watchMessages relays WatchOfferMessages as server-sent events: one
+// "message" event per chat message, then an "error" event if the stream
+// breaks while the client is still connected.
+func (h *ExchangeHandler) watchMessages(c *gin.Context) {
+	ctx := c.Request.Context()
+	stream, err := h.client.WatchOfferMessages(ctx, &exchangepb.OfferID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Stream(func(w io.Writer) bool {
+		m, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return false
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				st := status.Convert(err)
+				c.SSEvent("error", gin.H{"error": st.Message(), "code": st.Code().String()})
+			}
+			return false
+		}
+		data, err := marshaler.Marshal(m)
+		if err != nil {
+			c.SSEvent("error", gin.H{"error": err.Error(), "code": codes.Internal.String()})
+			return false
+		}
+		c.SSEvent("message", string(data))
+		return true
+	})
+}
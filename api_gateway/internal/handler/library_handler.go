@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+)
+
+type LibraryHandler struct {
+	client userlibpb.UserLibraryServiceClient
+}
+
+func NewLibraryHandler(client userlibpb.UserLibraryServiceClient) *LibraryHandler {
+	return &LibraryHandler{client: client}
+}
+
+func (h *LibraryHandler) Register(r gin.IRouter) {
+	g := r.Group("/libraries")
+	g.POST("", h.assign)
+	g.GET("/users/:user_id", h.listUserBooks)
+	g.GET("/users/:user_id/recommendations", h.recommend)
+	g.DELETE("/users/:user_id/books/:book_id", h.unassign)
+	g.GET("/books/:book_id", h.listByBook)
+	g.GET("/entries", h.listAll)
+	g.GET("/entries/:id", h.getEntry)
+	g.PUT("/entries/:id", h.updateEntry)
+	g.DELETE("/entries/:id", h.deleteEntry)
+}
+
+func (h *LibraryHandler) assign(c *gin.Context) {
+	req := &userlibpb.AssignBookRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.AssignBook(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *LibraryHandler) unassign(c *gin.Context) {
+	resp, err := h.client.UnassignBook(c.Request.Context(), &userlibpb.UnassignBookRequest{
+		UserId: c.Param("user_id"),
+		BookId: c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *LibraryHandler) listUserBooks(c *gin.Context) {
+	resp, err := h.client.ListUserBooks(c.Request.Context(), &userlibpb.ListUserBooksRequest{UserId: c.Param("user_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// recommend lists books the user does not own yet; ?limit= defaults to 10.
+func (h *LibraryHandler) recommend(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.RecommendForUser(c.Request.Context(), &userlibpb.RecommendForUserRequest{
+		UserId: c.Param("user_id"),
+		Limit:  limit,
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *LibraryHandler) listByBook(c *gin.Context) {
+	resp, err := h.client.ListByBook(c.Request.Context(), &userlibpb.ListByBookRequest{BookId: c.Param("book_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// listAll pages through all library entries, filtered by ?user_id= and
+// ?book_id=.
+func (h *LibraryHandler) listAll(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.ListAllEntries(c.Request.Context(), &userlibpb.ListEntriesRequest{
+		PageSize:  size,
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+		UserId:    c.Query("user_id"),
+		BookId:    c.Query("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *LibraryHandler) getEntry(c *gin.Context) {
+	resp, err := h.client.GetEntry(c.Request.Context(), &userlibpb.GetEntryRequest{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *LibraryHandler) updateEntry(c *gin.Context) {
+	entry := &userlibpb.UserBook{}
+	if err := bindProto(c, entry); err != nil {
+		renderError(c, err)
+		return
+	}
+	entry.Id = c.Param("id")
+	resp, err := h.client.UpdateEntry(c.Request.Context(), &userlibpb.UpdateEntryRequest{Entry: entry})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *LibraryHandler) deleteEntry(c *gin.Context) {
+	resp, err := h.client.DeleteEntry(c.Request.Context(), &userlibpb.DeleteEntryRequest{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
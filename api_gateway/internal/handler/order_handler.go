@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	orderpb "github.com/OshakbayAigerim/read_space/order_service/proto"
+)
+
+type OrderHandler struct {
+	client orderpb.OrderServiceClient
+}
+
+func NewOrderHandler(client orderpb.OrderServiceClient) *OrderHandler {
+	return &OrderHandler{client: client}
+}
+
+func (h *OrderHandler) Register(r gin.IRouter) {
+	g := r.Group("/orders")
+	g.GET("", h.list)
+	g.POST("", h.create)
+	g.GET("/user/:user_id", h.listByUser)
+	g.GET("/:id", h.get)
+	g.PUT("/:id", h.update)
+	g.DELETE("/:id", h.delete)
+	g.PUT("/:id/cancel", h.cancel)
+	g.PUT("/:id/return", h.returnBook)
+	g.POST("/:id/books/:book_id", h.addBook)
+	g.DELETE("/:id/books/:book_id", h.removeBook)
+}
+
+func (h *OrderHandler) create(c *gin.Context) {
+	req := &orderpb.CreateOrderRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.CreateOrder(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *OrderHandler) get(c *gin.Context) {
+	resp, err := h.client.GetOrder(c.Request.Context(), &orderpb.OrderID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// list pages through all orders, filtered by ?status=, ?user_id=,
+// ?created_after= and ?created_before=.
+func (h *OrderHandler) list(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.ListAllOrders(c.Request.Context(), &orderpb.ListOrdersRequest{
+		PageSize:      size,
+		PageToken:     c.Query("page_token"),
+		Sort:          c.Query("sort"),
+		Status:        c.Query("status"),
+		UserId:        c.Query("user_id"),
+		CreatedAfter:  c.Query("created_after"),
+		CreatedBefore: c.Query("created_before"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) listByUser(c *gin.Context) {
+	resp, err := h.client.ListOrdersByUser(c.Request.Context(), &orderpb.ListOrdersByUserRequest{UserId: c.Param("user_id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) update(c *gin.Context) {
+	order := &orderpb.Order{}
+	if err := bindProto(c, order); err != nil {
+		renderError(c, err)
+		return
+	}
+	order.Id = c.Param("id")
+	resp, err := h.client.UpdateOrder(c.Request.Context(), &orderpb.UpdateOrderRequest{Order: order})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) delete(c *gin.Context) {
+	if _, err := h.client.DeleteOrder(c.Request.Context(), &orderpb.OrderID{Id: c.Param("id")}); err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *OrderHandler) cancel(c *gin.Context) {
+	resp, err := h.client.CancelOrder(c.Request.Context(), &orderpb.OrderID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) returnBook(c *gin.Context) {
+	resp, err := h.client.ReturnBook(c.Request.Context(), &orderpb.OrderID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) addBook(c *gin.Context) {
+	resp, err := h.client.AddBookToOrder(c.Request.Context(), &orderpb.BookOperationRequest{
+		OrderId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *OrderHandler) removeBook(c *gin.Context) {
+	resp, err := h.client.RemoveBookFromOrder(c.Request.Context(), &orderpb.BookOperationRequest{
+		OrderId: c.Param("id"),
+		BookId:  c.Param("book_id"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
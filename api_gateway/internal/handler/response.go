@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	marshaler   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// bindProto decodes the JSON request body into m. An empty body leaves m untouched.
+func bindProto(c *gin.Context, m proto.Message) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot read body: %v", err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := unmarshaler.Unmarshal(body, m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+// pageSize reads the ?page_size= of the paginated list routes; the other
+// paging parameters, ?page_token= and ?sort=, are passed through as they are.
+func pageSize(c *gin.Context) (int32, error) {
+	v := c.Query("page_size")
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "page_size must be an integer")
+	}
+	return int32(n), nil
+}
+
+func renderProto(c *gin.Context, code int, m proto.Message) {
+	data, err := marshaler.Marshal(m)
+	if err != nil {
+		renderError(c, status.Errorf(codes.Internal, "cannot encode response: %v", err))
+		return
+	}
+	c.Data(code, "application/json", data)
+}
+
+// renderProtoList writes a JSON object with the messages collected under key
+// and, unless it is empty, the token of the next page. It is used for
+// server-streaming RPCs that have no list message of their own.
+func renderProtoList[M proto.Message](c *gin.Context, key string, list []M, nextPageToken string) {
+	items := make([]json.RawMessage, len(list))
+	for i, m := range list {
+		data, err := marshaler.Marshal(m)
+		if err != nil {
+			renderError(c, status.Errorf(codes.Internal, "cannot encode response: %v", err))
+			return
+		}
+		items[i] = data
+	}
+	body := gin.H{key: items}
+	if nextPageToken != "" {
+		body["next_page_token"] = nextPageToken
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// renderError converts a gRPC status error into the matching HTTP status.
+func renderError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	c.AbortWithStatusJSON(HTTPStatusFromCode(st.Code()), gin.H{
+		"error": st.Message(),
+		"code":  st.Code().String(),
+	})
+}
+
+// HTTPStatusFromCode maps a gRPC status code onto an HTTP status code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
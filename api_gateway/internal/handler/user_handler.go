@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	userpb "github.com/OshakbayAigerim/read_space/user_service/proto"
+)
+
+type UserHandler struct {
+	client userpb.UserServiceClient
+}
+
+func NewUserHandler(client userpb.UserServiceClient) *UserHandler {
+	return &UserHandler{client: client}
+}
+
+func (h *UserHandler) Register(r gin.IRouter) {
+	g := r.Group("/users")
+	g.GET("", h.listAll)
+	g.POST("", h.create)
+	g.GET("/:id", h.get)
+	g.PUT("/:id/password", h.changePassword)
+
+	a := r.Group("/auth")
+	a.POST("/login", h.login)
+	a.POST("/refresh", h.refresh)
+}
+
+func (h *UserHandler) create(c *gin.Context) {
+	req := &userpb.CreateUserRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.CreateUser(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusCreated, resp)
+}
+
+func (h *UserHandler) changePassword(c *gin.Context) {
+	req := &userpb.ChangePasswordRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	req.UserId = c.Param("id")
+	if _, err := h.client.ChangePassword(c.Request.Context(), req); err != nil {
+		renderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UserHandler) login(c *gin.Context) {
+	req := &userpb.LoginRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.Login(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *UserHandler) refresh(c *gin.Context) {
+	req := &userpb.RefreshRequest{}
+	if err := bindProto(c, req); err != nil {
+		renderError(c, err)
+		return
+	}
+	resp, err := h.client.Refresh(c.Request.Context(), req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+func (h *UserHandler) get(c *gin.Context) {
+	resp, err := h.client.GetUser(c.Request.Context(), &userpb.UserID{Id: c.Param("id")})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	renderProto(c, http.StatusOK, resp)
+}
+
+// listAll pages through all users, filtered by ?role=. The next page token
+// arrives in the stream trailer.
+func (h *UserHandler) listAll(c *gin.Context) {
+	size, err := pageSize(c)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	stream, err := h.client.ListAllUsers(c.Request.Context(), &userpb.ListUsersRequest{
+		PageSize:  size,
+		PageToken: c.Query("page_token"),
+		Sort:      c.Query("sort"),
+		Role:      c.Query("role"),
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	var users []*userpb.User
+	for {
+		u, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			renderError(c, err)
+			return
+		}
+		users = append(users, u)
+	}
+	var next string
+	if v := stream.Trailer().Get(paging.TrailerKey); len(v) > 0 {
+		next = v[0]
+	}
+	renderProtoList(c, "users", users, next)
+}
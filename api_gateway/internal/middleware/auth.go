@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+)
+
+// ContextUserIDKey is the gin context key holding the authenticated user ID.
+const ContextUserIDKey = "user_id"
+
+// Auth validates the bearer access token and forwards it to the backends in
+// gRPC metadata, where it is verified again. Requests for which public returns
+// true pass through unauthenticated.
+func Auth(tokens *auth.Manager, public func(c *gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if public(c) {
+			c.Next()
+			return
+		}
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "missing bearer token",
+				"code":  "Unauthenticated",
+			})
+			return
+		}
+		claims, err := tokens.ParseClaims(token, auth.AccessToken)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+				"code":  "Unauthenticated",
+			})
+			return
+		}
+		c.Set(ContextUserIDKey, claims.Subject)
+		c.Request = c.Request.WithContext(auth.WithToken(c.Request.Context(), token))
+		c.Next()
+	}
+}
+
+// PublicRoutes reports whether a request may skip authentication: logging in,
+// refreshing, registering and browsing the catalog.
+func PublicRoutes(c *gin.Context) bool {
+	path := c.FullPath()
+	switch {
+	case c.Request.Method == http.MethodPost && (path == "/auth/login" || path == "/auth/refresh" || path == "/users"):
+		return true
+	case c.Request.Method == http.MethodGet && (path == "/books" || strings.HasPrefix(path, "/books/")):
+		return true
+	}
+	return false
+}
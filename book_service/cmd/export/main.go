@@ -0,0 +1,108 @@
+// Command export writes the book catalog through the ExportBooks RPC as
+// CSV, JSON Lines or a simplified ONIX 3.0 feed:
+//
+//	go run ./book_service/cmd/export [-addr host:port] [-format csv|jsonl|onix]
+//		[-genre G] [-language L] [-currency CODE] [-o FILE]
+//
+// The file goes to standard output unless -o names one, whose extension
+// (.csv, .jsonl or .xml) then picks the default format.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+)
+
+var formats = map[string]string{".csv": "csv", ".jsonl": "jsonl", ".ndjson": "jsonl", ".xml": "onix"}
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "book service address")
+	format := flag.String("format", "", "csv, jsonl or onix; by default taken from the -o extension, else csv")
+	genre := flag.String("genre", "", "export only the books of this genre")
+	language := flag.String("language", "", "export only the books in this language")
+	currency := flag.String("currency", "", "ISO 4217 currency of the prices in an ONIX feed")
+	output := flag.String("o", "", "file to write instead of standard output")
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = formats[strings.ToLower(filepath.Ext(*output))]
+		if *format == "" {
+			*format = "csv"
+		}
+	}
+
+	req := &pb.ExportBooksRequest{Format: *format, Genre: *genre, Language: *language, Currency: *currency}
+	if err := run(*addr, req, *output); err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+}
+
+func run(addr string, req *pb.ExportBooksRequest, output string) error {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("connect to book service: %w", err)
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewBookServiceClient(conn).ExportBooks(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err := download(stream, os.Stdout)
+		return err
+	}
+
+	// Written beside the target and renamed, so a failed export leaves no
+	// truncated file behind.
+	tmp, err := os.CreateTemp(filepath.Dir(output), filepath.Base(output)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := download(stream, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), output); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d bytes to %s\n", n, output)
+	return nil
+}
+
+// download copies the chunks of the exported file to w until the stream
+// ends, returning the bytes written.
+func download(stream pb.BookService_ExportBooksClient, w io.Writer) (int64, error) {
+	var n int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		written, err := w.Write(chunk.Data)
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+}
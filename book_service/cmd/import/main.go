@@ -0,0 +1,108 @@
+// Command import loads a CSV or JSON Lines catalog file into the book
+// service through the ImportBooks RPC:
+//
+//	go run ./book_service/cmd/import [-addr host:port] [-format csv|jsonl] FILE
+//
+// FILE may be - for standard input, which then needs -format. The command
+// exits with status 1 if any row failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+)
+
+const chunkSize = 32 * 1024
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "book service address")
+	format := flag.String("format", "", "csv or jsonl; by default taken from the file extension")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: import [-addr host:port] [-format csv|jsonl] FILE")
+		os.Exit(2)
+	}
+
+	path := flag.Arg(0)
+	in := os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if *format == "ndjson" {
+			*format = "jsonl"
+		}
+	}
+	if *format != "csv" && *format != "jsonl" {
+		log.Fatalf("Cannot tell the format of %s; pass -format csv or -format jsonl", path)
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("Failed to connect to book service: %v", err)
+	}
+	defer conn.Close()
+
+	resp, err := upload(context.Background(), pb.NewBookServiceClient(conn), in, *format)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	fmt.Printf("created %d, updated %d, failed %d\n", resp.Created, resp.Updated, resp.Failed)
+	for _, e := range resp.Errors {
+		fmt.Printf("line %d: %s\n", e.Line, e.Message)
+	}
+	if int(resp.Failed) > len(resp.Errors) {
+		fmt.Printf("... and %d more\n", int(resp.Failed)-len(resp.Errors))
+	}
+	if resp.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// upload streams r to the service in chunks, naming the format in the first.
+func upload(ctx context.Context, client pb.BookServiceClient, r io.Reader, format string) (*pb.ImportBooksResponse, error) {
+	stream, err := client.ImportBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, chunkSize)
+	req := &pb.ImportBooksRequest{Format: format}
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			req.Data = buf[:n]
+			if err := stream.Send(req); err != nil {
+				// The server ended the stream; its status is the reason.
+				if err == io.EOF {
+					break
+				}
+				return nil, err
+			}
+			req = &pb.ImportBooksRequest{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
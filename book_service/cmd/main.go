@@ -11,9 +11,13 @@ import (
 	"github.com/OshakbayAigerim/read_space/book_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/config"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/handler"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/migrations"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/search"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/usecase"
 	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
 )
 
 func main() {
@@ -26,6 +30,12 @@ func main() {
 		}
 	}()
 
+	db := mongoClient.Database("readspace")
+	migrations.CreateBookIndexes(db)
+	migrations.CreateTextIndex(db)
+	migrations.CreateISBNIndex(db)
+	migrations.CreateReviewIndexes(db)
+
 	redisClient := config.ConnectRedis()
 	defer func() {
 		if err := redisClient.Close(); err != nil {
@@ -44,16 +54,33 @@ func main() {
 	bookRepo := repository.NewMongoBookRepository(mongoClient)
 	cachedBookRepo := repository.NewCachedBookRepository(bookRepo, bookCache)
 
-	bookUC := usecase.NewBookUseCase(cachedBookRepo)
+	// Follow the events before loading, so no change made meanwhile is lost.
+	index := search.New()
+	if err := index.Follow(nc); err != nil {
+		log.Fatalf(" Failed to subscribe the search index: %v", err)
+	}
+	if err := index.Load(ctx, bookRepo); err != nil {
+		log.Fatalf(" Failed to load the search index: %v", err)
+	}
+
+	libConn, err := grpc.Dial("localhost:50055", grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf(" Cannot dial UserLibraryService: %v", err)
+	}
+	defer libConn.Close()
+
+	bookUC := usecase.NewBookUseCase(cachedBookRepo, index)
+	reviewUC := usecase.NewReviewUseCase(repository.NewMongoReviewRepository(mongoClient), cachedBookRepo, userlibpb.NewUserLibraryServiceClient(libConn))
 
-	srv := handler.NewBookHandler(bookUC, nc)
+	srv := handler.NewBookHandler(bookUC, reviewUC, nc)
 
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf(" Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+	grpcServer := grpc.NewServer(auth.ServerOptions(tokens)...)
 	pb.RegisterBookServiceServer(grpcServer, srv)
 
 	log.Println("BookService gRPC server started on port 50051")
@@ -18,6 +18,8 @@ type BookCache interface {
 	SetList(ctx context.Context, key string, books []*domain.Book, expiration time.Duration) error
 
 	Delete(ctx context.Context, key string) error
+	// DeletePrefix deletes every key starting with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
 }
 type redisBookCache struct {
 	client *redis.Client
@@ -92,3 +94,18 @@ func (r *redisBookCache) Delete(ctx context.Context, key string) error {
 	log.Printf("🗑 Cache key '%s' deleted", key)
 	return nil
 }
+
+func (r *redisBookCache) DeletePrefix(ctx context.Context, prefix string) error {
+	iter := r.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := r.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	log.Printf("🗑 Cache keys '%s*' deleted", prefix)
+	return nil
+}
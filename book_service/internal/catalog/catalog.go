@@ -0,0 +1,96 @@
+// Package catalog reads and writes book catalogs in the file formats the
+// import and export tools exchange with the catalog team and partners: CSV
+// with a header row, JSON Lines with one book object per line, and, for
+// export only, a simplified ONIX 3.0 feed.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatONIX  = "onix"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+// Columns are the CSV columns in the order they are written. Import ignores
+// the read-only id, rating and rating_count, so an export can be imported
+// again.
+var Columns = []string{
+	"id", "isbn13", "isbn10", "title", "author", "genre", "language",
+	"description", "price", "pages", "published_date", "rating", "rating_count",
+}
+
+var readOnly = map[string]bool{"id": true, "rating": true, "rating_count": true}
+
+// Record is one book of a catalog file.
+type Record struct {
+	ID            string  `json:"id,omitempty"`
+	ISBN13        string  `json:"isbn13,omitempty"`
+	ISBN10        string  `json:"isbn10,omitempty"`
+	Title         string  `json:"title"`
+	Author        string  `json:"author"`
+	Genre         string  `json:"genre,omitempty"`
+	Language      string  `json:"language,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Price         float32 `json:"price,omitempty"`
+	Pages         int     `json:"pages,omitempty"`
+	PublishedDate string  `json:"published_date,omitempty"`
+	Rating        float32 `json:"rating,omitempty"`
+	RatingCount   int     `json:"rating_count,omitempty"`
+}
+
+func FromBook(b *domain.Book) Record {
+	return Record{
+		ID:            b.ID.Hex(),
+		ISBN13:        b.ISBN13,
+		ISBN10:        b.ISBN10,
+		Title:         b.Title,
+		Author:        b.Author,
+		Genre:         b.Genre,
+		Language:      b.Language,
+		Description:   b.Description,
+		Price:         b.Price,
+		Pages:         b.Pages,
+		PublishedDate: b.PublishedDate,
+		Rating:        b.Rating,
+		RatingCount:   b.RatingCount,
+	}
+}
+
+// Book returns the writable fields of the record as a new book.
+func (r Record) Book() *domain.Book {
+	return &domain.Book{
+		ISBN13:        r.ISBN13,
+		ISBN10:        r.ISBN10,
+		Title:         strings.TrimSpace(r.Title),
+		Author:        strings.TrimSpace(r.Author),
+		Genre:         r.Genre,
+		Language:      r.Language,
+		Description:   r.Description,
+		Price:         r.Price,
+		Pages:         r.Pages,
+		PublishedDate: r.PublishedDate,
+	}
+}
+
+// RowError is a row that could not be read; the rows after it still can.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,270 @@
+package catalog
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+// The feed keeps to the ONIX 3.0 reference tags a partner needs to list a
+// book: its identifiers, title, author, language, page count, genre,
+// description, publication date and, given a currency, its price. Code
+// values are from the ONIX code lists named beside them.
+const (
+	onixNamespace = "http://ns.editeur.org/onix/3.0/reference"
+	onixSender    = "ReadSpace"
+
+	onixConfirmed   = "03"  // list 1: notification confirmed on publication
+	onixProprietary = "01"  // list 5
+	onixISBN10      = "02"  // list 5
+	onixISBN13      = "15"  // list 5
+	onixSingleItem  = "00"  // list 2
+	onixBook        = "BA"  // list 150
+	onixTitle       = "01"  // lists 15 and 149: distinctive title of the product
+	onixAuthor      = "A01" // list 17
+	onixTextLang    = "01"  // list 22
+	onixPageCount   = "00"  // list 23: main content page count
+	onixPages       = "03"  // list 24
+	onixKeywords    = "20"  // list 27
+	onixDescription = "03"  // list 153
+	onixEveryone    = "00"  // list 154
+	onixPublished   = "01"  // list 163
+	onixAnySupplier = "00"  // list 93
+	onixAvailable   = "20"  // list 65
+	onixRRP         = "01"  // list 58: recommended retail price
+)
+
+// onixLanguages maps the language names and ISO 639-1 codes books are
+// stored with to the ISO 639-2/B codes ONIX requires. Other languages are
+// left out of the feed.
+var onixLanguages = map[string]string{
+	"en": "eng", "english": "eng",
+	"ru": "rus", "russian": "rus",
+	"kk": "kaz", "kazakh": "kaz",
+	"de": "ger", "german": "ger",
+	"fr": "fre", "french": "fre",
+	"es": "spa", "spanish": "spa",
+	"it": "ita", "italian": "ita",
+	"tr": "tur", "turkish": "tur",
+	"zh": "chi", "chinese": "chi",
+	"ja": "jpn", "japanese": "jpn",
+}
+
+type onixHeader struct {
+	XMLName      xml.Name `xml:"Header"`
+	SenderName   string   `xml:"Sender>SenderName"`
+	SentDateTime string
+}
+
+type onixProduct struct {
+	XMLName           xml.Name `xml:"Product"`
+	RecordReference   string
+	NotificationType  string
+	ProductIdentifier []onixIdentifier
+	DescriptiveDetail onixDescriptive
+	CollateralDetail  *onixCollateral `xml:",omitempty"`
+	PublishingDetail  *onixPublishing `xml:",omitempty"`
+	ProductSupply     *onixSupply     `xml:",omitempty"`
+}
+
+type onixIdentifier struct {
+	ProductIDType string
+	IDTypeName    string `xml:",omitempty"`
+	IDValue       string
+}
+
+type onixDescriptive struct {
+	ProductComposition string
+	ProductForm        string
+	TitleDetail        onixTitleDetail
+	Contributor        *onixContributor `xml:",omitempty"`
+	Language           *onixLanguage    `xml:",omitempty"`
+	Extent             *onixExtent      `xml:",omitempty"`
+	Subject            *onixSubject     `xml:",omitempty"`
+}
+
+type onixTitleDetail struct {
+	TitleType    string
+	TitleElement struct {
+		TitleElementLevel string
+		TitleText         string
+	}
+}
+
+type onixContributor struct {
+	SequenceNumber  int
+	ContributorRole string
+	PersonName      string
+}
+
+type onixLanguage struct {
+	LanguageRole string
+	LanguageCode string
+}
+
+type onixExtent struct {
+	ExtentType  string
+	ExtentValue int
+	ExtentUnit  string
+}
+
+type onixSubject struct {
+	SubjectSchemeIdentifier string
+	SubjectHeadingText      string
+}
+
+type onixCollateral struct {
+	TextContent struct {
+		TextType        string
+		ContentAudience string
+		Text            string
+	}
+}
+
+type onixPublishing struct {
+	PublishingDate struct {
+		PublishingDateRole string
+		Date               onixDate
+	}
+}
+
+type onixDate struct {
+	Format string `xml:"dateformat,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type onixSupply struct {
+	SupplyDetail struct {
+		Supplier struct {
+			SupplierRole string
+			SupplierName string
+		}
+		ProductAvailability string
+		Price               struct {
+			PriceType    string
+			PriceAmount  string
+			CurrencyCode string
+		}
+	}
+}
+
+func newONIXWriter(w io.Writer, currency string) (*Writer, error) {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	root := xml.StartElement{
+		Name: xml.Name{Local: "ONIXMessage"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "release"}, Value: "3.0"},
+			{Name: xml.Name{Local: "xmlns"}, Value: onixNamespace},
+		},
+	}
+	if err := enc.EncodeToken(root); err != nil {
+		return nil, err
+	}
+	header := onixHeader{SenderName: onixSender, SentDateTime: time.Now().UTC().Format("20060102T1504Z")}
+	if err := enc.Encode(header); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		write: func(b *domain.Book) error { return enc.Encode(onixFromBook(b, currency)) },
+		close: func() error {
+			if err := enc.EncodeToken(root.End()); err != nil {
+				return err
+			}
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, "\n")
+			return err
+		},
+	}, nil
+}
+
+func onixFromBook(b *domain.Book, currency string) onixProduct {
+	p := onixProduct{
+		RecordReference:  "readspace.book." + b.ID.Hex(),
+		NotificationType: onixConfirmed,
+		ProductIdentifier: []onixIdentifier{
+			{ProductIDType: onixProprietary, IDTypeName: onixSender, IDValue: b.ID.Hex()},
+		},
+	}
+	if b.ISBN13 != "" {
+		p.ProductIdentifier = append(p.ProductIdentifier, onixIdentifier{ProductIDType: onixISBN13, IDValue: b.ISBN13})
+	}
+	if b.ISBN10 != "" {
+		p.ProductIdentifier = append(p.ProductIdentifier, onixIdentifier{ProductIDType: onixISBN10, IDValue: b.ISBN10})
+	}
+
+	d := &p.DescriptiveDetail
+	d.ProductComposition, d.ProductForm = onixSingleItem, onixBook
+	d.TitleDetail.TitleType = onixTitle
+	d.TitleDetail.TitleElement.TitleElementLevel = onixTitle
+	d.TitleDetail.TitleElement.TitleText = b.Title
+	if b.Author != "" {
+		d.Contributor = &onixContributor{SequenceNumber: 1, ContributorRole: onixAuthor, PersonName: b.Author}
+	}
+	if code, ok := onixLanguages[strings.ToLower(strings.TrimSpace(b.Language))]; ok {
+		d.Language = &onixLanguage{LanguageRole: onixTextLang, LanguageCode: code}
+	}
+	if b.Pages > 0 {
+		d.Extent = &onixExtent{ExtentType: onixPageCount, ExtentValue: b.Pages, ExtentUnit: onixPages}
+	}
+	if b.Genre != "" {
+		d.Subject = &onixSubject{SubjectSchemeIdentifier: onixKeywords, SubjectHeadingText: b.Genre}
+	}
+
+	if b.Description != "" {
+		p.CollateralDetail = &onixCollateral{}
+		t := &p.CollateralDetail.TextContent
+		t.TextType, t.ContentAudience, t.Text = onixDescription, onixEveryone, b.Description
+	}
+	if date, ok := onixDateOf(b.PublishedDate); ok {
+		p.PublishingDetail = &onixPublishing{}
+		p.PublishingDetail.PublishingDate.PublishingDateRole = onixPublished
+		p.PublishingDetail.PublishingDate.Date = date
+	}
+	if currency != "" && b.Price > 0 {
+		p.ProductSupply = &onixSupply{}
+		s := &p.ProductSupply.SupplyDetail
+		s.Supplier.SupplierRole, s.Supplier.SupplierName = onixAnySupplier, onixSender
+		s.ProductAvailability = onixAvailable
+		s.Price.PriceType = onixRRP
+		s.Price.PriceAmount = strconv.FormatFloat(float64(b.Price), 'f', 2, 32)
+		s.Price.CurrencyCode = currency
+	}
+	return p
+}
+
+// onixDateOf converts an ISO published date, of a day, month or year, to an
+// ONIX date with its list 55 format.
+func onixDateOf(iso string) (onixDate, bool) {
+	v := strings.ReplaceAll(strings.TrimSpace(iso), "-", "")
+	if !digits(v) {
+		return onixDate{}, false
+	}
+	switch len(v) {
+	case 8:
+		return onixDate{Format: "00", Value: v}, true
+	case 6:
+		return onixDate{Format: "01", Value: v}, true
+	case 4:
+		return onixDate{Format: "05", Value: v}, true
+	}
+	return onixDate{}, false
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
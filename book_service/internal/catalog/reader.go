@@ -0,0 +1,157 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+// maxLine bounds a JSON Lines row, description included.
+const maxLine = 1 << 20
+
+// Reader reads the books of a catalog file one row at a time.
+type Reader struct {
+	read func() (int, *domain.Book, error)
+}
+
+// NewReader starts reading r in format. A CSV file must begin with a header
+// naming its columns, among them title and author; the columns may come in
+// any order.
+func NewReader(r io.Reader, format string) (*Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return newJSONLReader(r), nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+}
+
+// Read returns the next book and the line it starts on, or io.EOF after the
+// last one. A row that cannot be read is reported as a *RowError and
+// skipped; any other error ends the file.
+func (r *Reader) Read() (int, *domain.Book, error) {
+	return r.read()
+}
+
+// setters parse a CSV cell into its record field.
+var setters = map[string]func(*Record, string) error{
+	"isbn13":         func(r *Record, v string) error { r.ISBN13 = v; return nil },
+	"isbn10":         func(r *Record, v string) error { r.ISBN10 = v; return nil },
+	"title":          func(r *Record, v string) error { r.Title = v; return nil },
+	"author":         func(r *Record, v string) error { r.Author = v; return nil },
+	"genre":          func(r *Record, v string) error { r.Genre = v; return nil },
+	"language":       func(r *Record, v string) error { r.Language = v; return nil },
+	"description":    func(r *Record, v string) error { r.Description = v; return nil },
+	"published_date": func(r *Record, v string) error { r.PublishedDate = v; return nil },
+	"price": func(r *Record, v string) error {
+		if v == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return errors.New("price must be a number")
+		}
+		r.Price = float32(f)
+		return nil
+	},
+	"pages": func(r *Record, v string) error {
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("pages must be an integer")
+		}
+		r.Pages = n
+		return nil
+	},
+}
+
+func newCSVReader(r io.Reader) (*Reader, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing CSV header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	set := make([]func(*Record, string) error, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate CSV column %q", name)
+		}
+		seen[name] = true
+		if readOnly[name] {
+			continue
+		}
+		if set[i] = setters[name]; set[i] == nil {
+			return nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+	}
+	if !seen["title"] || !seen["author"] {
+		return nil, errors.New("CSV header must name the title and author columns")
+	}
+
+	return &Reader{read: func() (int, *domain.Book, error) {
+		row, err := cr.Read()
+		var pe *csv.ParseError
+		switch {
+		case errors.As(err, &pe):
+			return pe.StartLine, nil, &RowError{Line: pe.StartLine, Err: pe.Err}
+		case err != nil:
+			return 0, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		var rec Record
+		for i, v := range row {
+			if set[i] == nil {
+				continue
+			}
+			if err := set[i](&rec, strings.TrimSpace(v)); err != nil {
+				return line, nil, &RowError{Line: line, Err: err}
+			}
+		}
+		return line, rec.Book(), nil
+	}}, nil
+}
+
+func newJSONLReader(r io.Reader) *Reader {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxLine)
+	line := 0
+	return &Reader{read: func() (int, *domain.Book, error) {
+		for sc.Scan() {
+			line++
+			data := bytes.TrimSpace(sc.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.DisallowUnknownFields()
+			var rec Record
+			if err := dec.Decode(&rec); err != nil {
+				return line, nil, &RowError{Line: line, Err: err}
+			}
+			return line, rec.Book(), nil
+		}
+		if err := sc.Err(); err != nil {
+			return 0, nil, err
+		}
+		return 0, nil, io.EOF
+	}}
+}
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	line  int
+	title string
+	err   bool
+}
+
+func readAll(t *testing.T, r *Reader) []row {
+	t.Helper()
+	var rows []row
+	for {
+		line, book, err := r.Read()
+		if err == io.EOF {
+			return rows
+		}
+		var rowErr *RowError
+		switch {
+		case errors.As(err, &rowErr):
+			rows = append(rows, row{line: line, err: true})
+		case err != nil:
+			t.Fatalf("read: %v", err)
+		default:
+			rows = append(rows, row{line: line, title: book.Title})
+		}
+	}
+}
+
+func TestReader_SkipsBadRowsWithTheirLine(t *testing.T) {
+	csvFile := "\ufeffTitle,author,id,price\n" +
+		"Dune,Frank Herbert,abc,9.5\n" +
+		"Emma,Jane Austen,,cheap\n" +
+		"\"Multi\nline\",Someone,,\n"
+	r, err := NewReader(strings.NewReader(csvFile), FormatCSV)
+	if err != nil {
+		t.Fatalf("new CSV reader: %v", err)
+	}
+	want := []row{{line: 2, title: "Dune"}, {line: 3, err: true}, {line: 4, title: "Multi\nline"}}
+	if got := readAll(t, r); !equal(got, want) {
+		t.Errorf("CSV rows = %v, want %v", got, want)
+	}
+
+	if _, err := NewReader(strings.NewReader("title,year\n"), FormatCSV); err == nil {
+		t.Error("expected an unknown column to fail")
+	}
+	if _, err := NewReader(strings.NewReader("title\n"), FormatCSV); err == nil {
+		t.Error("expected a header without author to fail")
+	}
+	if _, err := NewReader(strings.NewReader(""), "xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	jsonl := `{"title":"Dune","author":"Frank Herbert"}` + "\n\n" +
+		`{"title":"Emma","colour":"red"}` + "\n" +
+		`{"title":" Persuasion ","author":"Jane Austen","pages":272}`
+	want = []row{{line: 1, title: "Dune"}, {line: 3, err: true}, {line: 4, title: "Persuasion"}}
+	if got := readAll(t, newJSONLReader(strings.NewReader(jsonl))); !equal(got, want) {
+		t.Errorf("JSONL rows = %v, want %v", got, want)
+	}
+}
+
+func equal(a, b []row) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+// Writer writes books to a catalog file one at a time.
+type Writer struct {
+	write func(*domain.Book) error
+	close func() error
+}
+
+// NewWriter starts a catalog file in format on w. currency, an ISO 4217
+// code, prices the books of an ONIX feed; without one they are left
+// unpriced. The other formats carry the bare price.
+func NewWriter(w io.Writer, format, currency string) (*Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatJSONL:
+		return newJSONLWriter(w), nil
+	case FormatONIX:
+		if currency != "" && !isCurrency(currency) {
+			return nil, errors.New("currency must be an ISO 4217 code such as USD")
+		}
+		return newONIXWriter(w, currency)
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+}
+
+// Write adds book to the file.
+func (w *Writer) Write(book *domain.Book) error {
+	return w.write(book)
+}
+
+// Close ends the file and flushes what is still buffered. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	return w.close()
+}
+
+func newCSVWriter(w io.Writer) (*Writer, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return nil, err
+	}
+	row := make([]string, len(Columns))
+	return &Writer{
+		write: func(b *domain.Book) error {
+			r := FromBook(b)
+			row = append(row[:0],
+				r.ID, r.ISBN13, r.ISBN10, r.Title, r.Author, r.Genre, r.Language,
+				r.Description,
+				strconv.FormatFloat(float64(r.Price), 'f', -1, 32),
+				strconv.Itoa(r.Pages),
+				r.PublishedDate,
+				strconv.FormatFloat(float64(r.Rating), 'f', -1, 32),
+				strconv.Itoa(r.RatingCount),
+			)
+			return cw.Write(row)
+		},
+		close: func() error {
+			cw.Flush()
+			return cw.Error()
+		},
+	}, nil
+}
+
+func newJSONLWriter(w io.Writer) *Writer {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Writer{
+		write: func(b *domain.Book) error { return enc.Encode(FromBook(b)) },
+		close: func() error { return nil },
+	}
+}
+
+func isCurrency(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+func TestWriter_ExportsReadBackAndONIX(t *testing.T) {
+	book := &domain.Book{
+		ID: primitive.NewObjectID(), Title: "Dune, Part \"One\"", Author: "Frank Herbert",
+		Genre: "Science Fiction", Language: "English", Description: "Spice & sand",
+		Price: 9.5, Pages: 412, PublishedDate: "1965-08-01", Rating: 4.5, RatingCount: 2,
+		ISBN10: "0441013597", ISBN13: "9780441013593",
+	}
+
+	for _, format := range []string{FormatCSV, FormatJSONL} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format, "")
+		if err != nil {
+			t.Fatalf("new %s writer: %v", format, err)
+		}
+		if err := w.Write(book); err != nil {
+			t.Fatalf("write %s: %v", format, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("close %s: %v", format, err)
+		}
+
+		r, err := NewReader(&buf, format)
+		if err != nil {
+			t.Fatalf("read back %s: %v", format, err)
+		}
+		_, got, err := r.Read()
+		if err != nil {
+			t.Fatalf("read back %s: %v", format, err)
+		}
+		want := *book
+		want.ID, want.Rating, want.RatingCount = primitive.NilObjectID, 0, 0
+		if *got != want {
+			t.Errorf("%s read back %+v, want %+v", format, *got, want)
+		}
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, FormatONIX, "usd"); err == nil {
+		t.Error("expected a lower-case currency to fail")
+	}
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, FormatONIX, "USD")
+	if err := w.Write(book); err != nil {
+		t.Fatalf("write onix: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close onix: %v", err)
+	}
+	var feed struct {
+		Release  string        `xml:"release,attr"`
+		Products []onixProduct `xml:"Product"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &feed); err != nil {
+		t.Fatalf("parse onix: %v\n%s", err, buf.String())
+	}
+	if feed.Release != "3.0" || len(feed.Products) != 1 {
+		t.Fatalf("expected one product in a 3.0 feed, got %+v", feed)
+	}
+	p := feed.Products[0]
+	if len(p.ProductIdentifier) != 3 || p.ProductIdentifier[1].IDValue != book.ISBN13 {
+		t.Errorf("unexpected identifiers %+v", p.ProductIdentifier)
+	}
+	if p.DescriptiveDetail.Language == nil || p.DescriptiveDetail.Language.LanguageCode != "eng" {
+		t.Errorf("expected language eng, got %+v", p.DescriptiveDetail.Language)
+	}
+	if d := p.PublishingDetail.PublishingDate.Date; d.Format != "00" || d.Value != "19650801" {
+		t.Errorf("unexpected publishing date %+v", d)
+	}
+	if price := p.ProductSupply.SupplyDetail.Price; price.PriceAmount != "9.50" || price.CurrencyCode != "USD" {
+		t.Errorf("unexpected price %+v", price)
+	}
+	if !strings.Contains(buf.String(), "Spice &amp; sand") {
+		t.Errorf("expected the description escaped, got\n%s", buf.String())
+	}
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type Book struct {
@@ -12,7 +14,78 @@ type Book struct {
 	Language      string             `bson:"language"`
 	Description   string             `bson:"description"`
 	Rating        float32            `bson:"rating"`
+	RatingCount   int                `bson:"rating_count"`
 	Price         float32            `bson:"price"`
 	Pages         int                `bson:"pages"`
 	PublishedDate string             `bson:"published_date"`
+	ISBN10        string             `bson:"isbn10,omitempty"`
+	ISBN13        string             `bson:"isbn13,omitempty"`
+}
+
+// BookSortFields are the sort orders ListAllBooks accepts.
+var BookSortFields = paging.Fields{
+	"title":          "title",
+	"author":         "author",
+	"rating":         "rating",
+	"price":          "price",
+	"pages":          "pages",
+	"published_date": "published_date",
+}
+
+// SearchSortFields are the sort orders SearchBooks accepts.
+var SearchSortFields = paging.Fields{
+	"relevance":      "score",
+	"title":          "title",
+	"author":         "author",
+	"rating":         "rating",
+	"price":          "price",
+	"pages":          "pages",
+	"published_date": "published_date",
+}
+
+// BookFilter narrows ListAllBooks and SearchBooks; zero fields match
+// everything.
+type BookFilter struct {
+	Genre     string
+	Author    string
+	Language  string
+	MinRating float32
+}
+
+// ScoredBook is a search hit with its text relevance score.
+type ScoredBook struct {
+	Book  `bson:",inline"`
+	Score float64 `bson:"score"`
+}
+
+// BrowseFilter combines the filters of BrowseBooks. Values within Genres,
+// Languages and Authors are alternatives; every filter given must match.
+// Zero bounds are open, and the years bound published_date inclusively.
+type BrowseFilter struct {
+	Genres        []string
+	Languages     []string
+	Authors       []string
+	MinPrice      float32
+	MaxPrice      float32
+	MinRating     float32
+	MinPages      int
+	MaxPages      int
+	PublishedFrom int
+	PublishedTo   int
+}
+
+type FacetCount struct {
+	Value string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// BrowseResult is a page of BrowseBooks. Each facet counts the books that
+// match every filter except the one on that facet.
+type BrowseResult struct {
+	Books         []*Book
+	NextPageToken string
+	Total         int64
+	Genres        []FacetCount
+	Languages     []FacetCount
+	Authors       []FacetCount
 }
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+// Review is the star rating and text a user gives a book they own. A
+// book's Rating and RatingCount are computed from its reviews.
+type Review struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	BookID    primitive.ObjectID `bson:"book_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Rating    int                `bson:"rating"`
+	Text      string             `bson:"text,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+	UpdatedAt primitive.DateTime `bson:"updated_at"`
+}
+
+// ReviewSortFields are the sort orders ListReviews accepts.
+var ReviewSortFields = paging.Fields{
+	"created_at": "created_at",
+	"rating":     "rating",
+}
+
+// RatingSummary is what the reviews of a book add up to.
+type RatingSummary struct {
+	Average float64
+	Count   int
+}
@@ -2,27 +2,33 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 
 	"github.com/nats-io/nats.go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/isbn"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/usecase"
 	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type BookHandler struct {
 	pb.UnimplementedBookServiceServer
 	usecase usecase.BookUseCase
+	reviews usecase.ReviewUseCase
 	nc      *nats.Conn
 }
 
-func NewBookHandler(u usecase.BookUseCase, nc *nats.Conn) *BookHandler {
+func NewBookHandler(u usecase.BookUseCase, reviews usecase.ReviewUseCase, nc *nats.Conn) *BookHandler {
 	return &BookHandler{
 		usecase: u,
+		reviews: reviews,
 		nc:      nc,
 	}
 }
@@ -37,29 +43,23 @@ func (h *BookHandler) CreateBook(ctx context.Context, req *pb.CreateBookRequest)
 		Genre:         req.Book.Genre,
 		Language:      req.Book.Language,
 		Description:   req.Book.Description,
-		Rating:        req.Book.Rating,
 		Price:         req.Book.Price,
 		Pages:         int(req.Book.Pages),
 		PublishedDate: req.Book.PublishedDate,
+		ISBN10:        req.Book.Isbn10,
+		ISBN13:        req.Book.Isbn13,
 	}
 
 	created, err := h.usecase.CreateBook(ctx, book)
 	if err != nil {
-		return nil, err
+		return nil, bookError(err, "cannot create book")
 	}
 
-	evt := struct {
-		Id     string `json:"id"`
-		Title  string `json:"title"`
-		Author string `json:"author"`
-	}{
-		Id:     created.ID.Hex(),
+	events.Emit(h.nc, events.BookCreated, events.BookEvent{
+		ID:     created.ID.Hex(),
 		Title:  created.Title,
 		Author: created.Author,
-	}
-	if data, err := json.Marshal(evt); err == nil {
-		h.nc.Publish("book.created", data)
-	}
+	})
 
 	return &pb.BookResponse{
 		Book: &pb.Book{
@@ -70,9 +70,12 @@ func (h *BookHandler) CreateBook(ctx context.Context, req *pb.CreateBookRequest)
 			Language:      created.Language,
 			Description:   created.Description,
 			Rating:        created.Rating,
+			RatingCount:   int32(created.RatingCount),
 			Price:         created.Price,
 			Pages:         int32(created.Pages),
 			PublishedDate: created.PublishedDate,
+			Isbn10:        created.ISBN10,
+			Isbn13:        created.ISBN13,
 		},
 	}, nil
 }
@@ -91,15 +94,55 @@ func (h *BookHandler) GetBook(ctx context.Context, req *pb.BookID) (*pb.BookResp
 			Language:      book.Language,
 			Description:   book.Description,
 			Rating:        book.Rating,
+			RatingCount:   int32(book.RatingCount),
 			Price:         book.Price,
 			Pages:         int32(book.Pages),
 			PublishedDate: book.PublishedDate,
+			Isbn10:        book.ISBN10,
+			Isbn13:        book.ISBN13,
 		},
 	}, nil
 }
 
-func (h *BookHandler) ListAllBooks(ctx context.Context, _ *pb.Empty) (*pb.BookList, error) {
-	books, err := h.usecase.ListBooks(ctx)
+func (h *BookHandler) GetBookByISBN(ctx context.Context, req *pb.ISBNRequest) (*pb.BookResponse, error) {
+	if req.GetIsbn() == "" {
+		return nil, status.Error(codes.InvalidArgument, "isbn is required")
+	}
+	book, err := h.usecase.GetBookByISBN(ctx, req.Isbn)
+	if err != nil {
+		return nil, bookError(err, "cannot get book")
+	}
+	return &pb.BookResponse{
+		Book: &pb.Book{
+			Id:            book.ID.Hex(),
+			Title:         book.Title,
+			Author:        book.Author,
+			Genre:         book.Genre,
+			Language:      book.Language,
+			Description:   book.Description,
+			Rating:        book.Rating,
+			RatingCount:   int32(book.RatingCount),
+			Price:         book.Price,
+			Pages:         int32(book.Pages),
+			PublishedDate: book.PublishedDate,
+			Isbn10:        book.ISBN10,
+			Isbn13:        book.ISBN13,
+		},
+	}, nil
+}
+
+func (h *BookHandler) ListAllBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.BookList, error) {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.BookSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f := domain.BookFilter{
+		Genre:     req.GetGenre(),
+		Author:    req.GetAuthor(),
+		Language:  req.GetLanguage(),
+		MinRating: req.GetMinRating(),
+	}
+	books, next, err := h.usecase.ListBooks(ctx, f, q)
 	if err != nil {
 		return nil, err
 	}
@@ -114,12 +157,15 @@ func (h *BookHandler) ListAllBooks(ctx context.Context, _ *pb.Empty) (*pb.BookLi
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
-	return &pb.BookList{Books: res}, nil
+	return &pb.BookList{Books: res, NextPageToken: next}, nil
 }
 
 func (h *BookHandler) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.BookResponse, error) {
@@ -137,15 +183,23 @@ func (h *BookHandler) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest)
 		Genre:         req.Book.Genre,
 		Language:      req.Book.Language,
 		Description:   req.Book.Description,
-		Rating:        req.Book.Rating,
 		Price:         req.Book.Price,
 		Pages:         int(req.Book.Pages),
 		PublishedDate: req.Book.PublishedDate,
+		ISBN10:        req.Book.Isbn10,
+		ISBN13:        req.Book.Isbn13,
 	}
 	updated, err := h.usecase.UpdateBook(ctx, book)
 	if err != nil {
-		return nil, err
+		return nil, bookError(err, "cannot update book")
 	}
+
+	events.Emit(h.nc, events.BookUpdated, events.BookEvent{
+		ID:     updated.ID.Hex(),
+		Title:  updated.Title,
+		Author: updated.Author,
+	})
+
 	return &pb.BookResponse{
 		Book: &pb.Book{
 			Id:            updated.ID.Hex(),
@@ -155,9 +209,12 @@ func (h *BookHandler) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest)
 			Language:      updated.Language,
 			Description:   updated.Description,
 			Rating:        updated.Rating,
+			RatingCount:   int32(updated.RatingCount),
 			Price:         updated.Price,
 			Pages:         int32(updated.Pages),
 			PublishedDate: updated.PublishedDate,
+			Isbn10:        updated.ISBN10,
+			Isbn13:        updated.ISBN13,
 		},
 	}, nil
 }
@@ -169,6 +226,7 @@ func (h *BookHandler) DeleteBook(ctx context.Context, req *pb.BookID) (*pb.Empty
 	if err := h.usecase.DeleteBook(ctx, req.Id); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete book: %v", err)
 	}
+	events.Emit(h.nc, events.BookDeleted, events.BookEvent{ID: req.Id})
 	return &pb.Empty{}, nil
 }
 
@@ -187,9 +245,12 @@ func (h *BookHandler) ListBooksByGenre(ctx context.Context, req *pb.GenreRequest
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: res}, nil
@@ -210,9 +271,12 @@ func (h *BookHandler) ListBooksByAuthor(ctx context.Context, req *pb.AuthorReque
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: res}, nil
@@ -233,9 +297,12 @@ func (h *BookHandler) ListBooksByLanguage(ctx context.Context, req *pb.LanguageR
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: res}, nil
@@ -256,9 +323,12 @@ func (h *BookHandler) ListTopRatedBooks(ctx context.Context, _ *pb.Empty) (*pb.B
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: res}, nil
@@ -279,25 +349,119 @@ func (h *BookHandler) ListNewArrivals(ctx context.Context, _ *pb.Empty) (*pb.Boo
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: res}, nil
 }
 
-func (h *BookHandler) SearchBooks(ctx context.Context, req *pb.SearchRequest) (*pb.BookList, error) {
+func (h *BookHandler) SearchBooks(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
 	if req == nil || req.Keyword == "" {
 		return nil, status.Error(codes.InvalidArgument, "keyword is required")
 	}
-	books, err := h.usecase.SearchBooks(ctx, req.Keyword)
+	sort := req.GetSort()
+	if sort == "" {
+		sort = "-relevance"
+	}
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), sort, domain.SearchSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f := domain.BookFilter{
+		Genre:     req.GetGenre(),
+		Author:    req.GetAuthor(),
+		Language:  req.GetLanguage(),
+		MinRating: req.GetMinRating(),
+	}
+	hits, next, err := h.usecase.SearchBooks(ctx, req.Keyword, f, q)
 	if err != nil {
 		return nil, err
 	}
-	var res []*pb.Book
-	for _, b := range books {
-		res = append(res, &pb.Book{
+	var res []*pb.SearchResult
+	for _, b := range hits {
+		res = append(res, &pb.SearchResult{
+			Book: &pb.Book{
+				Id:            b.ID.Hex(),
+				Title:         b.Title,
+				Author:        b.Author,
+				Genre:         b.Genre,
+				Language:      b.Language,
+				Description:   b.Description,
+				Rating:        b.Rating,
+				RatingCount:   int32(b.RatingCount),
+				Price:         b.Price,
+				Pages:         int32(b.Pages),
+				PublishedDate: b.PublishedDate,
+				Isbn10:        b.ISBN10,
+				Isbn13:        b.ISBN13,
+			},
+			Score: b.Score,
+		})
+	}
+	return &pb.SearchResponse{Results: res, NextPageToken: next}, nil
+}
+
+const (
+	defaultSuggestions = 10
+	maxSuggestions     = 50
+)
+
+func (h *BookHandler) SuggestBooks(ctx context.Context, req *pb.SuggestRequest) (*pb.SuggestResponse, error) {
+	if req == nil || req.Prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "prefix is required")
+	}
+	limit := int(req.Limit)
+	switch {
+	case limit <= 0:
+		limit = defaultSuggestions
+	case limit > maxSuggestions:
+		limit = maxSuggestions
+	}
+	var res []*pb.Suggestion
+	for _, s := range h.usecase.SuggestBooks(ctx, req.Prefix, limit) {
+		res = append(res, &pb.Suggestion{Text: s.Text, Field: s.Field, BookId: s.BookID})
+	}
+	return &pb.SuggestResponse{Suggestions: res}, nil
+}
+
+func (h *BookHandler) BrowseBooks(ctx context.Context, req *pb.BrowseRequest) (*pb.BrowseResponse, error) {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.BookSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	switch {
+	case req.GetMaxPrice() > 0 && req.GetMinPrice() > req.GetMaxPrice():
+		return nil, status.Error(codes.InvalidArgument, "min_price is above max_price")
+	case req.GetMaxPages() > 0 && req.GetMinPages() > req.GetMaxPages():
+		return nil, status.Error(codes.InvalidArgument, "min_pages is above max_pages")
+	case req.GetPublishedTo() > 0 && req.GetPublishedFrom() > req.GetPublishedTo():
+		return nil, status.Error(codes.InvalidArgument, "published_from is after published_to")
+	}
+	f := domain.BrowseFilter{
+		Genres:        req.GetGenres(),
+		Languages:     req.GetLanguages(),
+		Authors:       req.GetAuthors(),
+		MinPrice:      req.GetMinPrice(),
+		MaxPrice:      req.GetMaxPrice(),
+		MinRating:     req.GetMinRating(),
+		MinPages:      int(req.GetMinPages()),
+		MaxPages:      int(req.GetMaxPages()),
+		PublishedFrom: int(req.GetPublishedFrom()),
+		PublishedTo:   int(req.GetPublishedTo()),
+	}
+	res, err := h.usecase.BrowseBooks(ctx, f, q)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot browse books: %v", err)
+	}
+
+	var books []*pb.Book
+	for _, b := range res.Books {
+		books = append(books, &pb.Book{
 			Id:            b.ID.Hex(),
 			Title:         b.Title,
 			Author:        b.Author,
@@ -305,12 +469,30 @@ func (h *BookHandler) SearchBooks(ctx context.Context, req *pb.SearchRequest) (*
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
-	return &pb.BookList{Books: res}, nil
+	return &pb.BrowseResponse{
+		Books:         books,
+		NextPageToken: res.NextPageToken,
+		Total:         res.Total,
+		Genres:        facetCounts(res.Genres),
+		Languages:     facetCounts(res.Languages),
+		Authors:       facetCounts(res.Authors),
+	}, nil
+}
+
+func facetCounts(list []domain.FacetCount) []*pb.FacetCount {
+	out := make([]*pb.FacetCount, len(list))
+	for i, f := range list {
+		out[i] = &pb.FacetCount{Value: f.Value, Count: f.Count}
+	}
+	return out
 }
 
 func (h *BookHandler) RecommendBooks(ctx context.Context, req *pb.BookID) (*pb.BookList, error) {
@@ -331,10 +513,25 @@ func (h *BookHandler) RecommendBooks(ctx context.Context, req *pb.BookID) (*pb.B
 			Language:      b.Language,
 			Description:   b.Description,
 			Rating:        b.Rating,
+			RatingCount:   int32(b.RatingCount),
 			Price:         b.Price,
 			Pages:         int32(b.Pages),
 			PublishedDate: b.PublishedDate,
+			Isbn10:        b.ISBN10,
+			Isbn13:        b.ISBN13,
 		})
 	}
 	return &pb.BookList{Books: pbBooks}, nil
 }
+
+func bookError(err error, failure string) error {
+	switch {
+	case errors.Is(err, isbn.ErrInvalid), errors.Is(err, isbn.ErrMismatch):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrDuplicateISBN):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", failure, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: not found", failure)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", failure, err)
+}
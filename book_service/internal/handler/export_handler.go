@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"bufio"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/catalog"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+)
+
+// exportChunkSize is the size of the chunks the file is streamed in.
+const exportChunkSize = 32 * 1024
+
+// chunkSender sends every write as one chunk of the file.
+type chunkSender struct {
+	stream pb.BookService_ExportBooksServer
+}
+
+func (s chunkSender) Write(p []byte) (int, error) {
+	if err := s.stream.Send(&pb.ExportBooksChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (h *BookHandler) ExportBooks(req *pb.ExportBooksRequest, stream pb.BookService_ExportBooksServer) error {
+	buf := bufio.NewWriterSize(chunkSender{stream}, exportChunkSize)
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	w, err := catalog.NewWriter(buf, format, strings.TrimSpace(req.Currency))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	f := domain.BookFilter{Genre: req.Genre, Language: req.Language}
+	if err := h.usecase.ExportBooks(stream.Context(), f, w); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "cannot export books: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
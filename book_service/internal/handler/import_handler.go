@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/catalog"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/usecase"
+	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+)
+
+// maxImportErrors bounds the row errors returned, keeping the response of a
+// badly broken file within the message size limit.
+const maxImportErrors = 1000
+
+func (h *BookHandler) ImportBooks(stream pb.BookService_ImportBooksServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty import")
+	}
+	if err != nil {
+		return err
+	}
+
+	// The chunks are piped into the reader as they arrive, so the file is
+	// never held in memory whole.
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		msg := first
+		for {
+			if _, err := pw.Write(msg.Data); err != nil {
+				return
+			}
+			var err error
+			if msg, err = stream.Recv(); err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	r, err := catalog.NewReader(pr, strings.ToLower(strings.TrimSpace(first.Format)))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &pb.ImportBooksResponse{}
+	report := func(row usecase.ImportRow) {
+		if row.Err != nil {
+			resp.Failed++
+			if len(resp.Errors) < maxImportErrors {
+				resp.Errors = append(resp.Errors, &pb.ImportError{Line: int32(row.Line), Message: row.Err.Error()})
+			}
+			return
+		}
+		subject := events.BookUpdated
+		if row.Created {
+			subject = events.BookCreated
+			resp.Created++
+		} else {
+			resp.Updated++
+		}
+		events.Emit(h.nc, subject, events.BookEvent{
+			ID:     row.Book.ID.Hex(),
+			Title:  row.Book.Title,
+			Author: row.Book.Author,
+		})
+	}
+	if err := h.usecase.ImportBooks(stream.Context(), r, report); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "import stopped after %d rows: %v",
+			resp.Created+resp.Updated+resp.Failed, err)
+	}
+
+	sort.Slice(resp.Errors, func(i, j int) bool { return resp.Errors[i].Line < resp.Errors[j].Line })
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/usecase"
+	pb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+func (h *BookHandler) CreateReview(ctx context.Context, req *pb.CreateReviewRequest) (*pb.ReviewResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.BookId == "" {
+		return nil, status.Error(codes.InvalidArgument, "book_id is required")
+	}
+	if !primitive.IsValidObjectID(req.BookId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid book_id")
+	}
+	review, book, err := h.reviews.CreateReview(ctx, req.BookId, caller, int(req.Rating), req.Text)
+	if err != nil {
+		return nil, reviewError(err, "cannot create review")
+	}
+	h.reviewed(review, book, review.Rating)
+	return &pb.ReviewResponse{Review: toReviewProto(review)}, nil
+}
+
+func (h *BookHandler) UpdateReview(ctx context.Context, req *pb.UpdateReviewRequest) (*pb.ReviewResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if !primitive.IsValidObjectID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	review, book, err := h.reviews.UpdateReview(ctx, req.Id, caller, int(req.Rating), req.Text)
+	if err != nil {
+		return nil, reviewError(err, "cannot update review")
+	}
+	h.reviewed(review, book, review.Rating)
+	return &pb.ReviewResponse{Review: toReviewProto(review)}, nil
+}
+
+func (h *BookHandler) DeleteReview(ctx context.Context, req *pb.ReviewID) (*pb.Empty, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if !primitive.IsValidObjectID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	admin := auth.RoleFromContext(ctx) == auth.RoleAdmin
+	review, book, err := h.reviews.DeleteReview(ctx, req.Id, caller, admin)
+	if err != nil {
+		return nil, reviewError(err, "cannot delete review")
+	}
+	h.reviewed(review, book, 0)
+	return &pb.Empty{}, nil
+}
+
+func (h *BookHandler) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ReviewList, error) {
+	if !primitive.IsValidObjectID(req.GetBookId()) {
+		return nil, status.Error(codes.InvalidArgument, "a valid book_id is required")
+	}
+	sort := req.GetSort()
+	if sort == "" {
+		sort = "-created_at"
+	}
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), sort, domain.ReviewSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reviews, next, err := h.reviews.ListReviews(ctx, req.BookId, q)
+	if err != nil {
+		return nil, reviewError(err, "cannot list reviews")
+	}
+	res := make([]*pb.Review, len(reviews))
+	for i, r := range reviews {
+		res[i] = toReviewProto(r)
+	}
+	return &pb.ReviewList{Reviews: res, NextPageToken: next}, nil
+}
+
+// reviewed publishes book.reviewed with the book's new rating. The review
+// is stored by then, so a book deleted meanwhile only skips the event.
+func (h *BookHandler) reviewed(r *domain.Review, book *domain.Book, stars int) {
+	if book == nil {
+		return
+	}
+	events.Emit(h.nc, events.BookReviewed, events.BookReviewedEvent{
+		BookID:      book.ID.Hex(),
+		ReviewID:    r.ID.Hex(),
+		UserID:      r.UserID.Hex(),
+		Stars:       stars,
+		Rating:      book.Rating,
+		RatingCount: book.RatingCount,
+	})
+}
+
+func toReviewProto(r *domain.Review) *pb.Review {
+	return &pb.Review{
+		Id:        r.ID.Hex(),
+		BookId:    r.BookID.Hex(),
+		UserId:    r.UserID.Hex(),
+		Rating:    int32(r.Rating),
+		Text:      r.Text,
+		CreatedAt: r.CreatedAt.Time().UTC().Format(time.RFC3339),
+		UpdatedAt: r.UpdatedAt.Time().UTC().Format(time.RFC3339),
+	}
+}
+
+func reviewError(err error, failure string) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidRating), errors.Is(err, usecase.ErrReviewTooLong):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrAlreadyReviewed):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrNotBookOwner), errors.Is(err, usecase.ErrNotReviewer):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", failure, err)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Errorf(codes.NotFound, "%s: not found", failure)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", failure, err)
+}
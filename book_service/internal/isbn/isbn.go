@@ -0,0 +1,156 @@
+// Package isbn validates ISBN-10 and ISBN-13 numbers and converts between
+// the two forms. Books are deduplicated on the ISBN-13, which every ISBN-10
+// has one of.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalid  = errors.New("invalid ISBN")
+	ErrNoISBN10 = errors.New("only ISBN-13s starting with 978 have an ISBN-10")
+	ErrMismatch = errors.New("ISBN-10 and ISBN-13 name different books")
+)
+
+// Normalize drops the hyphens and spaces of s and upper-cases the ISBN-10
+// check digit X.
+func Normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '-' || r == ' ':
+			return -1
+		case r == 'x':
+			return 'X'
+		}
+		return r
+	}, s)
+}
+
+// Valid10 reports whether s is a normalized ISBN-10 with a correct check
+// digit.
+func Valid10(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		var d int
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case c == 'X' && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
+
+// Valid13 reports whether s is a normalized ISBN-13 with a correct check
+// digit.
+func Valid13(s string) bool {
+	if len(s) != 13 || !digits(s) {
+		return false
+	}
+	return s[12] == check13(s[:12])
+}
+
+// To13 converts a valid ISBN-10 to its ISBN-13.
+func To13(isbn10 string) (string, error) {
+	isbn10 = Normalize(isbn10)
+	if !Valid10(isbn10) {
+		return "", ErrInvalid
+	}
+	body := "978" + isbn10[:9]
+	return body + string(check13(body)), nil
+}
+
+// To10 converts a valid ISBN-13 to its ISBN-10. Only the 978 prefix maps
+// onto ISBN-10s.
+func To10(isbn13 string) (string, error) {
+	isbn13 = Normalize(isbn13)
+	if !Valid13(isbn13) {
+		return "", ErrInvalid
+	}
+	if !strings.HasPrefix(isbn13, "978") {
+		return "", ErrNoISBN10
+	}
+	body := isbn13[3:12]
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(body[i]-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", nil
+	}
+	return body + string(rune('0'+check)), nil
+}
+
+// Resolve validates a book's ISBNs, either of which may be empty, and
+// returns both normalized forms. The ISBN-10 is left empty for ISBN-13s
+// that have none.
+func Resolve(isbn10, isbn13 string) (string, string, error) {
+	isbn10, isbn13 = Normalize(isbn10), Normalize(isbn13)
+	switch {
+	case isbn10 == "" && isbn13 == "":
+		return "", "", nil
+	case isbn13 == "":
+		to13, err := To13(isbn10)
+		return isbn10, to13, err
+	}
+	if !Valid13(isbn13) {
+		return "", "", ErrInvalid
+	}
+	to10, err := To10(isbn13)
+	if isbn10 == "" {
+		if errors.Is(err, ErrNoISBN10) {
+			return "", isbn13, nil
+		}
+		return to10, isbn13, err
+	}
+	if !Valid10(isbn10) {
+		return "", "", ErrInvalid
+	}
+	if to10 != isbn10 {
+		return "", "", ErrMismatch
+	}
+	return isbn10, isbn13, nil
+}
+
+// Canonical returns the ISBN-13 of s, which may be either form.
+func Canonical(s string) (string, error) {
+	s = Normalize(s)
+	if len(s) == 10 {
+		return To13(s)
+	}
+	if !Valid13(s) {
+		return "", ErrInvalid
+	}
+	return s, nil
+}
+
+func check13(body string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(body[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
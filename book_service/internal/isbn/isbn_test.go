@@ -0,0 +1,49 @@
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConvert_RoundTrips(t *testing.T) {
+	cases := []struct{ isbn10, isbn13 string }{
+		{"0306406152", "9780306406157"},
+		{"080442957X", "9780804429573"},
+		{"0261103571", "9780261103573"},
+	}
+	for _, c := range cases {
+		if got, err := To13(c.isbn10); err != nil || got != c.isbn13 {
+			t.Errorf("To13(%s) = %s, %v; want %s", c.isbn10, got, err, c.isbn13)
+		}
+		if got, err := To10(c.isbn13); err != nil || got != c.isbn10 {
+			t.Errorf("To10(%s) = %s, %v; want %s", c.isbn13, got, err, c.isbn10)
+		}
+	}
+	if _, err := To10("9791032305690"); !errors.Is(err, ErrNoISBN10) {
+		t.Errorf("expected a 979 ISBN to have no ISBN-10, got %v", err)
+	}
+}
+
+func TestResolve_ValidatesAndFillsTheOtherForm(t *testing.T) {
+	if i10, i13, err := Resolve("0-306-40615-2", ""); err != nil || i10 != "0306406152" || i13 != "9780306406157" {
+		t.Errorf("expected both forms from the ISBN-10, got %s %s %v", i10, i13, err)
+	}
+	if i10, i13, err := Resolve("", "978-0-8044-2957-3"); err != nil || i10 != "080442957X" || i13 != "9780804429573" {
+		t.Errorf("expected both forms from the ISBN-13, got %s %s %v", i10, i13, err)
+	}
+	if i10, i13, err := Resolve("", "979-10-323-0569-0"); err != nil || i10 != "" || i13 != "9791032305690" {
+		t.Errorf("expected a 979 ISBN to resolve without an ISBN-10, got %s %s %v", i10, i13, err)
+	}
+	if _, _, err := Resolve("0306406153", ""); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected a bad ISBN-10 check digit to fail, got %v", err)
+	}
+	if _, _, err := Resolve("", "9780306406158"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected a bad ISBN-13 check digit to fail, got %v", err)
+	}
+	if _, _, err := Resolve("0306406152", "9780804429573"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("expected different books to mismatch, got %v", err)
+	}
+	if _, _, err := Resolve("", ""); err != nil {
+		t.Errorf("a book without ISBNs is valid, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateBookIndexes backs the keyset pagination of ListAllBooks: every sort
+// order ends in _id, and the filters are indexed ahead of the default order.
+// As prefixes, the same indexes serve plain lookups by genre, author,
+// language and rating.
+func CreateBookIndexes(db *mongo.Database) {
+	collection := db.Collection("books")
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "author", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "rating", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "pages", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "published_date", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "genre", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "language", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for books collection")
+}
+
+// CreateTextIndex creates the weighted text index SearchBooks runs on: a
+// match in the title counts ten times, in the author five times as much as
+// one in the description. Books carry their own language field with values
+// Mongo does not know, so stemming is switched off rather than taken from it.
+func CreateTextIndex(db *mongo.Database) {
+	collection := db.Collection("books")
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "author", Value: "text"},
+			{Key: "description", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("books_text").
+			SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "author", Value: 5}, {Key: "description", Value: 1}}).
+			SetDefaultLanguage("none").
+			SetLanguageOverride("text_language"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+		log.Fatalf("Failed to create text index: %v", err)
+	}
+
+	log.Println("Created text index for books collection")
+}
+
+// CreateReviewIndexes lets a user review a book once and backs the keyset
+// pagination of ListReviews in both of its sort orders.
+func CreateReviewIndexes(db *mongo.Database) {
+	collection := db.Collection("reviews")
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "book_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "book_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "book_id", Value: 1}, {Key: "rating", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for reviews collection")
+}
+
+// CreateISBNIndex makes the ISBN-13 unique among the books that have one;
+// books without ISBNs leave the field out rather than storing it empty.
+func CreateISBNIndex(db *mongo.Database) {
+	collection := db.Collection("books")
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "isbn13", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"isbn13": bson.M{"$exists": true}}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+		log.Fatalf("Failed to create ISBN index: %v", err)
+	}
+
+	log.Println("Created ISBN index for books collection")
+}
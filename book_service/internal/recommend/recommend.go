@@ -0,0 +1,158 @@
+// Package recommend ranks books by how much they have in common with a
+// source book: author, genre, language, and the words of their titles and
+// descriptions weighted by TF-IDF.
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/search"
+)
+
+// Score weights. A text similarity of 1 (the same words in the same
+// proportions) counts as much as textWeight.
+const (
+	authorWeight   = 3.0
+	genreWeight    = 2.0
+	languageWeight = 1.0
+	textWeight     = 4.0
+
+	// minTermLength drops the shortest function words; stopWords the rest.
+	minTermLength = 3
+)
+
+// stopWords are frequent English and Russian words that say nothing about a
+// book's subject.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "his": true, "her": true, "their": true, "into": true, "are": true,
+	"was": true, "were": true, "who": true, "its": true, "but": true, "not": true,
+	"что": true, "это": true, "как": true, "его": true, "для": true, "или": true,
+	"она": true, "они": true, "так": true, "все": true, "был": true, "при": true,
+}
+
+// Keywords returns up to n of the title and description words of b, most
+// frequent first, for finding candidates that talk about the same things.
+func Keywords(b *domain.Book, n int) []string {
+	tf := termFreq(b)
+	words := make([]string, 0, len(tf))
+	for w := range tf {
+		words = append(words, w)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if tf[words[i]] != tf[words[j]] {
+			return tf[words[i]] > tf[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+// Rank returns the limit candidates most similar to src, best first, leaving
+// out src itself and the books that have nothing in common with it. Term
+// rarity is measured over src and the candidates.
+func Rank(src *domain.Book, candidates []*domain.Book, limit int) []*domain.Book {
+	docs := make([]map[string]int, len(candidates))
+	df := make(map[string]int)
+	srcTF := termFreq(src)
+	for w := range srcTF {
+		df[w]++
+	}
+	for i, c := range candidates {
+		docs[i] = termFreq(c)
+		for w := range docs[i] {
+			df[w]++
+		}
+	}
+	n := len(candidates) + 1
+	srcVec := weigh(srcTF, df, n)
+
+	type scored struct {
+		book  *domain.Book
+		score float64
+	}
+	var ranked []scored
+	for i, c := range candidates {
+		if c.ID == src.ID {
+			continue
+		}
+		s := textWeight * cosine(srcVec, weigh(docs[i], df, n))
+		if same(src.Author, c.Author) {
+			s += authorWeight
+		}
+		if same(src.Genre, c.Genre) {
+			s += genreWeight
+		}
+		if same(src.Language, c.Language) {
+			s += languageWeight
+		}
+		if s > 0 {
+			ranked = append(ranked, scored{c, s})
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.book.Rating != b.book.Rating {
+			return a.book.Rating > b.book.Rating
+		}
+		return a.book.ID.Hex() < b.book.ID.Hex()
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	out := make([]*domain.Book, len(ranked))
+	for i, r := range ranked {
+		out[i] = r.book
+	}
+	return out
+}
+
+func termFreq(b *domain.Book) map[string]int {
+	tf := make(map[string]int)
+	for _, w := range search.Terms(b.Title + " " + b.Description) {
+		if utf8.RuneCountInString(w) >= minTermLength && !stopWords[w] {
+			tf[w]++
+		}
+	}
+	return tf
+}
+
+// weigh turns term frequencies into a TF-IDF vector over n documents.
+func weigh(tf map[string]int, df map[string]int, n int) map[string]float64 {
+	v := make(map[string]float64, len(tf))
+	for w, f := range tf {
+		v[w] = float64(f) * (math.Log(float64(1+n)/float64(1+df[w])) + 1)
+	}
+	return v
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, na, nb float64
+	for w, x := range a {
+		dot += x * b[w]
+		na += x * x
+	}
+	for _, y := range b {
+		nb += y * y
+	}
+	if dot == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// same compares two non-empty attribute values, ignoring case.
+func same(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
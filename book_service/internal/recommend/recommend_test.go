@@ -0,0 +1,57 @@
+package recommend
+
+import (
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+func book(title, author, genre, language, description string) *domain.Book {
+	return &domain.Book{
+		ID:          primitive.NewObjectID(),
+		Title:       title,
+		Author:      author,
+		Genre:       genre,
+		Language:    language,
+		Description: description,
+	}
+}
+
+func titles(books []*domain.Book) []string {
+	var out []string
+	for _, b := range books {
+		out = append(out, b.Title)
+	}
+	return out
+}
+
+func TestRank_ScoresSharedAttributesAndWords(t *testing.T) {
+	src := book("The Hobbit", "Tolkien", "Fantasy", "English", "A hobbit, a wizard and dwarves travel to the dragon's mountain.")
+	candidates := []*domain.Book{
+		src,
+		book("Cookbook", "Someone", "Cooking", "French", "Recipes for the kitchen."),
+		book("Silmarillion", "Tolkien", "Mythology", "English", "The elder days of elves."),
+		book("Eragon", "Paolini", "Fantasy", "English", "A farm boy finds a dragon egg and meets a wizard."),
+		book("Earthsea", "Le Guin", "Fantasy", "English", "A young wizard learns magic."),
+		book("Le Petit Prince", "Saint-Exupéry", "Fable", "French", "A prince visits planets."),
+	}
+
+	got := titles(Rank(src, candidates, 10))
+	want := []string{"Silmarillion", "Eragon", "Earthsea"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := Rank(src, candidates, 1); len(got) != 1 || got[0].Title != "Silmarillion" {
+		t.Errorf("expected the limit to keep the best match, got %v", titles(got))
+	}
+}
+
+func TestKeywords_MostFrequentFirstWithoutStopWords(t *testing.T) {
+	b := book("Dragons", "", "", "", "A dragon and another dragon; the dragon of the north.")
+	if got := Keywords(b, 2); !slices.Equal(got, []string{"dragon", "another"}) {
+		t.Errorf("unexpected keywords %v", got)
+	}
+}
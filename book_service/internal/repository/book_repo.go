@@ -3,20 +3,46 @@ package repository
 
 import (
 	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type BookRepository interface {
 	Create(ctx context.Context, book *domain.Book) (*domain.Book, error)
 	GetByID(ctx context.Context, id string) (*domain.Book, error)
-	ListAll(ctx context.Context) ([]*domain.Book, error)
+	GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error)
+	// ListAll returns one page of the books matching f and the token of the
+	// next page.
+	ListAll(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error)
+	// Each calls fn with every book matching f in insertion order, reading
+	// them from a cursor, and stops at the first error fn returns.
+	Each(ctx context.Context, f domain.BookFilter, fn func(*domain.Book) error) error
+	// Update leaves Rating and RatingCount alone; SetRating stores the ones
+	// computed from the reviews and returns the updated book.
 	Update(ctx context.Context, book *domain.Book) (*domain.Book, error)
+	SetRating(ctx context.Context, id primitive.ObjectID, rating float32, count int) (*domain.Book, error)
+	// ResolveIDs sets the ID of every book already stored: found by its
+	// ISBN-13 if it has one, by its exact title and author otherwise. The
+	// others get a zero ID.
+	ResolveIDs(ctx context.Context, books []*domain.Book) error
+	// BulkSave inserts the books with a zero ID, giving them one, and updates
+	// the others like Update, except that missing ISBNs are kept. It returns
+	// the error of every book that could not be written by its index.
+	BulkSave(ctx context.Context, books []*domain.Book) (map[int]error, error)
 	Delete(ctx context.Context, id string) error
 	ListByGenre(ctx context.Context, genre string) ([]*domain.Book, error)
 	ListByAuthor(ctx context.Context, author string) ([]*domain.Book, error)
 	ListByLanguage(ctx context.Context, language string) ([]*domain.Book, error)
 	ListTopRated(ctx context.Context) ([]*domain.Book, error)
 	ListNewArrivals(ctx context.Context) ([]*domain.Book, error)
-	SearchBooks(ctx context.Context, keyword string) ([]*domain.Book, error)
+	// SearchBooks returns one page of the books matching keyword and f with
+	// their relevance scores, and the token of the next page.
+	// Browse returns one page of the books matching f together with the
+	// total and the facet counts.
+	Browse(ctx context.Context, f domain.BrowseFilter, q *paging.Query) (*domain.BrowseResult, error)
+	SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error)
 	RecommendBooks(ctx context.Context, bookID string) ([]*domain.Book, error)
 }
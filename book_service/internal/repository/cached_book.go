@@ -5,8 +5,11 @@ import (
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/OshakbayAigerim/read_space/book_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type cachedBookRepo struct {
@@ -29,13 +32,12 @@ func (r *cachedBookRepo) getCacheKeyForList(listType string) string {
 	return "books:" + listType
 }
 
+func (r *cachedBookRepo) getCacheKeyForRecommendations(id string) string {
+	return r.getCacheKeyForList("recommend:" + id)
+}
+
 func (r *cachedBookRepo) Create(ctx context.Context, book *domain.Book) (*domain.Book, error) {
-	created, err := r.repo.Create(ctx, book)
-	if err != nil {
-		return nil, err
-	}
-	r.cache.Delete(ctx, r.getCacheKeyForList("all"))
-	return created, nil
+	return r.repo.Create(ctx, book)
 }
 
 func (r *cachedBookRepo) GetByID(ctx context.Context, id string) (*domain.Book, error) {
@@ -58,18 +60,20 @@ func (r *cachedBookRepo) GetByID(ctx context.Context, id string) (*domain.Book,
 	return book, nil
 }
 
-func (r *cachedBookRepo) ListAll(ctx context.Context) ([]*domain.Book, error) {
-	cacheKey := r.getCacheKeyForList("all")
-	if books, err := r.cache.GetList(ctx, cacheKey); err == nil && books != nil {
-		return books, nil
-	}
+// GetByISBN is not cached: a changed ISBN could not be dropped from the
+// cache by the key of the old one.
+func (r *cachedBookRepo) GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	return r.repo.GetByISBN(ctx, isbn13)
+}
 
-	books, err := r.repo.ListAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-	r.cache.SetList(ctx, cacheKey, books, 5*time.Minute)
-	return books, nil
+// ListAll is not cached: a page is a cheap index range scan, and caching
+// every filter, sort and token combination would not pay off.
+func (r *cachedBookRepo) ListAll(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error) {
+	return r.repo.ListAll(ctx, f, q)
+}
+
+func (r *cachedBookRepo) Each(ctx context.Context, f domain.BookFilter, fn func(*domain.Book) error) error {
+	return r.repo.Each(ctx, f, fn)
 }
 
 func (r *cachedBookRepo) Update(ctx context.Context, book *domain.Book) (*domain.Book, error) {
@@ -78,17 +82,53 @@ func (r *cachedBookRepo) Update(ctx context.Context, book *domain.Book) (*domain
 		return nil, err
 	}
 	r.cache.Delete(ctx, r.getCacheKeyForBook(updated.ID.Hex()))
-	r.cache.Delete(ctx, r.getCacheKeyForList("all"))
+	r.cache.Delete(ctx, r.getCacheKeyForRecommendations(updated.ID.Hex()))
 	return updated, nil
 }
 
+// SetRating drops every cached copy of the book's old rating: the book
+// itself, the top rated and new arrivals lists, the lists it appears in and
+// the recommendations, which may list it for any book.
+func (r *cachedBookRepo) SetRating(ctx context.Context, id primitive.ObjectID, rating float32, count int) (*domain.Book, error) {
+	book, err := r.repo.SetRating(ctx, id, rating, count)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Delete(ctx, r.getCacheKeyForBook(book.ID.Hex()))
+	r.cache.Delete(ctx, r.getCacheKeyForList("top_rated"))
+	r.cache.Delete(ctx, r.getCacheKeyForList("new_arrivals"))
+	r.cache.DeletePrefix(ctx, r.getCacheKeyForRecommendations(""))
+	r.cache.Delete(ctx, r.getCacheKeyForList("genre:"+book.Genre))
+	r.cache.Delete(ctx, r.getCacheKeyForList("author:"+book.Author))
+	r.cache.Delete(ctx, r.getCacheKeyForList("language:"+book.Language))
+	return book, nil
+}
+
+func (r *cachedBookRepo) ResolveIDs(ctx context.Context, books []*domain.Book) error {
+	return r.repo.ResolveIDs(ctx, books)
+}
+
+func (r *cachedBookRepo) BulkSave(ctx context.Context, books []*domain.Book) (map[int]error, error) {
+	failed, err := r.repo.BulkSave(ctx, books)
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range books {
+		if _, ok := failed[i]; !ok {
+			r.cache.Delete(ctx, r.getCacheKeyForBook(b.ID.Hex()))
+			r.cache.Delete(ctx, r.getCacheKeyForRecommendations(b.ID.Hex()))
+		}
+	}
+	return failed, nil
+}
+
 func (r *cachedBookRepo) Delete(ctx context.Context, id string) error {
 	err := r.repo.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
 	r.cache.Delete(ctx, r.getCacheKeyForBook(id))
-	r.cache.Delete(ctx, r.getCacheKeyForList("all"))
+	r.cache.Delete(ctx, r.getCacheKeyForRecommendations(id))
 	return nil
 }
 
@@ -157,12 +197,16 @@ func (r *cachedBookRepo) ListNewArrivals(ctx context.Context) ([]*domain.Book, e
 	return books, nil
 }
 
-func (r *cachedBookRepo) SearchBooks(ctx context.Context, keyword string) ([]*domain.Book, error) {
-	return r.repo.SearchBooks(ctx, keyword)
+func (r *cachedBookRepo) Browse(ctx context.Context, f domain.BrowseFilter, q *paging.Query) (*domain.BrowseResult, error) {
+	return r.repo.Browse(ctx, f, q)
+}
+
+func (r *cachedBookRepo) SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error) {
+	return r.repo.SearchBooks(ctx, keyword, f, q)
 }
 
 func (r *cachedBookRepo) RecommendBooks(ctx context.Context, bookID string) ([]*domain.Book, error) {
-	cacheKey := r.getCacheKeyForList("recommend:" + bookID)
+	cacheKey := r.getCacheKeyForRecommendations(bookID)
 	if books, err := r.cache.GetList(ctx, cacheKey); err == nil && books != nil {
 		return books, nil
 	}
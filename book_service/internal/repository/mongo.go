@@ -3,7 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/recommend"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -45,22 +50,40 @@ func (r *mongoBookRepo) GetByID(ctx context.Context, id string) (*domain.Book, e
 	return &book, nil
 }
 
-func (r *mongoBookRepo) ListAll(ctx context.Context) ([]*domain.Book, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
-	if err != nil {
+func (r *mongoBookRepo) GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	var book domain.Book
+	if err := r.collection.FindOne(ctx, bson.M{"isbn13": isbn13}).Decode(&book); err != nil {
 		return nil, err
 	}
+	return &book, nil
+}
+
+func (r *mongoBookRepo) ListAll(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error) {
+	filter, opts := q.Find(bookFilter(f))
+	books, err := r.findByFilterWithOpts(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return paging.Page(q, books)
+}
+
+func (r *mongoBookRepo) Each(ctx context.Context, f domain.BookFilter, fn func(*domain.Book) error) error {
+	cursor, err := r.collection.Find(ctx, bookFilter(f), options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
 	defer cursor.Close(ctx)
 
-	var books []*domain.Book
 	for cursor.Next(ctx) {
 		var book domain.Book
 		if err := cursor.Decode(&book); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(&book); err != nil {
+			return err
 		}
-		books = append(books, &book)
 	}
-	return books, nil
+	return cursor.Err()
 }
 
 func (r *mongoBookRepo) Update(ctx context.Context, book *domain.Book) (*domain.Book, error) {
@@ -68,7 +91,19 @@ func (r *mongoBookRepo) Update(ctx context.Context, book *domain.Book) (*domain.
 		return nil, errors.New("book ID is empty")
 	}
 	filter := bson.M{"_id": book.ID}
-	update := bson.M{"$set": book}
+	set := editable(book)
+	// Missing ISBNs are removed rather than stored empty, which the unique
+	// index on isbn13 would count as duplicates.
+	unset := bson.M{}
+	for field, value := range map[string]string{"isbn10": book.ISBN10, "isbn13": book.ISBN13} {
+		if value == "" {
+			unset[field] = ""
+		}
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updatedBook domain.Book
@@ -80,6 +115,114 @@ func (r *mongoBookRepo) Update(ctx context.Context, book *domain.Book) (*domain.
 	return &updatedBook, nil
 }
 
+// editable returns the fields of book that Update and BulkSave write:
+// rating and rating_count are computed from the reviews (see SetRating), and
+// empty ISBNs are left out.
+func editable(book *domain.Book) bson.M {
+	set := bson.M{
+		"title":          book.Title,
+		"author":         book.Author,
+		"genre":          book.Genre,
+		"language":       book.Language,
+		"description":    book.Description,
+		"price":          book.Price,
+		"pages":          book.Pages,
+		"published_date": book.PublishedDate,
+	}
+	if book.ISBN10 != "" {
+		set["isbn10"] = book.ISBN10
+	}
+	if book.ISBN13 != "" {
+		set["isbn13"] = book.ISBN13
+	}
+	return set
+}
+
+func (r *mongoBookRepo) ResolveIDs(ctx context.Context, books []*domain.Book) error {
+	var isbns []string
+	var pairs bson.A
+	for _, b := range books {
+		if b.ISBN13 != "" {
+			isbns = append(isbns, b.ISBN13)
+		} else {
+			pairs = append(pairs, bson.M{"title": b.Title, "author": b.Author})
+		}
+	}
+	var or bson.A
+	if len(isbns) > 0 {
+		or = append(or, bson.M{"isbn13": bson.M{"$in": isbns}})
+	}
+	or = append(or, pairs...)
+	if len(or) == 0 {
+		return nil
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"title": 1, "author": 1, "isbn13": 1}).
+		SetSort(bson.M{"_id": 1})
+	stored, err := r.findByFilterWithOpts(ctx, bson.M{"$or": or}, opts)
+	if err != nil {
+		return err
+	}
+	byISBN := make(map[string]primitive.ObjectID)
+	byName := make(map[[2]string]primitive.ObjectID)
+	for _, s := range stored {
+		if s.ISBN13 != "" {
+			byISBN[s.ISBN13] = s.ID
+		}
+		// The oldest book of a title and author wins.
+		if _, ok := byName[[2]string{s.Title, s.Author}]; !ok {
+			byName[[2]string{s.Title, s.Author}] = s.ID
+		}
+	}
+	for _, b := range books {
+		if b.ISBN13 != "" {
+			b.ID = byISBN[b.ISBN13]
+		} else {
+			b.ID = byName[[2]string{b.Title, b.Author}]
+		}
+	}
+	return nil
+}
+
+func (r *mongoBookRepo) BulkSave(ctx context.Context, books []*domain.Book) (map[int]error, error) {
+	if len(books) == 0 {
+		return nil, nil
+	}
+	models := make([]mongo.WriteModel, len(books))
+	for i, b := range books {
+		if b.ID.IsZero() {
+			b.ID = primitive.NewObjectID()
+			models[i] = mongo.NewInsertOneModel().SetDocument(b)
+		} else {
+			models[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": b.ID}).
+				SetUpdate(bson.M{"$set": editable(b)})
+		}
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	var bwe mongo.BulkWriteException
+	if !errors.As(err, &bwe) || bwe.WriteConcernError != nil {
+		return nil, err
+	}
+	failed := make(map[int]error, len(bwe.WriteErrors))
+	for _, we := range bwe.WriteErrors {
+		failed[we.Index] = mongo.WriteException{WriteErrors: mongo.WriteErrors{we.WriteError}}
+	}
+	return failed, nil
+}
+
+func (r *mongoBookRepo) SetRating(ctx context.Context, id primitive.ObjectID, rating float32, count int) (*domain.Book, error) {
+	update := bson.M{"$set": bson.M{"rating": rating, "rating_count": count}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var book domain.Book
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
 func (r *mongoBookRepo) Delete(ctx context.Context, id string) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -105,7 +248,8 @@ func (r *mongoBookRepo) ListByLanguage(ctx context.Context, language string) ([]
 }
 
 func (r *mongoBookRepo) ListTopRated(ctx context.Context) ([]*domain.Book, error) {
-	opts := options.Find().SetSort(bson.M{"rating": -1}).SetLimit(10)
+	// Of two books with the same average, the one more readers agree on wins.
+	opts := options.Find().SetSort(bson.D{{Key: "rating", Value: -1}, {Key: "rating_count", Value: -1}}).SetLimit(10)
 	return r.findByFilterWithOpts(ctx, bson.M{}, opts)
 }
 
@@ -114,13 +258,240 @@ func (r *mongoBookRepo) ListNewArrivals(ctx context.Context) ([]*domain.Book, er
 	return r.findByFilterWithOpts(ctx, bson.M{}, opts)
 }
 
-func (r *mongoBookRepo) SearchBooks(ctx context.Context, keyword string) ([]*domain.Book, error) {
-	filter := bson.M{"$text": bson.M{"$search": keyword}}
-	return r.findByFilter(ctx, filter)
+// facetLimit caps the values listed per facet.
+const facetLimit = 50
+
+func (r *mongoBookRepo) Browse(ctx context.Context, f domain.BrowseFilter, q *paging.Query) (*domain.BrowseResult, error) {
+	ranges := browseRanges(f)
+	genres := anyOf("genre", f.Genres)
+	languages := anyOf("language", f.Languages)
+	authors := anyOf("author", f.Authors)
+
+	filter, opts := q.Find(merge(ranges, genres, languages, authors))
+	books, err := r.findByFilterWithOpts(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	res := &domain.BrowseResult{}
+	if res.Books, res.NextPageToken, err = paging.Page(q, books); err != nil {
+		return nil, err
+	}
+
+	// Each facet leaves out its own filter, so the sidebar keeps offering the
+	// alternatives to the values already picked.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: ranges}},
+		{{Key: "$facet", Value: bson.M{
+			"genres":    facetStages("genre", languages, authors),
+			"languages": facetStages("language", genres, authors),
+			"authors":   facetStages("author", genres, languages),
+			"total": bson.A{
+				bson.M{"$match": merge(genres, languages, authors)},
+				bson.M{"$count": "n"},
+			},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Genres    []domain.FacetCount `bson:"genres"`
+		Languages []domain.FacetCount `bson:"languages"`
+		Authors   []domain.FacetCount `bson:"authors"`
+		Total     []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+	if len(facets) == 1 {
+		res.Genres, res.Languages, res.Authors = facets[0].Genres, facets[0].Languages, facets[0].Authors
+		if len(facets[0].Total) == 1 {
+			res.Total = facets[0].Total[0].N
+		}
+	}
+	return res, nil
+}
+
+// browseRanges matches the bounds of f.
+func browseRanges(f domain.BrowseFilter) bson.M {
+	filter := bson.M{}
+	bound := func(field, op string, v any) {
+		r, ok := filter[field].(bson.M)
+		if !ok {
+			r = bson.M{}
+			filter[field] = r
+		}
+		r[op] = v
+	}
+	if f.MinPrice > 0 {
+		bound("price", "$gte", f.MinPrice)
+	}
+	if f.MaxPrice > 0 {
+		bound("price", "$lte", f.MaxPrice)
+	}
+	if f.MinRating > 0 {
+		bound("rating", "$gte", f.MinRating)
+	}
+	if f.MinPages > 0 {
+		bound("pages", "$gte", f.MinPages)
+	}
+	if f.MaxPages > 0 {
+		bound("pages", "$lte", f.MaxPages)
+	}
+	// published_date is an ISO date string, so a year range is a string range.
+	if f.PublishedFrom > 0 {
+		bound("published_date", "$gte", fmt.Sprintf("%04d", f.PublishedFrom))
+	}
+	if f.PublishedTo > 0 {
+		bound("published_date", "$lt", fmt.Sprintf("%04d", f.PublishedTo+1))
+	}
+	return filter
+}
+
+// anyOf matches field against any of values, or everything when there are none.
+func anyOf(field string, values []string) bson.M {
+	if len(values) == 0 {
+		return nil
+	}
+	return bson.M{field: bson.M{"$in": values}}
+}
+
+func merge(filters ...bson.M) bson.M {
+	out := bson.M{}
+	for _, f := range filters {
+		for k, v := range f {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// facetStages counts the books per value of field among those matching filters.
+func facetStages(field string, filters ...bson.M) bson.A {
+	match := merge(filters...)
+	match[field] = bson.M{"$nin": bson.A{"", nil}}
+	return bson.A{
+		bson.M{"$match": match},
+		bson.M{"$group": bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		bson.M{"$limit": facetLimit},
+	}
+}
+
+// SearchBooks needs the weighted text index created by
+// migrations.CreateTextIndex. The score is computed per match, so the page
+// cursor is applied after it in the pipeline rather than by an index.
+func (r *mongoBookRepo) SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error) {
+	match := bookFilter(f)
+	match["$text"] = bson.M{"$search": keyword}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	pipeline = append(pipeline, q.Stages()...)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var books []*domain.ScoredBook
+	if err := cursor.All(ctx, &books); err != nil {
+		return nil, "", err
+	}
+	return paging.Page(q, books)
+}
+
+// bookFilter matches the books f selects.
+func bookFilter(f domain.BookFilter) bson.M {
+	filter := bson.M{}
+	if f.Genre != "" {
+		filter["genre"] = f.Genre
+	}
+	if f.Author != "" {
+		filter["author"] = f.Author
+	}
+	if f.Language != "" {
+		filter["language"] = f.Language
+	}
+	if f.MinRating > 0 {
+		filter["rating"] = bson.M{"$gte": f.MinRating}
+	}
+	return filter
 }
 
+const (
+	recommendLimit = 10
+	// The candidates scored for a recommendation: the best rated books
+	// sharing the author, genre or language, and the best text matches of
+	// the source's keywords.
+	attributeCandidates = 500
+	textCandidates      = 100
+	keywordCount        = 20
+)
+
+// RecommendBooks ranks the books most similar to bookID with
+// recommend.Rank. When nothing is similar, the top rated books are returned.
 func (r *mongoBookRepo) RecommendBooks(ctx context.Context, bookID string) ([]*domain.Book, error) {
-	return r.ListTopRated(ctx)
+	src, err := r.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[primitive.ObjectID]*domain.Book)
+	var shared bson.A
+	for _, attr := range []struct{ field, value string }{{"author", src.Author}, {"genre", src.Genre}, {"language", src.Language}} {
+		if attr.value != "" {
+			shared = append(shared, bson.M{attr.field: attr.value})
+		}
+	}
+	if len(shared) > 0 {
+		opts := options.Find().SetSort(bson.D{{Key: "rating", Value: -1}}).SetLimit(attributeCandidates)
+		books, err := r.findByFilterWithOpts(ctx, bson.M{"_id": bson.M{"$ne": src.ID}, "$or": shared}, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range books {
+			candidates[b.ID] = b
+		}
+	}
+	if keywords := recommend.Keywords(src, keywordCount); len(keywords) > 0 {
+		filter := bson.M{"_id": bson.M{"$ne": src.ID}, "$text": bson.M{"$search": strings.Join(keywords, " ")}}
+		opts := options.Find().SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).SetLimit(textCandidates)
+		books, err := r.findByFilterWithOpts(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range books {
+			candidates[b.ID] = b
+		}
+	}
+
+	list := make([]*domain.Book, 0, len(candidates))
+	for _, b := range candidates {
+		list = append(list, b)
+	}
+	if ranked := recommend.Rank(src, list, recommendLimit); len(ranked) > 0 {
+		return ranked, nil
+	}
+
+	top, err := r.ListTopRated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := top[:0]
+	for _, b := range top {
+		if b.ID != src.ID {
+			out = append(out, b)
+		}
+	}
+	return out, nil
 }
 
 func (r *mongoBookRepo) findByFilter(ctx context.Context, filter interface{}) ([]*domain.Book, error) {
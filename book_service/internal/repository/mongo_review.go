@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+type mongoReviewRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoReviewRepository(client *mongo.Client) ReviewRepository {
+	return &mongoReviewRepo{
+		collection: client.Database("readspace").Collection("reviews"),
+	}
+}
+
+func (r *mongoReviewRepo) Create(ctx context.Context, review *domain.Review) error {
+	review.ID = primitive.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, review)
+	return err
+}
+
+func (r *mongoReviewRepo) GetByID(ctx context.Context, id string) (*domain.Review, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid id format")
+	}
+	var review domain.Review
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&review); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *mongoReviewRepo) Update(ctx context.Context, review *domain.Review) error {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": review.ID}, bson.M{"$set": bson.M{
+		"rating":     review.Rating,
+		"text":       review.Text,
+		"updated_at": review.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoReviewRepo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *mongoReviewRepo) ListByBook(ctx context.Context, bookID primitive.ObjectID, q *paging.Query) ([]*domain.Review, string, error) {
+	filter, opts := q.Find(bson.M{"book_id": bookID})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []*domain.Review
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, "", err
+	}
+	return paging.Page(q, reviews)
+}
+
+func (r *mongoReviewRepo) Summary(ctx context.Context, bookID primitive.ObjectID) (domain.RatingSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"book_id": bookID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return domain.RatingSummary{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var sum domain.RatingSummary
+	if cursor.Next(ctx) {
+		var row struct {
+			Average float64 `bson:"average"`
+			Count   int     `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return sum, err
+		}
+		sum = domain.RatingSummary{Average: row.Average, Count: row.Count}
+	}
+	return sum, cursor.Err()
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+type ReviewRepository interface {
+	// Create fails with a duplicate key error when the user has already
+	// reviewed the book.
+	Create(ctx context.Context, review *domain.Review) error
+	GetByID(ctx context.Context, id string) (*domain.Review, error)
+	// Update stores the rating, text and update time of review.
+	Update(ctx context.Context, review *domain.Review) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// ListByBook returns one page of the reviews of bookID and the token of
+	// the next page.
+	ListByBook(ctx context.Context, bookID primitive.ObjectID, q *paging.Query) ([]*domain.Review, string, error)
+	Summary(ctx context.Context, bookID primitive.ObjectID) (domain.RatingSummary, error)
+}
@@ -0,0 +1,419 @@
+// Package search keeps an in-memory index of book titles and authors for
+// autocompletion and typo-tolerant search. Every book_service replica holds
+// its own copy: it is loaded from Mongo at start and kept current from the
+// book.created, book.updated and book.deleted events.
+package search
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+const (
+	FieldTitle  = "title"
+	FieldAuthor = "author"
+
+	// maxExpansions is how many known terms an unknown query term may
+	// stand for in Expand.
+	maxExpansions = 3
+	// minCompletion is the shortest query term Expand completes; shorter
+	// ones would stand for too much of the vocabulary.
+	minCompletion = 3
+)
+
+// Suggestion is a title or author completing what the user has typed.
+type Suggestion struct {
+	Text   string
+	Field  string
+	BookID string // only for titles; an author may have many books
+}
+
+type Index struct {
+	mu    sync.RWMutex
+	books map[string]entry
+	terms map[string]map[string]struct{} // term -> IDs of the books using it
+	grams map[string]map[string]struct{} // trigram -> terms containing it
+}
+
+type entry struct {
+	title, author string
+	terms         []string
+}
+
+func New() *Index {
+	return &Index{
+		books: make(map[string]entry),
+		terms: make(map[string]map[string]struct{}),
+		grams: make(map[string]map[string]struct{}),
+	}
+}
+
+// Lister is the part of repository.BookRepository that Load needs.
+type Lister interface {
+	ListAll(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error)
+}
+
+// Load indexes every stored book.
+func (x *Index) Load(ctx context.Context, repo Lister) error {
+	token := ""
+	for {
+		q, err := paging.New(paging.MaxSize, token, "", nil)
+		if err != nil {
+			return err
+		}
+		books, next, err := repo.ListAll(ctx, domain.BookFilter{}, q)
+		if err != nil {
+			return err
+		}
+		for _, b := range books {
+			x.Put(b.ID.Hex(), b.Title, b.Author)
+		}
+		if next == "" {
+			return nil
+		}
+		token = next
+	}
+}
+
+// Follow keeps the index current from the book events of every replica.
+func (x *Index) Follow(nc *nats.Conn) error {
+	put := func(e events.BookEvent) { x.Put(e.ID, e.Title, e.Author) }
+	if _, err := events.Subscribe(nc, events.BookCreated, put); err != nil {
+		return err
+	}
+	if _, err := events.Subscribe(nc, events.BookUpdated, put); err != nil {
+		return err
+	}
+	_, err := events.Subscribe(nc, events.BookDeleted, func(e events.BookEvent) { x.Remove(e.ID) })
+	return err
+}
+
+// Put adds the book or replaces its indexed title and author.
+func (x *Index) Put(id, title, author string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+	e := entry{title: title, author: author, terms: unique(Terms(title + " " + author))}
+	x.books[id] = e
+	for _, t := range e.terms {
+		ids, ok := x.terms[t]
+		if !ok {
+			ids = make(map[string]struct{})
+			x.terms[t] = ids
+			for _, g := range trigrams(t) {
+				if x.grams[g] == nil {
+					x.grams[g] = make(map[string]struct{})
+				}
+				x.grams[g][t] = struct{}{}
+			}
+		}
+		ids[id] = struct{}{}
+	}
+}
+
+func (x *Index) Remove(id string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(id)
+}
+
+func (x *Index) remove(id string) {
+	e, ok := x.books[id]
+	if !ok {
+		return
+	}
+	delete(x.books, id)
+	for _, t := range e.terms {
+		ids := x.terms[t]
+		delete(ids, id)
+		if len(ids) > 0 {
+			continue
+		}
+		delete(x.terms, t)
+		for _, g := range trigrams(t) {
+			delete(x.grams[g], t)
+			if len(x.grams[g]) == 0 {
+				delete(x.grams, g)
+			}
+		}
+	}
+}
+
+// Suggest returns up to limit titles and authors containing the words of
+// prefix, the last one possibly unfinished. Those starting with prefix come
+// first, then shorter ones. When nothing completes the last word, titles and
+// authors with a word a few typos away from it are suggested instead.
+func (x *Index) Suggest(prefix string, limit int) []Suggestion {
+	words := Terms(prefix)
+	if len(words) == 0 || limit <= 0 {
+		return nil
+	}
+	head, last := words[:len(words)-1], words[len(words)-1]
+	typed := strings.Join(words, " ")
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	candidates, fuzzy := x.completions(last), false
+	if len(candidates) == 0 {
+		candidates, fuzzy = x.similar(last), true
+	}
+
+	type ranked struct {
+		Suggestion
+		rank int
+		norm string
+	}
+	var out []ranked
+	seen := make(map[string]bool)
+	for _, t := range candidates {
+		for id := range x.terms[t] {
+			e := x.books[id]
+			for _, f := range [...]struct{ field, text string }{{FieldTitle, e.title}, {FieldAuthor, e.author}} {
+				fw := Terms(f.text)
+				if !slices.Contains(fw, t) || !containsAll(fw, head) {
+					continue
+				}
+				norm := strings.Join(fw, " ")
+				key := f.field + "\x00" + norm
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				r := ranked{Suggestion: Suggestion{Text: f.text, Field: f.field}, rank: 1, norm: norm}
+				if f.field == FieldTitle {
+					r.BookID = id
+				}
+				switch {
+				case fuzzy:
+					r.rank = 2
+				case strings.HasPrefix(norm, typed):
+					r.rank = 0
+				}
+				out = append(out, r)
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if len(a.norm) != len(b.norm) {
+			return len(a.norm) < len(b.norm)
+		}
+		if a.norm != b.norm {
+			return a.norm < b.norm
+		}
+		return a.Field > b.Field // titles before authors
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	res := make([]Suggestion, len(out))
+	for i, r := range out {
+		res[i] = r.Suggestion
+	}
+	return res
+}
+
+// Expand returns, for every plain term of the Mongo $text query the index
+// does not know, up to maxExpansions known terms it may have meant: words it
+// is the beginning of, then words a few typos away. Negated terms and quoted
+// phrases are never expanded, and no term of the query is returned.
+func (x *Index) Expand(query string) []string {
+	plain, other := split(query)
+	words := unique(plain)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var out []string
+	seen := make(map[string]bool, len(words)+len(other))
+	for _, w := range append(other, words...) {
+		seen[w] = true
+	}
+	for _, w := range words {
+		if _, known := x.terms[w]; known {
+			continue
+		}
+		var alts []string
+		if utf8.RuneCountInString(w) >= minCompletion {
+			alts = x.completions(w)
+		}
+		alts = append(alts, x.similar(w)...)
+		added := 0
+		for _, a := range alts {
+			if added == maxExpansions {
+				break
+			}
+			if !seen[a] {
+				seen[a] = true
+				out = append(out, a)
+				added++
+			}
+		}
+	}
+	return out
+}
+
+// completions returns the known terms starting with prefix, most used first.
+func (x *Index) completions(prefix string) []string {
+	var out []string
+	for t := range x.terms {
+		if strings.HasPrefix(t, prefix) {
+			out = append(out, t)
+		}
+	}
+	x.byUse(out, nil)
+	return out
+}
+
+// similar returns the known terms within maxEdits(term) edits of term,
+// closest and then most used first. Candidates are found through shared
+// trigrams: a single edit changes at most three of them.
+func (x *Index) similar(term string) []string {
+	limit := maxEdits(term)
+	if limit == 0 {
+		return nil
+	}
+	grams := trigrams(term)
+	shared := make(map[string]int)
+	for _, g := range grams {
+		for t := range x.grams[g] {
+			shared[t]++
+		}
+	}
+	dist := make(map[string]int)
+	var out []string
+	for t, n := range shared {
+		if n < len(grams)-3*limit {
+			continue
+		}
+		if d := distance(term, t); d <= limit {
+			dist[t] = d
+			out = append(out, t)
+		}
+	}
+	x.byUse(out, dist)
+	return out
+}
+
+// byUse sorts terms by dist, then by the number of books using them.
+func (x *Index) byUse(terms []string, dist map[string]int) {
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := terms[i], terms[j]
+		if dist[a] != dist[b] {
+			return dist[a] < dist[b]
+		}
+		if na, nb := len(x.terms[a]), len(x.terms[b]); na != nb {
+			return na > nb
+		}
+		return a < b
+	})
+}
+
+// maxEdits is how many typos a term of its length may contain.
+func maxEdits(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// split returns the terms of a Mongo $text query that are searched as
+// written: plain, and those negated with a leading "-" or inside a quoted
+// phrase.
+func split(query string) (plain, other []string) {
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			other = append(other, Terms(part)...)
+			continue
+		}
+		for _, token := range strings.Fields(part) {
+			if strings.HasPrefix(token, "-") {
+				other = append(other, Terms(token)...)
+			} else {
+				plain = append(plain, Terms(token)...)
+			}
+		}
+	}
+	return plain, other
+}
+
+// Terms splits s into lower-case words the way the Mongo text index does.
+func Terms(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func trigrams(term string) []string {
+	r := []rune("  " + term + " ")
+	out := make([]string, 0, len(r)-2)
+	for i := 0; i+3 <= len(r); i++ {
+		out = append(out, string(r[i:i+3]))
+	}
+	return unique(out)
+}
+
+// distance is the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func unique(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := s[:0:0]
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// containsAll reports whether every one of want is among words.
+func containsAll(words, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(words, w) {
+			return false
+		}
+	}
+	return true
+}
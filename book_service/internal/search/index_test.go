@@ -0,0 +1,75 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func catalog() *Index {
+	x := New()
+	x.Put("1", "The Hobbit", "J.R.R. Tolkien")
+	x.Put("2", "The Lord of the Rings", "J.R.R. Tolkien")
+	x.Put("3", "Harry Potter and the Philosopher's Stone", "J.K. Rowling")
+	x.Put("4", "Harvest", "Jim Crace")
+	x.Put("5", "Мастер и Маргарита", "Михаил Булгаков")
+	return x
+}
+
+func TestSuggest_CompletesAndCorrects(t *testing.T) {
+	x := catalog()
+
+	got := x.Suggest("har", 10)
+	if len(got) != 2 || got[0].Text != "Harvest" || got[0].BookID != "4" || got[1].BookID != "3" {
+		t.Errorf("har: expected Harvest, then Harry Potter, got %+v", got)
+	}
+	if got := x.Suggest("lord of the r", 10); len(got) != 1 || got[0].BookID != "2" {
+		t.Errorf("lord of the r: expected The Lord of the Rings, got %+v", got)
+	}
+	got = x.Suggest("tolkin", 10)
+	if len(got) != 1 || got[0].Field != FieldAuthor || got[0].Text != "J.R.R. Tolkien" || got[0].BookID != "" {
+		t.Errorf("tolkin: expected the author once, got %+v", got)
+	}
+	if got := x.Suggest("булгак", 10); len(got) != 1 || got[0].Text != "Михаил Булгаков" {
+		t.Errorf("булгак: expected Михаил Булгаков, got %+v", got)
+	}
+	if got := x.Suggest("h", 1); len(got) != 1 {
+		t.Errorf("expected the limit to apply, got %+v", got)
+	}
+}
+
+func TestExpand_KeepsKnownTermsAndMapsUnknownOnes(t *testing.T) {
+	x := catalog()
+
+	if got := x.Expand("Hobbit"); len(got) != 0 {
+		t.Errorf("known term must not be expanded, got %v", got)
+	}
+	if got := x.Expand("tolkin har"); !slices.Equal(got, []string{"tolkien", "harry", "harvest"}) {
+		t.Errorf("unexpected expansion %v", got)
+	}
+}
+
+func TestExpand_LeavesNegatedTermsAndPhrasesAlone(t *testing.T) {
+	x := catalog()
+
+	if got := x.Expand("harvest -harr"); len(got) != 0 {
+		t.Errorf("negated term must not be expanded, got %v", got)
+	}
+	if got := x.Expand(`"tolkin hobit" lrd`); !slices.Equal(got, []string{"lord"}) {
+		t.Errorf("phrase terms must not be expanded, got %v", got)
+	}
+	if got := x.Expand("har -harry"); !slices.Equal(got, []string{"harvest"}) {
+		t.Errorf("a negated term must not come back as an expansion, got %v", got)
+	}
+}
+
+func TestPutRemove_ReplaceIndexedTerms(t *testing.T) {
+	x := catalog()
+	x.Put("1", "There and Back Again", "J.R.R. Tolkien")
+	if got := x.Suggest("hobb", 10); len(got) != 0 {
+		t.Errorf("renamed book must no longer be suggested, got %+v", got)
+	}
+	x.Remove("4")
+	if got := x.Expand("har"); !slices.Equal(got, []string{"harry"}) {
+		t.Errorf("removed book's terms must be dropped, got %v", got)
+	}
+}
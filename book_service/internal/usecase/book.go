@@ -2,14 +2,24 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/catalog"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/isbn"
 	"github.com/OshakbayAigerim/read_space/book_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/search"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type BookUseCase interface {
 	CreateBook(ctx context.Context, book *domain.Book) (*domain.Book, error)
 	GetBookByID(ctx context.Context, id string) (*domain.Book, error)
-	ListBooks(ctx context.Context) ([]*domain.Book, error)
+	GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error)
+	ListBooks(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error)
 	UpdateBook(ctx context.Context, book *domain.Book) (*domain.Book, error)
 	DeleteBook(ctx context.Context, id string) error
 	ListBooksByGenre(ctx context.Context, genre string) ([]*domain.Book, error)
@@ -17,34 +27,75 @@ type BookUseCase interface {
 	ListBooksByLanguage(ctx context.Context, language string) ([]*domain.Book, error)
 	ListTopRated(ctx context.Context) ([]*domain.Book, error)
 	ListNewArrivals(ctx context.Context) ([]*domain.Book, error)
-	SearchBooks(ctx context.Context, keyword string) ([]*domain.Book, error)
+	SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error)
+	SuggestBooks(ctx context.Context, prefix string, limit int) []search.Suggestion
+	BrowseBooks(ctx context.Context, f domain.BrowseFilter, q *paging.Query) (*domain.BrowseResult, error)
 	RecommendBooks(ctx context.Context, bookID string) ([]*domain.Book, error)
+	ImportBooks(ctx context.Context, r *catalog.Reader, report func(ImportRow)) error
+	ExportBooks(ctx context.Context, f domain.BookFilter, w *catalog.Writer) error
 }
 
+// ErrDuplicateISBN is returned when another book already has the ISBN.
+var ErrDuplicateISBN = errors.New("a book with this ISBN already exists")
+
 type bookUseCase struct {
-	repo repository.BookRepository
+	repo  repository.BookRepository
+	index *search.Index
 }
 
-func NewBookUseCase(r repository.BookRepository) BookUseCase {
+func NewBookUseCase(r repository.BookRepository, index *search.Index) BookUseCase {
 	return &bookUseCase{
-		repo: r,
+		repo:  r,
+		index: index,
 	}
 }
 
+// CreateBook starts every book unrated: ratings come from reviews only.
 func (u *bookUseCase) CreateBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
-	return u.repo.Create(ctx, book)
+	if err := resolveISBN(book); err != nil {
+		return nil, err
+	}
+	book.Rating, book.RatingCount = 0, 0
+	created, err := u.repo.Create(ctx, book)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicateISBN
+	}
+	return created, err
 }
 
 func (u *bookUseCase) GetBookByID(ctx context.Context, id string) (*domain.Book, error) {
 	return u.repo.GetByID(ctx, id)
 }
 
-func (u *bookUseCase) ListBooks(ctx context.Context) ([]*domain.Book, error) {
-	return u.repo.ListAll(ctx)
+// GetBookByISBN accepts either form of the ISBN, with or without hyphens.
+func (u *bookUseCase) GetBookByISBN(ctx context.Context, s string) (*domain.Book, error) {
+	isbn13, err := isbn.Canonical(s)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetByISBN(ctx, isbn13)
+}
+
+func (u *bookUseCase) ListBooks(ctx context.Context, f domain.BookFilter, q *paging.Query) ([]*domain.Book, string, error) {
+	return u.repo.ListAll(ctx, f, q)
 }
 
 func (u *bookUseCase) UpdateBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
-	return u.repo.Update(ctx, book)
+	if err := resolveISBN(book); err != nil {
+		return nil, err
+	}
+	updated, err := u.repo.Update(ctx, book)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicateISBN
+	}
+	return updated, err
+}
+
+// resolveISBN validates the ISBNs of book and fills in the missing form.
+func resolveISBN(book *domain.Book) error {
+	var err error
+	book.ISBN10, book.ISBN13, err = isbn.Resolve(book.ISBN10, book.ISBN13)
+	return err
 }
 
 func (u *bookUseCase) DeleteBook(ctx context.Context, id string) error {
@@ -71,8 +122,22 @@ func (u *bookUseCase) ListNewArrivals(ctx context.Context) ([]*domain.Book, erro
 	return u.repo.ListNewArrivals(ctx)
 }
 
-func (u *bookUseCase) SearchBooks(ctx context.Context, keyword string) ([]*domain.Book, error) {
-	return u.repo.SearchBooks(ctx, keyword)
+// SearchBooks also searches for the titles and authors the keyword may have
+// meant: the words the search index expands unfinished and misspelt ones to
+// are added to the keyword, whose phrases and negations are kept as written.
+func (u *bookUseCase) SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error) {
+	if terms := u.index.Expand(keyword); len(terms) > 0 {
+		keyword += " " + strings.Join(terms, " ")
+	}
+	return u.repo.SearchBooks(ctx, keyword, f, q)
+}
+
+func (u *bookUseCase) SuggestBooks(ctx context.Context, prefix string, limit int) []search.Suggestion {
+	return u.index.Suggest(prefix, limit)
+}
+
+func (u *bookUseCase) BrowseBooks(ctx context.Context, f domain.BrowseFilter, q *paging.Query) (*domain.BrowseResult, error) {
+	return u.repo.Browse(ctx, f, q)
 }
 
 func (u *bookUseCase) RecommendBooks(ctx context.Context, bookID string) ([]*domain.Book, error) {
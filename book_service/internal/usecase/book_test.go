@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/search"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
+
+// searchRecorder records the keyword SearchBooks reaches the database with.
+type searchRecorder struct {
+	repository.BookRepository
+	keyword string
+}
+
+func (r *searchRecorder) SearchBooks(ctx context.Context, keyword string, f domain.BookFilter, q *paging.Query) ([]*domain.ScoredBook, string, error) {
+	r.keyword = keyword
+	return nil, "", nil
+}
+
+func TestSearchBooks_KeepsPhrasesAndNegations(t *testing.T) {
+	index := search.New()
+	index.Put("1", "Harry Potter and the Chamber of Secrets", "J.K. Rowling")
+	index.Put("2", "Harvest", "Jim Crace")
+	repo := &searchRecorder{}
+	uc := NewBookUseCase(repo, index)
+	q, _ := paging.New(0, "", "", domain.SearchSortFields)
+
+	cases := map[string]string{
+		"harry -chamber":         "harry -chamber",
+		`"harry potter" rowlng`:  `"harry potter" rowlng rowling`,
+		`harv -"chamber secrts"`: `harv -"chamber secrts" harvest`,
+	}
+	for keyword, want := range cases {
+		if _, _, err := uc.SearchBooks(context.Background(), keyword, domain.BookFilter{}, q); err != nil {
+			t.Fatalf("search %q: %v", keyword, err)
+		}
+		if repo.keyword != want {
+			t.Errorf("search %q: expected the database to get %q, got %q", keyword, want, repo.keyword)
+		}
+	}
+}
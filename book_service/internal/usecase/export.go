@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/catalog"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+// ExportBooks writes every book matching f to w as it is read from the
+// database, so a catalog of any size is never held in memory. The caller
+// closes w.
+func (u *bookUseCase) ExportBooks(ctx context.Context, f domain.BookFilter, w *catalog.Writer) error {
+	return u.repo.Each(ctx, f, w.Write)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/catalog"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+)
+
+// ImportBatchSize is the number of rows written to Mongo at once.
+const ImportBatchSize = 500
+
+var (
+	ErrTitleRequired  = errors.New("title is required")
+	ErrAuthorRequired = errors.New("author is required")
+	ErrNegativePrice  = errors.New("price must not be negative")
+	ErrNegativePages  = errors.New("pages must not be negative")
+)
+
+// ImportRow is the outcome of one row of an import: the book it created or
+// updated, or why it was skipped.
+type ImportRow struct {
+	Line    int
+	Book    *domain.Book
+	Created bool
+	Err     error
+}
+
+// ImportBooks upserts every book read from r: a row updates the book with
+// its ISBN-13, or without one the book with its exact title and author, and
+// creates a book otherwise. Ratings are left alone. Each row is reported
+// once, invalid rows as soon as they are read and the others when their
+// batch is written; only a read or database failure stops the import.
+func (u *bookUseCase) ImportBooks(ctx context.Context, r *catalog.Reader, report func(ImportRow)) error {
+	var (
+		batch []*domain.Book
+		lines []int
+		keys  = make(map[string]bool)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := u.repo.ResolveIDs(ctx, batch); err != nil {
+			return err
+		}
+		created := make([]bool, len(batch))
+		for i, b := range batch {
+			created[i] = b.ID.IsZero()
+		}
+		failed, err := u.repo.BulkSave(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for i, b := range batch {
+			row := ImportRow{Line: lines[i], Book: b, Created: created[i]}
+			if err := failed[i]; err != nil {
+				row.Book, row.Err = nil, err
+				if mongo.IsDuplicateKeyError(err) {
+					row.Err = ErrDuplicateISBN
+				}
+			}
+			report(row)
+		}
+		batch, lines = batch[:0], lines[:0]
+		clear(keys)
+		return nil
+	}
+
+	for {
+		line, book, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		var rowErr *catalog.RowError
+		if errors.As(err, &rowErr) {
+			report(ImportRow{Line: line, Err: rowErr.Err})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := validateImport(book); err != nil {
+			report(ImportRow{Line: line, Err: err})
+			continue
+		}
+
+		// Rows of one batch are resolved together, so a book repeated in the
+		// file goes to the next batch to be updated rather than created twice.
+		key := importKey(book)
+		if keys[key] {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		keys[key] = true
+		batch, lines = append(batch, book), append(lines, line)
+		if len(batch) == ImportBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+func validateImport(book *domain.Book) error {
+	switch {
+	case book.Title == "":
+		return ErrTitleRequired
+	case book.Author == "":
+		return ErrAuthorRequired
+	case book.Price < 0:
+		return ErrNegativePrice
+	case book.Pages < 0:
+		return ErrNegativePages
+	}
+	return resolveISBN(book)
+}
+
+// importKey identifies the book a row upserts, as ResolveIDs matches it.
+func importKey(book *domain.Book) string {
+	if book.ISBN13 != "" {
+		return book.ISBN13
+	}
+	return book.Title + "\x00" + book.Author
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+	MaxReviewLength = 5000
+)
+
+var (
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5")
+	ErrReviewTooLong   = errors.New("review text is too long")
+	ErrNotBookOwner    = errors.New("only users who own the book can review it")
+	ErrAlreadyReviewed = errors.New("book already reviewed by this user")
+	ErrNotReviewer     = errors.New("only the author of a review can change it")
+)
+
+// ReviewUseCase keeps the reviews of books and, after every change, the
+// book's Rating and RatingCount computed from them. The changes return the
+// book as it is afterwards.
+type ReviewUseCase interface {
+	CreateReview(ctx context.Context, bookID, userID string, rating int, text string) (*domain.Review, *domain.Book, error)
+	UpdateReview(ctx context.Context, id, userID string, rating int, text string) (*domain.Review, *domain.Book, error)
+	// DeleteReview lets admins delete any review.
+	DeleteReview(ctx context.Context, id, userID string, admin bool) (*domain.Review, *domain.Book, error)
+	ListReviews(ctx context.Context, bookID string, q *paging.Query) ([]*domain.Review, string, error)
+}
+
+type reviewUseCase struct {
+	reviews   repository.ReviewRepository
+	books     repository.BookRepository
+	libClient userlibpb.UserLibraryServiceClient
+}
+
+func NewReviewUseCase(r repository.ReviewRepository, b repository.BookRepository, lc userlibpb.UserLibraryServiceClient) ReviewUseCase {
+	return &reviewUseCase{reviews: r, books: b, libClient: lc}
+}
+
+// CreateReview reviews the book as userID, who must have it in their
+// library.
+func (u *reviewUseCase) CreateReview(ctx context.Context, bookID, userID string, rating int, text string) (*domain.Review, *domain.Book, error) {
+	text, err := checkReview(rating, text)
+	if err != nil {
+		return nil, nil, err
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	book, err := u.books.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.requireOwner(ctx, userID, bookID); err != nil {
+		return nil, nil, err
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	review := &domain.Review{
+		BookID:    book.ID,
+		UserID:    uid,
+		Rating:    rating,
+		Text:      text,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.reviews.Create(ctx, review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, nil, ErrAlreadyReviewed
+		}
+		return nil, nil, err
+	}
+	book, err = u.refresh(ctx, book.ID)
+	return review, book, err
+}
+
+func (u *reviewUseCase) UpdateReview(ctx context.Context, id, userID string, rating int, text string) (*domain.Review, *domain.Book, error) {
+	text, err := checkReview(rating, text)
+	if err != nil {
+		return nil, nil, err
+	}
+	review, err := u.reviews.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if review.UserID.Hex() != userID {
+		return nil, nil, ErrNotReviewer
+	}
+	review.Rating = rating
+	review.Text = text
+	review.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	if err := u.reviews.Update(ctx, review); err != nil {
+		return nil, nil, err
+	}
+	book, err := u.refresh(ctx, review.BookID)
+	return review, book, err
+}
+
+func (u *reviewUseCase) DeleteReview(ctx context.Context, id, userID string, admin bool) (*domain.Review, *domain.Book, error) {
+	review, err := u.reviews.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if review.UserID.Hex() != userID && !admin {
+		return nil, nil, ErrNotReviewer
+	}
+	if err := u.reviews.Delete(ctx, review.ID); err != nil {
+		return nil, nil, err
+	}
+	book, err := u.refresh(ctx, review.BookID)
+	return review, book, err
+}
+
+func (u *reviewUseCase) ListReviews(ctx context.Context, bookID string, q *paging.Query) ([]*domain.Review, string, error) {
+	bid, err := primitive.ObjectIDFromHex(bookID)
+	if err != nil {
+		return nil, "", err
+	}
+	return u.reviews.ListByBook(ctx, bid, q)
+}
+
+func checkReview(rating int, text string) (string, error) {
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return "", ErrInvalidRating
+	}
+	text = strings.TrimSpace(text)
+	if len(text) > MaxReviewLength {
+		return "", ErrReviewTooLong
+	}
+	return text, nil
+}
+
+func (u *reviewUseCase) requireOwner(ctx context.Context, userID, bookID string) error {
+	resp, err := u.libClient.ListUserBooks(ctx, &userlibpb.ListUserBooksRequest{UserId: userID})
+	if err != nil {
+		return err
+	}
+	for _, e := range resp.Entries {
+		if e.BookId == bookID {
+			return nil
+		}
+	}
+	return ErrNotBookOwner
+}
+
+// refresh recomputes the rating of the book from all of its reviews rather
+// than adjusting it by the change, so concurrent reviews cannot make it drift.
+// It returns a nil book when the book has been deleted meanwhile.
+func (u *reviewUseCase) refresh(ctx context.Context, bookID primitive.ObjectID) (*domain.Book, error) {
+	sum, err := u.reviews.Summary(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	rating := float32(math.Round(sum.Average*100) / 100)
+	book, err := u.books.SetRating(ctx, bookID, rating, sum.Count)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return book, err
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+
+	"github.com/OshakbayAigerim/read_space/book_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/book_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+)
+
+type fakeReviews struct {
+	repository.ReviewRepository
+	byID map[primitive.ObjectID]*domain.Review
+}
+
+func (r *fakeReviews) Create(ctx context.Context, review *domain.Review) error {
+	for _, o := range r.byID {
+		if o.BookID == review.BookID && o.UserID == review.UserID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	review.ID = primitive.NewObjectID()
+	r.byID[review.ID] = review
+	return nil
+}
+
+func (r *fakeReviews) GetByID(ctx context.Context, id string) (*domain.Review, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	if review, ok := r.byID[oid]; ok {
+		copied := *review
+		return &copied, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *fakeReviews) Update(ctx context.Context, review *domain.Review) error {
+	r.byID[review.ID] = review
+	return nil
+}
+
+func (r *fakeReviews) Delete(ctx context.Context, id primitive.ObjectID) error {
+	delete(r.byID, id)
+	return nil
+}
+
+func (r *fakeReviews) Summary(ctx context.Context, bookID primitive.ObjectID) (domain.RatingSummary, error) {
+	var sum domain.RatingSummary
+	total := 0
+	for _, review := range r.byID {
+		if review.BookID == bookID {
+			sum.Count++
+			total += review.Rating
+		}
+	}
+	if sum.Count > 0 {
+		sum.Average = float64(total) / float64(sum.Count)
+	}
+	return sum, nil
+}
+
+type fakeBooks struct {
+	repository.BookRepository
+	book *domain.Book
+}
+
+func (r *fakeBooks) GetByID(ctx context.Context, id string) (*domain.Book, error) {
+	if id != r.book.ID.Hex() {
+		return nil, mongo.ErrNoDocuments
+	}
+	return r.book, nil
+}
+
+func (r *fakeBooks) SetRating(ctx context.Context, id primitive.ObjectID, rating float32, count int) (*domain.Book, error) {
+	r.book.Rating, r.book.RatingCount = rating, count
+	return r.book, nil
+}
+
+// fakeLibrary lets every user own the books listed for them.
+type fakeLibrary struct {
+	userlibpb.UserLibraryServiceClient
+	owned map[string][]string
+}
+
+func (f *fakeLibrary) ListUserBooks(ctx context.Context, req *userlibpb.ListUserBooksRequest, opts ...grpc.CallOption) (*userlibpb.ListUserBooksResponse, error) {
+	resp := &userlibpb.ListUserBooksResponse{}
+	for _, b := range f.owned[req.UserId] {
+		resp.Entries = append(resp.Entries, &userlibpb.UserBook{UserId: req.UserId, BookId: b})
+	}
+	return resp, nil
+}
+
+func TestReviews_OwnersOnlyAndRatingRecomputed(t *testing.T) {
+	ctx := context.Background()
+	book := &domain.Book{ID: primitive.NewObjectID(), Rating: 4.9}
+	ann, bob, eve := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+	books := &fakeBooks{book: book}
+	lib := &fakeLibrary{owned: map[string][]string{ann: {book.ID.Hex()}, bob: {book.ID.Hex()}}}
+	uc := NewReviewUseCase(&fakeReviews{byID: map[primitive.ObjectID]*domain.Review{}}, books, lib)
+
+	if _, _, err := uc.CreateReview(ctx, book.ID.Hex(), eve, 5, "great"); !errors.Is(err, ErrNotBookOwner) {
+		t.Fatalf("expected ErrNotBookOwner, got %v", err)
+	}
+	if _, _, err := uc.CreateReview(ctx, book.ID.Hex(), ann, 6, ""); !errors.Is(err, ErrInvalidRating) {
+		t.Fatalf("expected ErrInvalidRating, got %v", err)
+	}
+
+	first, _, err := uc.CreateReview(ctx, book.ID.Hex(), ann, 5, "  great  ")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if first.Text != "great" {
+		t.Errorf("expected trimmed text, got %q", first.Text)
+	}
+	if _, _, err := uc.CreateReview(ctx, book.ID.Hex(), ann, 1, ""); !errors.Is(err, ErrAlreadyReviewed) {
+		t.Fatalf("expected ErrAlreadyReviewed, got %v", err)
+	}
+	_, got, err := uc.CreateReview(ctx, book.ID.Hex(), bob, 2, "")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got.Rating != 3.5 || got.RatingCount != 2 {
+		t.Errorf("expected rating 3.5 of 2, got %v of %d", got.Rating, got.RatingCount)
+	}
+
+	if _, _, err := uc.UpdateReview(ctx, first.ID.Hex(), bob, 1, ""); !errors.Is(err, ErrNotReviewer) {
+		t.Fatalf("expected ErrNotReviewer, got %v", err)
+	}
+	if _, got, err = uc.UpdateReview(ctx, first.ID.Hex(), ann, 4, ""); err != nil || got.Rating != 3 {
+		t.Fatalf("expected rating 3 after the edit, got %v (%v)", got.Rating, err)
+	}
+
+	if _, _, err := uc.DeleteReview(ctx, first.ID.Hex(), bob, false); !errors.Is(err, ErrNotReviewer) {
+		t.Fatalf("expected ErrNotReviewer, got %v", err)
+	}
+	if _, got, err = uc.DeleteReview(ctx, first.ID.Hex(), eve, true); err != nil || got.Rating != 2 || got.RatingCount != 1 {
+		t.Fatalf("expected an admin delete to leave rating 2 of 1, got %v of %d (%v)", got.Rating, got.RatingCount, err)
+	}
+
+	q, _ := paging.New(0, "", "", domain.ReviewSortFields)
+	if _, _, err := uc.ListReviews(ctx, "bad", q); err == nil {
+		t.Error("expected an invalid book id to fail")
+	}
+}
@@ -21,7 +21,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Модель книги
 type Book struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -34,6 +33,13 @@ type Book struct {
 	Price         float32                `protobuf:"fixed32,8,opt,name=price,proto3" json:"price,omitempty"`
 	Pages         int32                  `protobuf:"varint,9,opt,name=pages,proto3" json:"pages,omitempty"`
 	PublishedDate string                 `protobuf:"bytes,10,opt,name=published_date,json=publishedDate,proto3" json:"published_date,omitempty"`
+	// rating is the average of the book's reviews and rating_count their
+	// number; both are read-only.
+	RatingCount int32 `protobuf:"varint,11,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	// Either ISBN may be given, with or without hyphens; the other is filled
+	// in. Books are stored with both normalized, and the ISBN-13 is unique.
+	Isbn10        string `protobuf:"bytes,12,opt,name=isbn10,proto3" json:"isbn10,omitempty"`
+	Isbn13        string `protobuf:"bytes,13,opt,name=isbn13,proto3" json:"isbn13,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -138,7 +144,27 @@ func (x *Book) GetPublishedDate() string {
 	return ""
 }
 
-// Общие сообщения
+func (x *Book) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Book) GetIsbn10() string {
+	if x != nil {
+		return x.Isbn10
+	}
+	return ""
+}
+
+func (x *Book) GetIsbn13() string {
+	if x != nil {
+		return x.Isbn13
+	}
+	return ""
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -219,9 +245,11 @@ func (x *BookResponse) GetBook() *Book {
 	return nil
 }
 
+// next_page_token is only set by ListAllBooks; it is empty on the last page.
 type BookList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -263,6 +291,13 @@ func (x *BookList) GetBooks() []*Book {
 	return nil
 }
 
+func (x *BookList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type BookID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -307,6 +342,51 @@ func (x *BookID) GetId() string {
 	return ""
 }
 
+// ISBNRequest looks a book up by its ISBN-10 or ISBN-13.
+type ISBNRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isbn          string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ISBNRequest) Reset() {
+	*x = ISBNRequest{}
+	mi := &file_proto_book_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ISBNRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ISBNRequest) ProtoMessage() {}
+
+func (x *ISBNRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ISBNRequest.ProtoReflect.Descriptor instead.
+func (*ISBNRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ISBNRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
 type CreateBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
@@ -316,7 +396,7 @@ type CreateBookRequest struct {
 
 func (x *CreateBookRequest) Reset() {
 	*x = CreateBookRequest{}
-	mi := &file_proto_book_proto_msgTypes[5]
+	mi := &file_proto_book_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -328,7 +408,7 @@ func (x *CreateBookRequest) String() string {
 func (*CreateBookRequest) ProtoMessage() {}
 
 func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[5]
+	mi := &file_proto_book_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +421,7 @@ func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
 func (*CreateBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{5}
+	return file_proto_book_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CreateBookRequest) GetBook() *Book {
@@ -360,7 +440,7 @@ type UpdateBookRequest struct {
 
 func (x *UpdateBookRequest) Reset() {
 	*x = UpdateBookRequest{}
-	mi := &file_proto_book_proto_msgTypes[6]
+	mi := &file_proto_book_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -372,7 +452,7 @@ func (x *UpdateBookRequest) String() string {
 func (*UpdateBookRequest) ProtoMessage() {}
 
 func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[6]
+	mi := &file_proto_book_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,7 +465,7 @@ func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{6}
+	return file_proto_book_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateBookRequest) GetBook() *Book {
@@ -404,7 +484,7 @@ type GenreRequest struct {
 
 func (x *GenreRequest) Reset() {
 	*x = GenreRequest{}
-	mi := &file_proto_book_proto_msgTypes[7]
+	mi := &file_proto_book_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +496,7 @@ func (x *GenreRequest) String() string {
 func (*GenreRequest) ProtoMessage() {}
 
 func (x *GenreRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[7]
+	mi := &file_proto_book_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +509,7 @@ func (x *GenreRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenreRequest.ProtoReflect.Descriptor instead.
 func (*GenreRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{7}
+	return file_proto_book_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GenreRequest) GetGenre() string {
@@ -448,7 +528,7 @@ type AuthorRequest struct {
 
 func (x *AuthorRequest) Reset() {
 	*x = AuthorRequest{}
-	mi := &file_proto_book_proto_msgTypes[8]
+	mi := &file_proto_book_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -460,7 +540,7 @@ func (x *AuthorRequest) String() string {
 func (*AuthorRequest) ProtoMessage() {}
 
 func (x *AuthorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[8]
+	mi := &file_proto_book_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +553,7 @@ func (x *AuthorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthorRequest.ProtoReflect.Descriptor instead.
 func (*AuthorRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{8}
+	return file_proto_book_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AuthorRequest) GetAuthor() string {
@@ -492,7 +572,7 @@ type LanguageRequest struct {
 
 func (x *LanguageRequest) Reset() {
 	*x = LanguageRequest{}
-	mi := &file_proto_book_proto_msgTypes[9]
+	mi := &file_proto_book_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -504,7 +584,7 @@ func (x *LanguageRequest) String() string {
 func (*LanguageRequest) ProtoMessage() {}
 
 func (x *LanguageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[9]
+	mi := &file_proto_book_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +597,7 @@ func (x *LanguageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LanguageRequest.ProtoReflect.Descriptor instead.
 func (*LanguageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{9}
+	return file_proto_book_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *LanguageRequest) GetLanguage() string {
@@ -527,16 +607,26 @@ func (x *LanguageRequest) GetLanguage() string {
 	return ""
 }
 
+// SearchRequest runs a full-text search over title, author and description.
+// Results are ordered by relevance unless sort names a ListBooksRequest sort
+// field; "-relevance" is the default.
 type SearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Keyword       string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	Genre         string                 `protobuf:"bytes,5,opt,name=genre,proto3" json:"genre,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	Language      string                 `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	MinRating     float32                `protobuf:"fixed32,8,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
-	mi := &file_proto_book_proto_msgTypes[10]
+	mi := &file_proto_book_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -548,7 +638,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_book_proto_msgTypes[10]
+	mi := &file_proto_book_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,7 +651,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_proto_book_proto_rawDescGZIP(), []int{10}
+	return file_proto_book_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SearchRequest) GetKeyword() string {
@@ -571,62 +661,1602 @@ func (x *SearchRequest) GetKeyword() string {
 	return ""
 }
 
-var File_proto_book_proto protoreflect.FileDescriptor
+func (x *SearchRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
 
-const file_proto_book_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/book.proto\x12\x04book\"\x83\x02\n" +
-	"\x04Book\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06author\x18\x03 \x01(\tR\x06author\x12\x14\n" +
-	"\x05genre\x18\x04 \x01(\tR\x05genre\x12\x1a\n" +
-	"\blanguage\x18\x05 \x01(\tR\blanguage\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06rating\x18\a \x01(\x02R\x06rating\x12\x14\n" +
-	"\x05price\x18\b \x01(\x02R\x05price\x12\x14\n" +
-	"\x05pages\x18\t \x01(\x05R\x05pages\x12%\n" +
-	"\x0epublished_date\x18\n" +
-	" \x01(\tR\rpublishedDate\"\a\n" +
-	"\x05Empty\".\n" +
-	"\fBookResponse\x12\x1e\n" +
-	"\x04book\x18\x01 \x01(\v2\n" +
-	".book.BookR\x04book\",\n" +
-	"\bBookList\x12 \n" +
-	"\x05books\x18\x01 \x03(\v2\n" +
-	".book.BookR\x05books\"\x18\n" +
-	"\x06BookID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
-	"\x11CreateBookRequest\x12\x1e\n" +
-	"\x04book\x18\x01 \x01(\v2\n" +
-	".book.BookR\x04book\"3\n" +
-	"\x11UpdateBookRequest\x12\x1e\n" +
-	"\x04book\x18\x01 \x01(\v2\n" +
-	".book.BookR\x04book\"$\n" +
-	"\fGenreRequest\x12\x14\n" +
-	"\x05genre\x18\x01 \x01(\tR\x05genre\"'\n" +
-	"\rAuthorRequest\x12\x16\n" +
-	"\x06author\x18\x01 \x01(\tR\x06author\"-\n" +
-	"\x0fLanguageRequest\x12\x1a\n" +
-	"\blanguage\x18\x01 \x01(\tR\blanguage\")\n" +
-	"\rSearchRequest\x12\x18\n" +
-	"\akeyword\x18\x01 \x01(\tR\akeyword2\xfc\x04\n" +
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetMinRating() float32 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Book          *Book                  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_book_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchResult) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_book_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SuggestRequest completes what the user has typed so far; the last word may
+// be unfinished or misspelt. limit defaults to 10 and is capped at 50.
+type SuggestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_proto_book_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Suggestion is a title (with its book_id) or an author; field says which.
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	BookId        string                 `protobuf:"bytes,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_proto_book_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Suggestion) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type SuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_proto_book_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// BrowseRequest combines catalog filters. Values of one repeated filter are
+// alternatives, and every filter given must match; zero bounds are open.
+// The published years bound the year of published_date, both inclusive.
+// Paging and sort work as in ListBooksRequest.
+type BrowseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genres        []string               `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	Languages     []string               `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	Authors       []string               `protobuf:"bytes,3,rep,name=authors,proto3" json:"authors,omitempty"`
+	MinPrice      float32                `protobuf:"fixed32,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      float32                `protobuf:"fixed32,5,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MinRating     float32                `protobuf:"fixed32,6,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`
+	MinPages      int32                  `protobuf:"varint,7,opt,name=min_pages,json=minPages,proto3" json:"min_pages,omitempty"`
+	MaxPages      int32                  `protobuf:"varint,8,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`
+	PublishedFrom int32                  `protobuf:"varint,9,opt,name=published_from,json=publishedFrom,proto3" json:"published_from,omitempty"`
+	PublishedTo   int32                  `protobuf:"varint,10,opt,name=published_to,json=publishedTo,proto3" json:"published_to,omitempty"`
+	PageSize      int32                  `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,13,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseRequest) Reset() {
+	*x = BrowseRequest{}
+	mi := &file_proto_book_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseRequest) ProtoMessage() {}
+
+func (x *BrowseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseRequest.ProtoReflect.Descriptor instead.
+func (*BrowseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BrowseRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *BrowseRequest) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *BrowseRequest) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *BrowseRequest) GetMinPrice() float32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetMaxPrice() float32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetMinRating() float32 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetMinPages() int32 {
+	if x != nil {
+		return x.MinPages
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetPublishedFrom() int32 {
+	if x != nil {
+		return x.PublishedFrom
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetPublishedTo() int32 {
+	if x != nil {
+		return x.PublishedTo
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *BrowseRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *BrowseRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_proto_book_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// BrowseResponse holds one page of the matching books, their total, and per
+// genre, language and author the number of books matching every filter but
+// that facet's own, most frequent first.
+type BrowseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*Book                `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Genres        []*FacetCount          `protobuf:"bytes,4,rep,name=genres,proto3" json:"genres,omitempty"`
+	Languages     []*FacetCount          `protobuf:"bytes,5,rep,name=languages,proto3" json:"languages,omitempty"`
+	Authors       []*FacetCount          `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseResponse) Reset() {
+	*x = BrowseResponse{}
+	mi := &file_proto_book_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseResponse) ProtoMessage() {}
+
+func (x *BrowseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseResponse.ProtoReflect.Descriptor instead.
+func (*BrowseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BrowseResponse) GetBooks() []*Book {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
+func (x *BrowseResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *BrowseResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BrowseResponse) GetGenres() []*FacetCount {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *BrowseResponse) GetLanguages() []*FacetCount {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *BrowseResponse) GetAuthors() []*FacetCount {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+// ListBooksRequest pages through the catalog. sort is title, author, rating,
+// price, pages or published_date, prefixed with "-" for descending order;
+// the default is insertion order. Empty filters match every book.
+type ListBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Genre         string                 `protobuf:"bytes,4,opt,name=genre,proto3" json:"genre,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	MinRating     float32                `protobuf:"fixed32,7,opt,name=min_rating,json=minRating,proto3" json:"min_rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	mi := &file_proto_book_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBooksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetMinRating() float32 {
+	if x != nil {
+		return x.MinRating
+	}
+	return 0
+}
+
+// Review is a user's star rating (1 to 5) and text for a book they own.
+type Review struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_proto_book_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Review) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Review) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Review) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Review) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Review) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// CreateReviewRequest reviews book_id as the calling user, who must own the
+// book; a user reviews a book once.
+type CreateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReviewRequest) Reset() {
+	*x = CreateReviewRequest{}
+	mi := &file_proto_book_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReviewRequest) ProtoMessage() {}
+
+func (x *CreateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReviewRequest.ProtoReflect.Descriptor instead.
+func (*CreateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateReviewRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *CreateReviewRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *CreateReviewRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// UpdateReviewRequest replaces the rating and text of the caller's review.
+type UpdateReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rating        int32                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReviewRequest) Reset() {
+	*x = UpdateReviewRequest{}
+	mi := &file_proto_book_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReviewRequest) ProtoMessage() {}
+
+func (x *UpdateReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReviewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateReviewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateReviewRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *UpdateReviewRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ReviewID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewID) Reset() {
+	*x = ReviewID{}
+	mi := &file_proto_book_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewID) ProtoMessage() {}
+
+func (x *ReviewID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewID.ProtoReflect.Descriptor instead.
+func (*ReviewID) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReviewID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewResponse) Reset() {
+	*x = ReviewResponse{}
+	mi := &file_proto_book_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewResponse) ProtoMessage() {}
+
+func (x *ReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewResponse.ProtoReflect.Descriptor instead.
+func (*ReviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+// ListReviewsRequest pages through the reviews of book_id. sort is
+// created_at or rating, prefixed with "-" for descending order; the default
+// is newest first.
+type ListReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_proto_book_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListReviewsRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ReviewList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewList) Reset() {
+	*x = ReviewList{}
+	mi := &file_proto_book_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewList) ProtoMessage() {}
+
+func (x *ReviewList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewList.ProtoReflect.Descriptor instead.
+func (*ReviewList) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReviewList) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ReviewList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ImportBooksRequest carries the next chunk of a catalog file. The format,
+// csv or jsonl, is read from the first message; rows may span chunks.
+type ImportBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBooksRequest) Reset() {
+	*x = ImportBooksRequest{}
+	mi := &file_proto_book_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBooksRequest) ProtoMessage() {}
+
+func (x *ImportBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBooksRequest.ProtoReflect.Descriptor instead.
+func (*ImportBooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportBooksRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportBooksRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_proto_book_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ImportBooksResponse counts the rows of the file by outcome; errors lists
+// the failed rows, up to the first thousand.
+type ImportBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportBooksResponse) Reset() {
+	*x = ImportBooksResponse{}
+	mi := &file_proto_book_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportBooksResponse) ProtoMessage() {}
+
+func (x *ImportBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportBooksResponse.ProtoReflect.Descriptor instead.
+func (*ImportBooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportBooksResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportBooksResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportBooksResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportBooksResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// ExportBooksRequest exports every book, or those of genre and language when
+// given, as csv, jsonl or onix (a simplified ONIX 3.0 feed). currency, an
+// ISO 4217 code, prices the books of an ONIX feed.
+type ExportBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Genre         string                 `protobuf:"bytes,2,opt,name=genre,proto3" json:"genre,omitempty"`
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBooksRequest) Reset() {
+	*x = ExportBooksRequest{}
+	mi := &file_proto_book_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBooksRequest) ProtoMessage() {}
+
+func (x *ExportBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBooksRequest.ProtoReflect.Descriptor instead.
+func (*ExportBooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportBooksRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportBooksRequest) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *ExportBooksRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ExportBooksRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// ExportBooksChunk carries the next part of the exported file; the file is
+// the chunks' data concatenated.
+type ExportBooksChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportBooksChunk) Reset() {
+	*x = ExportBooksChunk{}
+	mi := &file_proto_book_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportBooksChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportBooksChunk) ProtoMessage() {}
+
+func (x *ExportBooksChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_book_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportBooksChunk.ProtoReflect.Descriptor instead.
+func (*ExportBooksChunk) Descriptor() ([]byte, []int) {
+	return file_proto_book_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportBooksChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_proto_book_proto protoreflect.FileDescriptor
+
+const file_proto_book_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/book.proto\x12\x04book\"\xd6\x02\n" +
+	"\x04Book\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x14\n" +
+	"\x05genre\x18\x04 \x01(\tR\x05genre\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06rating\x18\a \x01(\x02R\x06rating\x12\x14\n" +
+	"\x05price\x18\b \x01(\x02R\x05price\x12\x14\n" +
+	"\x05pages\x18\t \x01(\x05R\x05pages\x12%\n" +
+	"\x0epublished_date\x18\n" +
+	" \x01(\tR\rpublishedDate\x12!\n" +
+	"\frating_count\x18\v \x01(\x05R\vratingCount\x12\x16\n" +
+	"\x06isbn10\x18\f \x01(\tR\x06isbn10\x12\x16\n" +
+	"\x06isbn13\x18\r \x01(\tR\x06isbn13\"\a\n" +
+	"\x05Empty\".\n" +
+	"\fBookResponse\x12\x1e\n" +
+	"\x04book\x18\x01 \x01(\v2\n" +
+	".book.BookR\x04book\"T\n" +
+	"\bBookList\x12 \n" +
+	"\x05books\x18\x01 \x03(\v2\n" +
+	".book.BookR\x05books\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x18\n" +
+	"\x06BookID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"!\n" +
+	"\vISBNRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\"3\n" +
+	"\x11CreateBookRequest\x12\x1e\n" +
+	"\x04book\x18\x01 \x01(\v2\n" +
+	".book.BookR\x04book\"3\n" +
+	"\x11UpdateBookRequest\x12\x1e\n" +
+	"\x04book\x18\x01 \x01(\v2\n" +
+	".book.BookR\x04book\"$\n" +
+	"\fGenreRequest\x12\x14\n" +
+	"\x05genre\x18\x01 \x01(\tR\x05genre\"'\n" +
+	"\rAuthorRequest\x12\x16\n" +
+	"\x06author\x18\x01 \x01(\tR\x06author\"-\n" +
+	"\x0fLanguageRequest\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\"\xe2\x01\n" +
+	"\rSearchRequest\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05genre\x18\x05 \x01(\tR\x05genre\x12\x16\n" +
+	"\x06author\x18\x06 \x01(\tR\x06author\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\b \x01(\x02R\tminRating\"D\n" +
+	"\fSearchResult\x12\x1e\n" +
+	"\x04book\x18\x01 \x01(\v2\n" +
+	".book.BookR\x04book\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"f\n" +
+	"\x0eSearchResponse\x12,\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.book.SearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
+	"\x0eSuggestRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"O\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x17\n" +
+	"\abook_id\x18\x03 \x01(\tR\x06bookId\"E\n" +
+	"\x0fSuggestResponse\x122\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x10.book.SuggestionR\vsuggestions\"\x8c\x03\n" +
+	"\rBrowseRequest\x12\x16\n" +
+	"\x06genres\x18\x01 \x03(\tR\x06genres\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\x12\x18\n" +
+	"\aauthors\x18\x03 \x03(\tR\aauthors\x12\x1b\n" +
+	"\tmin_price\x18\x04 \x01(\x02R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x05 \x01(\x02R\bmaxPrice\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\x06 \x01(\x02R\tminRating\x12\x1b\n" +
+	"\tmin_pages\x18\a \x01(\x05R\bminPages\x12\x1b\n" +
+	"\tmax_pages\x18\b \x01(\x05R\bmaxPages\x12%\n" +
+	"\x0epublished_from\x18\t \x01(\x05R\rpublishedFrom\x12!\n" +
+	"\fpublished_to\x18\n" +
+	" \x01(\x05R\vpublishedTo\x12\x1b\n" +
+	"\tpage_size\x18\v \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\r \x01(\tR\x04sort\"8\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xf6\x01\n" +
+	"\x0eBrowseResponse\x12 \n" +
+	"\x05books\x18\x01 \x03(\v2\n" +
+	".book.BookR\x05books\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12(\n" +
+	"\x06genres\x18\x04 \x03(\v2\x10.book.FacetCountR\x06genres\x12.\n" +
+	"\tlanguages\x18\x05 \x03(\v2\x10.book.FacetCountR\tlanguages\x12*\n" +
+	"\aauthors\x18\x06 \x03(\v2\x10.book.FacetCountR\aauthors\"\xcb\x01\n" +
+	"\x10ListBooksRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05genre\x18\x04 \x01(\tR\x05genre\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x1d\n" +
+	"\n" +
+	"min_rating\x18\a \x01(\x02R\tminRating\"\xb4\x01\n" +
+	"\x06Review\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\abook_id\x18\x02 \x01(\tR\x06bookId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\"Z\n" +
+	"\x13CreateReviewRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x05R\x06rating\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"Q\n" +
+	"\x13UpdateReviewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x05R\x06rating\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\x1a\n" +
+	"\bReviewID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x0eReviewResponse\x12$\n" +
+	"\x06review\x18\x01 \x01(\v2\f.book.ReviewR\x06review\"}\n" +
+	"\x12ListReviewsRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"\\\n" +
+	"\n" +
+	"ReviewList\x12&\n" +
+	"\areviews\x18\x01 \x03(\v2\f.book.ReviewR\areviews\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"@\n" +
+	"\x12ImportBooksRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\";\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8c\x01\n" +
+	"\x13ImportBooksResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12)\n" +
+	"\x06errors\x18\x04 \x03(\v2\x11.book.ImportErrorR\x06errors\"z\n" +
+	"\x12ExportBooksRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05genre\x18\x02 \x01(\tR\x05genre\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"&\n" +
+	"\x10ExportBooksChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2\xaf\t\n" +
 	"\vBookService\x129\n" +
 	"\n" +
 	"CreateBook\x12\x17.book.CreateBookRequest\x1a\x12.book.BookResponse\x12+\n" +
-	"\aGetBook\x12\f.book.BookID\x1a\x12.book.BookResponse\x129\n" +
+	"\aGetBook\x12\f.book.BookID\x1a\x12.book.BookResponse\x126\n" +
+	"\rGetBookByISBN\x12\x11.book.ISBNRequest\x1a\x12.book.BookResponse\x129\n" +
 	"\n" +
 	"UpdateBook\x12\x17.book.UpdateBookRequest\x1a\x12.book.BookResponse\x12'\n" +
 	"\n" +
-	"DeleteBook\x12\f.book.BookID\x1a\v.book.Empty\x12+\n" +
-	"\fListAllBooks\x12\v.book.Empty\x1a\x0e.book.BookList\x126\n" +
+	"DeleteBook\x12\f.book.BookID\x1a\v.book.Empty\x126\n" +
+	"\fListAllBooks\x12\x16.book.ListBooksRequest\x1a\x0e.book.BookList\x126\n" +
 	"\x10ListBooksByGenre\x12\x12.book.GenreRequest\x1a\x0e.book.BookList\x128\n" +
 	"\x11ListBooksByAuthor\x12\x13.book.AuthorRequest\x1a\x0e.book.BookList\x12<\n" +
-	"\x13ListBooksByLanguage\x12\x15.book.LanguageRequest\x1a\x0e.book.BookList\x122\n" +
-	"\vSearchBooks\x12\x13.book.SearchRequest\x1a\x0e.book.BookList\x120\n" +
+	"\x13ListBooksByLanguage\x12\x15.book.LanguageRequest\x1a\x0e.book.BookList\x128\n" +
+	"\vSearchBooks\x12\x13.book.SearchRequest\x1a\x14.book.SearchResponse\x12;\n" +
+	"\fSuggestBooks\x12\x14.book.SuggestRequest\x1a\x15.book.SuggestResponse\x128\n" +
+	"\vBrowseBooks\x12\x13.book.BrowseRequest\x1a\x14.book.BrowseResponse\x120\n" +
 	"\x11ListTopRatedBooks\x12\v.book.Empty\x1a\x0e.book.BookList\x12.\n" +
 	"\x0fListNewArrivals\x12\v.book.Empty\x1a\x0e.book.BookList\x12.\n" +
-	"\x0eRecommendBooks\x12\f.book.BookID\x1a\x0e.book.BookListB=Z;github.com/OshakbayAigerim/book_service/proto/bookpb;bookpbb\x06proto3"
+	"\x0eRecommendBooks\x12\f.book.BookID\x1a\x0e.book.BookList\x12?\n" +
+	"\fCreateReview\x12\x19.book.CreateReviewRequest\x1a\x14.book.ReviewResponse\x12?\n" +
+	"\fUpdateReview\x12\x19.book.UpdateReviewRequest\x1a\x14.book.ReviewResponse\x12+\n" +
+	"\fDeleteReview\x12\x0e.book.ReviewID\x1a\v.book.Empty\x129\n" +
+	"\vListReviews\x12\x18.book.ListReviewsRequest\x1a\x10.book.ReviewList\x12D\n" +
+	"\vImportBooks\x12\x18.book.ImportBooksRequest\x1a\x19.book.ImportBooksResponse(\x01\x12A\n" +
+	"\vExportBooks\x12\x18.book.ExportBooksRequest\x1a\x16.book.ExportBooksChunk0\x01B=Z;github.com/OshakbayAigerim/book_service/proto/bookpb;bookpbb\x06proto3"
 
 var (
 	file_proto_book_proto_rawDescOnce sync.Once
@@ -640,54 +2270,104 @@ func file_proto_book_proto_rawDescGZIP() []byte {
 	return file_proto_book_proto_rawDescData
 }
 
-var file_proto_book_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_book_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_book_proto_goTypes = []any{
-	(*Book)(nil),              // 0: book.Book
-	(*Empty)(nil),             // 1: book.Empty
-	(*BookResponse)(nil),      // 2: book.BookResponse
-	(*BookList)(nil),          // 3: book.BookList
-	(*BookID)(nil),            // 4: book.BookID
-	(*CreateBookRequest)(nil), // 5: book.CreateBookRequest
-	(*UpdateBookRequest)(nil), // 6: book.UpdateBookRequest
-	(*GenreRequest)(nil),      // 7: book.GenreRequest
-	(*AuthorRequest)(nil),     // 8: book.AuthorRequest
-	(*LanguageRequest)(nil),   // 9: book.LanguageRequest
-	(*SearchRequest)(nil),     // 10: book.SearchRequest
+	(*Book)(nil),                // 0: book.Book
+	(*Empty)(nil),               // 1: book.Empty
+	(*BookResponse)(nil),        // 2: book.BookResponse
+	(*BookList)(nil),            // 3: book.BookList
+	(*BookID)(nil),              // 4: book.BookID
+	(*ISBNRequest)(nil),         // 5: book.ISBNRequest
+	(*CreateBookRequest)(nil),   // 6: book.CreateBookRequest
+	(*UpdateBookRequest)(nil),   // 7: book.UpdateBookRequest
+	(*GenreRequest)(nil),        // 8: book.GenreRequest
+	(*AuthorRequest)(nil),       // 9: book.AuthorRequest
+	(*LanguageRequest)(nil),     // 10: book.LanguageRequest
+	(*SearchRequest)(nil),       // 11: book.SearchRequest
+	(*SearchResult)(nil),        // 12: book.SearchResult
+	(*SearchResponse)(nil),      // 13: book.SearchResponse
+	(*SuggestRequest)(nil),      // 14: book.SuggestRequest
+	(*Suggestion)(nil),          // 15: book.Suggestion
+	(*SuggestResponse)(nil),     // 16: book.SuggestResponse
+	(*BrowseRequest)(nil),       // 17: book.BrowseRequest
+	(*FacetCount)(nil),          // 18: book.FacetCount
+	(*BrowseResponse)(nil),      // 19: book.BrowseResponse
+	(*ListBooksRequest)(nil),    // 20: book.ListBooksRequest
+	(*Review)(nil),              // 21: book.Review
+	(*CreateReviewRequest)(nil), // 22: book.CreateReviewRequest
+	(*UpdateReviewRequest)(nil), // 23: book.UpdateReviewRequest
+	(*ReviewID)(nil),            // 24: book.ReviewID
+	(*ReviewResponse)(nil),      // 25: book.ReviewResponse
+	(*ListReviewsRequest)(nil),  // 26: book.ListReviewsRequest
+	(*ReviewList)(nil),          // 27: book.ReviewList
+	(*ImportBooksRequest)(nil),  // 28: book.ImportBooksRequest
+	(*ImportError)(nil),         // 29: book.ImportError
+	(*ImportBooksResponse)(nil), // 30: book.ImportBooksResponse
+	(*ExportBooksRequest)(nil),  // 31: book.ExportBooksRequest
+	(*ExportBooksChunk)(nil),    // 32: book.ExportBooksChunk
 }
 var file_proto_book_proto_depIdxs = []int32{
 	0,  // 0: book.BookResponse.book:type_name -> book.Book
 	0,  // 1: book.BookList.books:type_name -> book.Book
 	0,  // 2: book.CreateBookRequest.book:type_name -> book.Book
 	0,  // 3: book.UpdateBookRequest.book:type_name -> book.Book
-	5,  // 4: book.BookService.CreateBook:input_type -> book.CreateBookRequest
-	4,  // 5: book.BookService.GetBook:input_type -> book.BookID
-	6,  // 6: book.BookService.UpdateBook:input_type -> book.UpdateBookRequest
-	4,  // 7: book.BookService.DeleteBook:input_type -> book.BookID
-	1,  // 8: book.BookService.ListAllBooks:input_type -> book.Empty
-	7,  // 9: book.BookService.ListBooksByGenre:input_type -> book.GenreRequest
-	8,  // 10: book.BookService.ListBooksByAuthor:input_type -> book.AuthorRequest
-	9,  // 11: book.BookService.ListBooksByLanguage:input_type -> book.LanguageRequest
-	10, // 12: book.BookService.SearchBooks:input_type -> book.SearchRequest
-	1,  // 13: book.BookService.ListTopRatedBooks:input_type -> book.Empty
-	1,  // 14: book.BookService.ListNewArrivals:input_type -> book.Empty
-	4,  // 15: book.BookService.RecommendBooks:input_type -> book.BookID
-	2,  // 16: book.BookService.CreateBook:output_type -> book.BookResponse
-	2,  // 17: book.BookService.GetBook:output_type -> book.BookResponse
-	2,  // 18: book.BookService.UpdateBook:output_type -> book.BookResponse
-	1,  // 19: book.BookService.DeleteBook:output_type -> book.Empty
-	3,  // 20: book.BookService.ListAllBooks:output_type -> book.BookList
-	3,  // 21: book.BookService.ListBooksByGenre:output_type -> book.BookList
-	3,  // 22: book.BookService.ListBooksByAuthor:output_type -> book.BookList
-	3,  // 23: book.BookService.ListBooksByLanguage:output_type -> book.BookList
-	3,  // 24: book.BookService.SearchBooks:output_type -> book.BookList
-	3,  // 25: book.BookService.ListTopRatedBooks:output_type -> book.BookList
-	3,  // 26: book.BookService.ListNewArrivals:output_type -> book.BookList
-	3,  // 27: book.BookService.RecommendBooks:output_type -> book.BookList
-	16, // [16:28] is the sub-list for method output_type
-	4,  // [4:16] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	0,  // 4: book.SearchResult.book:type_name -> book.Book
+	12, // 5: book.SearchResponse.results:type_name -> book.SearchResult
+	15, // 6: book.SuggestResponse.suggestions:type_name -> book.Suggestion
+	0,  // 7: book.BrowseResponse.books:type_name -> book.Book
+	18, // 8: book.BrowseResponse.genres:type_name -> book.FacetCount
+	18, // 9: book.BrowseResponse.languages:type_name -> book.FacetCount
+	18, // 10: book.BrowseResponse.authors:type_name -> book.FacetCount
+	21, // 11: book.ReviewResponse.review:type_name -> book.Review
+	21, // 12: book.ReviewList.reviews:type_name -> book.Review
+	29, // 13: book.ImportBooksResponse.errors:type_name -> book.ImportError
+	6,  // 14: book.BookService.CreateBook:input_type -> book.CreateBookRequest
+	4,  // 15: book.BookService.GetBook:input_type -> book.BookID
+	5,  // 16: book.BookService.GetBookByISBN:input_type -> book.ISBNRequest
+	7,  // 17: book.BookService.UpdateBook:input_type -> book.UpdateBookRequest
+	4,  // 18: book.BookService.DeleteBook:input_type -> book.BookID
+	20, // 19: book.BookService.ListAllBooks:input_type -> book.ListBooksRequest
+	8,  // 20: book.BookService.ListBooksByGenre:input_type -> book.GenreRequest
+	9,  // 21: book.BookService.ListBooksByAuthor:input_type -> book.AuthorRequest
+	10, // 22: book.BookService.ListBooksByLanguage:input_type -> book.LanguageRequest
+	11, // 23: book.BookService.SearchBooks:input_type -> book.SearchRequest
+	14, // 24: book.BookService.SuggestBooks:input_type -> book.SuggestRequest
+	17, // 25: book.BookService.BrowseBooks:input_type -> book.BrowseRequest
+	1,  // 26: book.BookService.ListTopRatedBooks:input_type -> book.Empty
+	1,  // 27: book.BookService.ListNewArrivals:input_type -> book.Empty
+	4,  // 28: book.BookService.RecommendBooks:input_type -> book.BookID
+	22, // 29: book.BookService.CreateReview:input_type -> book.CreateReviewRequest
+	23, // 30: book.BookService.UpdateReview:input_type -> book.UpdateReviewRequest
+	24, // 31: book.BookService.DeleteReview:input_type -> book.ReviewID
+	26, // 32: book.BookService.ListReviews:input_type -> book.ListReviewsRequest
+	28, // 33: book.BookService.ImportBooks:input_type -> book.ImportBooksRequest
+	31, // 34: book.BookService.ExportBooks:input_type -> book.ExportBooksRequest
+	2,  // 35: book.BookService.CreateBook:output_type -> book.BookResponse
+	2,  // 36: book.BookService.GetBook:output_type -> book.BookResponse
+	2,  // 37: book.BookService.GetBookByISBN:output_type -> book.BookResponse
+	2,  // 38: book.BookService.UpdateBook:output_type -> book.BookResponse
+	1,  // 39: book.BookService.DeleteBook:output_type -> book.Empty
+	3,  // 40: book.BookService.ListAllBooks:output_type -> book.BookList
+	3,  // 41: book.BookService.ListBooksByGenre:output_type -> book.BookList
+	3,  // 42: book.BookService.ListBooksByAuthor:output_type -> book.BookList
+	3,  // 43: book.BookService.ListBooksByLanguage:output_type -> book.BookList
+	13, // 44: book.BookService.SearchBooks:output_type -> book.SearchResponse
+	16, // 45: book.BookService.SuggestBooks:output_type -> book.SuggestResponse
+	19, // 46: book.BookService.BrowseBooks:output_type -> book.BrowseResponse
+	3,  // 47: book.BookService.ListTopRatedBooks:output_type -> book.BookList
+	3,  // 48: book.BookService.ListNewArrivals:output_type -> book.BookList
+	3,  // 49: book.BookService.RecommendBooks:output_type -> book.BookList
+	25, // 50: book.BookService.CreateReview:output_type -> book.ReviewResponse
+	25, // 51: book.BookService.UpdateReview:output_type -> book.ReviewResponse
+	1,  // 52: book.BookService.DeleteReview:output_type -> book.Empty
+	27, // 53: book.BookService.ListReviews:output_type -> book.ReviewList
+	30, // 54: book.BookService.ImportBooks:output_type -> book.ImportBooksResponse
+	32, // 55: book.BookService.ExportBooks:output_type -> book.ExportBooksChunk
+	35, // [35:56] is the sub-list for method output_type
+	14, // [14:35] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_proto_book_proto_init() }
@@ -701,7 +2381,7 @@ func file_proto_book_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_book_proto_rawDesc), len(file_proto_book_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
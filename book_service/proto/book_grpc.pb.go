@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	BookService_CreateBook_FullMethodName          = "/book.BookService/CreateBook"
 	BookService_GetBook_FullMethodName             = "/book.BookService/GetBook"
+	BookService_GetBookByISBN_FullMethodName       = "/book.BookService/GetBookByISBN"
 	BookService_UpdateBook_FullMethodName          = "/book.BookService/UpdateBook"
 	BookService_DeleteBook_FullMethodName          = "/book.BookService/DeleteBook"
 	BookService_ListAllBooks_FullMethodName        = "/book.BookService/ListAllBooks"
@@ -28,29 +29,44 @@ const (
 	BookService_ListBooksByAuthor_FullMethodName   = "/book.BookService/ListBooksByAuthor"
 	BookService_ListBooksByLanguage_FullMethodName = "/book.BookService/ListBooksByLanguage"
 	BookService_SearchBooks_FullMethodName         = "/book.BookService/SearchBooks"
+	BookService_SuggestBooks_FullMethodName        = "/book.BookService/SuggestBooks"
+	BookService_BrowseBooks_FullMethodName         = "/book.BookService/BrowseBooks"
 	BookService_ListTopRatedBooks_FullMethodName   = "/book.BookService/ListTopRatedBooks"
 	BookService_ListNewArrivals_FullMethodName     = "/book.BookService/ListNewArrivals"
 	BookService_RecommendBooks_FullMethodName      = "/book.BookService/RecommendBooks"
+	BookService_CreateReview_FullMethodName        = "/book.BookService/CreateReview"
+	BookService_UpdateReview_FullMethodName        = "/book.BookService/UpdateReview"
+	BookService_DeleteReview_FullMethodName        = "/book.BookService/DeleteReview"
+	BookService_ListReviews_FullMethodName         = "/book.BookService/ListReviews"
+	BookService_ImportBooks_FullMethodName         = "/book.BookService/ImportBooks"
+	BookService_ExportBooks_FullMethodName         = "/book.BookService/ExportBooks"
 )
 
 // BookServiceClient is the client API for BookService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-//
-// Сервис
 type BookServiceClient interface {
 	CreateBook(ctx context.Context, in *CreateBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	GetBook(ctx context.Context, in *BookID, opts ...grpc.CallOption) (*BookResponse, error)
+	GetBookByISBN(ctx context.Context, in *ISBNRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*BookResponse, error)
 	DeleteBook(ctx context.Context, in *BookID, opts ...grpc.CallOption) (*Empty, error)
-	ListAllBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BookList, error)
+	ListAllBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*BookList, error)
 	ListBooksByGenre(ctx context.Context, in *GenreRequest, opts ...grpc.CallOption) (*BookList, error)
 	ListBooksByAuthor(ctx context.Context, in *AuthorRequest, opts ...grpc.CallOption) (*BookList, error)
 	ListBooksByLanguage(ctx context.Context, in *LanguageRequest, opts ...grpc.CallOption) (*BookList, error)
-	SearchBooks(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*BookList, error)
+	SearchBooks(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	SuggestBooks(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	BrowseBooks(ctx context.Context, in *BrowseRequest, opts ...grpc.CallOption) (*BrowseResponse, error)
 	ListTopRatedBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BookList, error)
 	ListNewArrivals(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BookList, error)
 	RecommendBooks(ctx context.Context, in *BookID, opts ...grpc.CallOption) (*BookList, error)
+	CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	DeleteReview(ctx context.Context, in *ReviewID, opts ...grpc.CallOption) (*Empty, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ReviewList, error)
+	ImportBooks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBooksRequest, ImportBooksResponse], error)
+	ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBooksChunk], error)
 }
 
 type bookServiceClient struct {
@@ -81,6 +97,16 @@ func (c *bookServiceClient) GetBook(ctx context.Context, in *BookID, opts ...grp
 	return out, nil
 }
 
+func (c *bookServiceClient) GetBookByISBN(ctx context.Context, in *ISBNRequest, opts ...grpc.CallOption) (*BookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookResponse)
+	err := c.cc.Invoke(ctx, BookService_GetBookByISBN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) UpdateBook(ctx context.Context, in *UpdateBookRequest, opts ...grpc.CallOption) (*BookResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BookResponse)
@@ -101,7 +127,7 @@ func (c *bookServiceClient) DeleteBook(ctx context.Context, in *BookID, opts ...
 	return out, nil
 }
 
-func (c *bookServiceClient) ListAllBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BookList, error) {
+func (c *bookServiceClient) ListAllBooks(ctx context.Context, in *ListBooksRequest, opts ...grpc.CallOption) (*BookList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BookList)
 	err := c.cc.Invoke(ctx, BookService_ListAllBooks_FullMethodName, in, out, cOpts...)
@@ -141,9 +167,9 @@ func (c *bookServiceClient) ListBooksByLanguage(ctx context.Context, in *Languag
 	return out, nil
 }
 
-func (c *bookServiceClient) SearchBooks(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*BookList, error) {
+func (c *bookServiceClient) SearchBooks(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BookList)
+	out := new(SearchResponse)
 	err := c.cc.Invoke(ctx, BookService_SearchBooks_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -151,6 +177,26 @@ func (c *bookServiceClient) SearchBooks(ctx context.Context, in *SearchRequest,
 	return out, nil
 }
 
+func (c *bookServiceClient) SuggestBooks(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, BookService_SuggestBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) BrowseBooks(ctx context.Context, in *BrowseRequest, opts ...grpc.CallOption) (*BrowseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BrowseResponse)
+	err := c.cc.Invoke(ctx, BookService_BrowseBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bookServiceClient) ListTopRatedBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BookList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BookList)
@@ -181,24 +227,103 @@ func (c *bookServiceClient) RecommendBooks(ctx context.Context, in *BookID, opts
 	return out, nil
 }
 
+func (c *bookServiceClient) CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, BookService_CreateReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) UpdateReview(ctx context.Context, in *UpdateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, BookService_UpdateReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) DeleteReview(ctx context.Context, in *ReviewID, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, BookService_DeleteReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ReviewList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewList)
+	err := c.cc.Invoke(ctx, BookService_ListReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) ImportBooks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportBooksRequest, ImportBooksResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[0], BookService_ImportBooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportBooksRequest, ImportBooksResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ImportBooksClient = grpc.ClientStreamingClient[ImportBooksRequest, ImportBooksResponse]
+
+func (c *bookServiceClient) ExportBooks(ctx context.Context, in *ExportBooksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportBooksChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BookService_ServiceDesc.Streams[1], BookService_ExportBooks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportBooksRequest, ExportBooksChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ExportBooksClient = grpc.ServerStreamingClient[ExportBooksChunk]
+
 // BookServiceServer is the server API for BookService service.
 // All implementations must embed UnimplementedBookServiceServer
 // for forward compatibility.
-//
-// Сервис
 type BookServiceServer interface {
 	CreateBook(context.Context, *CreateBookRequest) (*BookResponse, error)
 	GetBook(context.Context, *BookID) (*BookResponse, error)
+	GetBookByISBN(context.Context, *ISBNRequest) (*BookResponse, error)
 	UpdateBook(context.Context, *UpdateBookRequest) (*BookResponse, error)
 	DeleteBook(context.Context, *BookID) (*Empty, error)
-	ListAllBooks(context.Context, *Empty) (*BookList, error)
+	ListAllBooks(context.Context, *ListBooksRequest) (*BookList, error)
 	ListBooksByGenre(context.Context, *GenreRequest) (*BookList, error)
 	ListBooksByAuthor(context.Context, *AuthorRequest) (*BookList, error)
 	ListBooksByLanguage(context.Context, *LanguageRequest) (*BookList, error)
-	SearchBooks(context.Context, *SearchRequest) (*BookList, error)
+	SearchBooks(context.Context, *SearchRequest) (*SearchResponse, error)
+	SuggestBooks(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	BrowseBooks(context.Context, *BrowseRequest) (*BrowseResponse, error)
 	ListTopRatedBooks(context.Context, *Empty) (*BookList, error)
 	ListNewArrivals(context.Context, *Empty) (*BookList, error)
 	RecommendBooks(context.Context, *BookID) (*BookList, error)
+	CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error)
+	UpdateReview(context.Context, *UpdateReviewRequest) (*ReviewResponse, error)
+	DeleteReview(context.Context, *ReviewID) (*Empty, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ReviewList, error)
+	ImportBooks(grpc.ClientStreamingServer[ImportBooksRequest, ImportBooksResponse]) error
+	ExportBooks(*ExportBooksRequest, grpc.ServerStreamingServer[ExportBooksChunk]) error
 	mustEmbedUnimplementedBookServiceServer()
 }
 
@@ -215,13 +340,16 @@ func (UnimplementedBookServiceServer) CreateBook(context.Context, *CreateBookReq
 func (UnimplementedBookServiceServer) GetBook(context.Context, *BookID) (*BookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBook not implemented")
 }
+func (UnimplementedBookServiceServer) GetBookByISBN(context.Context, *ISBNRequest) (*BookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookByISBN not implemented")
+}
 func (UnimplementedBookServiceServer) UpdateBook(context.Context, *UpdateBookRequest) (*BookResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBook not implemented")
 }
 func (UnimplementedBookServiceServer) DeleteBook(context.Context, *BookID) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBook not implemented")
 }
-func (UnimplementedBookServiceServer) ListAllBooks(context.Context, *Empty) (*BookList, error) {
+func (UnimplementedBookServiceServer) ListAllBooks(context.Context, *ListBooksRequest) (*BookList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllBooks not implemented")
 }
 func (UnimplementedBookServiceServer) ListBooksByGenre(context.Context, *GenreRequest) (*BookList, error) {
@@ -233,9 +361,15 @@ func (UnimplementedBookServiceServer) ListBooksByAuthor(context.Context, *Author
 func (UnimplementedBookServiceServer) ListBooksByLanguage(context.Context, *LanguageRequest) (*BookList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBooksByLanguage not implemented")
 }
-func (UnimplementedBookServiceServer) SearchBooks(context.Context, *SearchRequest) (*BookList, error) {
+func (UnimplementedBookServiceServer) SearchBooks(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchBooks not implemented")
 }
+func (UnimplementedBookServiceServer) SuggestBooks(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestBooks not implemented")
+}
+func (UnimplementedBookServiceServer) BrowseBooks(context.Context, *BrowseRequest) (*BrowseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BrowseBooks not implemented")
+}
 func (UnimplementedBookServiceServer) ListTopRatedBooks(context.Context, *Empty) (*BookList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTopRatedBooks not implemented")
 }
@@ -245,6 +379,24 @@ func (UnimplementedBookServiceServer) ListNewArrivals(context.Context, *Empty) (
 func (UnimplementedBookServiceServer) RecommendBooks(context.Context, *BookID) (*BookList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecommendBooks not implemented")
 }
+func (UnimplementedBookServiceServer) CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReview not implemented")
+}
+func (UnimplementedBookServiceServer) UpdateReview(context.Context, *UpdateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReview not implemented")
+}
+func (UnimplementedBookServiceServer) DeleteReview(context.Context, *ReviewID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReview not implemented")
+}
+func (UnimplementedBookServiceServer) ListReviews(context.Context, *ListReviewsRequest) (*ReviewList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedBookServiceServer) ImportBooks(grpc.ClientStreamingServer[ImportBooksRequest, ImportBooksResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportBooks not implemented")
+}
+func (UnimplementedBookServiceServer) ExportBooks(*ExportBooksRequest, grpc.ServerStreamingServer[ExportBooksChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportBooks not implemented")
+}
 func (UnimplementedBookServiceServer) mustEmbedUnimplementedBookServiceServer() {}
 func (UnimplementedBookServiceServer) testEmbeddedByValue()                     {}
 
@@ -302,6 +454,24 @@ func _BookService_GetBook_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_GetBookByISBN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ISBNRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetBookByISBN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_GetBookByISBN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetBookByISBN(ctx, req.(*ISBNRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_UpdateBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateBookRequest)
 	if err := dec(in); err != nil {
@@ -339,7 +509,7 @@ func _BookService_DeleteBook_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _BookService_ListAllBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListBooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -351,7 +521,7 @@ func _BookService_ListAllBooks_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: BookService_ListAllBooks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BookServiceServer).ListAllBooks(ctx, req.(*Empty))
+		return srv.(BookServiceServer).ListAllBooks(ctx, req.(*ListBooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -428,6 +598,42 @@ func _BookService_SearchBooks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_SuggestBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).SuggestBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_SuggestBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).SuggestBooks(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_BrowseBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrowseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).BrowseBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_BrowseBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).BrowseBooks(ctx, req.(*BrowseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BookService_ListTopRatedBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -482,6 +688,96 @@ func _BookService_RecommendBooks_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BookService_CreateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).CreateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_CreateReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).CreateReview(ctx, req.(*CreateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_UpdateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).UpdateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_UpdateReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).UpdateReview(ctx, req.(*UpdateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_DeleteReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).DeleteReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_DeleteReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).DeleteReview(ctx, req.(*ReviewID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookService_ListReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_ImportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BookServiceServer).ImportBooks(&grpc.GenericServerStream[ImportBooksRequest, ImportBooksResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ImportBooksServer = grpc.ClientStreamingServer[ImportBooksRequest, ImportBooksResponse]
+
+func _BookService_ExportBooks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBooksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookServiceServer).ExportBooks(m, &grpc.GenericServerStream[ExportBooksRequest, ExportBooksChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BookService_ExportBooksServer = grpc.ServerStreamingServer[ExportBooksChunk]
+
 // BookService_ServiceDesc is the grpc.ServiceDesc for BookService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -497,6 +793,10 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBook",
 			Handler:    _BookService_GetBook_Handler,
 		},
+		{
+			MethodName: "GetBookByISBN",
+			Handler:    _BookService_GetBookByISBN_Handler,
+		},
 		{
 			MethodName: "UpdateBook",
 			Handler:    _BookService_UpdateBook_Handler,
@@ -525,6 +825,14 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchBooks",
 			Handler:    _BookService_SearchBooks_Handler,
 		},
+		{
+			MethodName: "SuggestBooks",
+			Handler:    _BookService_SuggestBooks_Handler,
+		},
+		{
+			MethodName: "BrowseBooks",
+			Handler:    _BookService_BrowseBooks_Handler,
+		},
 		{
 			MethodName: "ListTopRatedBooks",
 			Handler:    _BookService_ListTopRatedBooks_Handler,
@@ -537,7 +845,34 @@ var BookService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RecommendBooks",
 			Handler:    _BookService_RecommendBooks_Handler,
 		},
+		{
+			MethodName: "CreateReview",
+			Handler:    _BookService_CreateReview_Handler,
+		},
+		{
+			MethodName: "UpdateReview",
+			Handler:    _BookService_UpdateReview_Handler,
+		},
+		{
+			MethodName: "DeleteReview",
+			Handler:    _BookService_DeleteReview_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _BookService_ListReviews_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportBooks",
+			Handler:       _BookService_ImportBooks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportBooks",
+			Handler:       _BookService_ExportBooks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/book.proto",
 }
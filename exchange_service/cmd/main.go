@@ -10,9 +10,12 @@ import (
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/config"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/handler"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/migrations"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/usecase"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/worker"
 	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
 	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
 	"github.com/nats-io/nats.go"
 	"google.golang.org/grpc"
@@ -21,6 +24,14 @@ import (
 func main() {
 	mongoClient := config.ConnectMongo()
 	db := mongoClient.Database("readspace")
+	migrations.CreateOfferIndexes(db)
+	migrations.CreateWantIndexes(db)
+	migrations.CreateRatingIndexes(db)
+	migrations.CreateDisputeIndexes(db)
+	migrations.CreateMessageIndexes(db)
+
+	offers := config.LoadOfferSettings()
+	migrations.BackfillOfferExpiry(db, offers.DefaultTTL)
 
 	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -47,13 +58,21 @@ func main() {
 	redisCache := cache.NewRedisExchangeCache(repo, rdb, 5*time.Minute)
 
 	uc := usecase.NewExchangeUseCase(repo, redisCache, libClient)
-	srv := handler.NewExchangeHandler(uc, nc)
+	matchUC := usecase.NewMatchUseCase(repository.NewMongoWantRepository(db), libClient)
+	disputeRepo := repository.NewMongoDisputeRepository(db)
+	reputationUC := usecase.NewReputationUseCase(repository.NewMongoRatingRepository(db), repo, disputeRepo)
+	disputeUC := usecase.NewDisputeUseCase(disputeRepo, repo, redisCache, libClient)
+	messageUC := usecase.NewMessageUseCase(repository.NewMongoMessageRepository(db), repo)
+	srv := handler.NewExchangeHandler(uc, matchUC, reputationUC, disputeUC, messageUC, nc, offers.DefaultTTL)
+
+	go worker.NewExpirySweeper(uc, srv.OfferExpired, offers.SweepInterval).Run(context.Background())
 
 	lis, err := net.Listen("tcp", ":50054")
 	if err != nil {
 		log.Fatalf("listen error: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+	grpcServer := grpc.NewServer(auth.ServerOptions(tokens)...)
 	exchangepb.RegisterExchangeServiceServer(grpcServer, srv)
 
 	log.Println("ExchangeService listening on :50054")
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	DefaultOfferTTL      = 7 * 24 * time.Hour
+	DefaultSweepInterval = time.Minute
+)
+
+// OfferSettings controls how long offers stay open and how often overdue
+// offers are expired.
+type OfferSettings struct {
+	DefaultTTL    time.Duration
+	SweepInterval time.Duration
+}
+
+// LoadOfferSettings reads OFFER_DEFAULT_TTL and OFFER_SWEEP_INTERVAL
+// (Go durations such as "72h" or "30s"), falling back to the defaults.
+func LoadOfferSettings() OfferSettings {
+	return OfferSettings{
+		DefaultTTL:    durationEnv("OFFER_DEFAULT_TTL", DefaultOfferTTL),
+		SweepInterval: durationEnv("OFFER_SWEEP_INTERVAL", DefaultSweepInterval),
+	}
+}
+
+func durationEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("⚠ invalid %s=%q, using %s", key, v, def)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	DisputeOpen     = "OPEN"
+	DisputeResolved = "RESOLVED"
+)
+
+// Dispute is a complaint about an accepted exchange, e.g. a book that never
+// arrived. While it is open the offer is frozen in DISPUTED.
+type Dispute struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	OfferID    primitive.ObjectID `bson:"offer_id"`
+	OpenedBy   primitive.ObjectID `bson:"opened_by"`
+	AgainstID  primitive.ObjectID `bson:"against_id"`
+	Reason     string             `bson:"reason"`
+	Status     string             `bson:"status"`
+	Evidence   []Evidence         `bson:"evidence"`
+	Resolution string             `bson:"resolution,omitempty"`
+	Reversed   bool               `bson:"reversed"`
+	ResolvedBy primitive.ObjectID `bson:"resolved_by,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"created_at"`
+	ResolvedAt primitive.DateTime `bson:"resolved_at,omitempty"`
+}
+
+// Evidence is a note added to a dispute by one of the parties or an admin.
+type Evidence struct {
+	AuthorID  primitive.ObjectID `bson:"author_id"`
+	Note      string             `bson:"note"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
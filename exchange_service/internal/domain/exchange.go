@@ -1,14 +1,54 @@
 package domain
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
 
 type ExchangeOffer struct {
-	ID               primitive.ObjectID
-	OwnerID          primitive.ObjectID
-	CounterpartyID   primitive.ObjectID
-	OfferedBookIDs   []primitive.ObjectID
-	RequestedBookIDs []primitive.ObjectID
-	Status           string
-	CreatedAt        primitive.DateTime
-	UpdatedAt        primitive.DateTime
+	ID               primitive.ObjectID   `bson:"_id"`
+	OwnerID          primitive.ObjectID   `bson:"owner_id"`
+	CounterpartyID   primitive.ObjectID   `bson:"counterparty_id"`
+	OfferedBookIDs   []primitive.ObjectID `bson:"offered_book_ids"`
+	RequestedBookIDs []primitive.ObjectID `bson:"requested_book_ids"`
+	Status           string               `bson:"status"`
+	CreatedAt        primitive.DateTime   `bson:"created_at"`
+	UpdatedAt        primitive.DateTime   `bson:"updated_at"`
+	ParentID         primitive.ObjectID   `bson:"parent_id,omitempty"`
+	ExpiresAt        primitive.DateTime   `bson:"expires_at,omitempty"`
+	// Revision counts the edits made to the offer; Revisions holds them,
+	// oldest first.
+	Revision  int             `bson:"revision"`
+	Revisions []OfferRevision `bson:"revisions,omitempty"`
+}
+
+const (
+	StatusPending   = "PENDING"
+	StatusAccepted  = "ACCEPTED"
+	StatusDeclined  = "DECLINED"
+	StatusCancelled = "CANCELLED"
+	StatusExpired   = "EXPIRED"
+	StatusCompleted = "COMPLETED"
+	StatusCountered = "COUNTERED"
+	StatusDisputed  = "DISPUTED"
+	StatusReversed  = "REVERSED"
+)
+
+// OfferSortFields are the sort orders ListAllOffers accepts.
+var OfferSortFields = paging.Fields{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"expires_at": "expires_at",
+}
+
+// OfferFilter narrows ListAllOffers; zero fields match everything. UserID
+// matches the owner or the counterparty; the created range is [After, Before).
+type OfferFilter struct {
+	Status        string
+	UserID        primitive.ObjectID
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 }
@@ -0,0 +1,25 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Want is a book a user would like to receive in an exchange.
+type Want struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	BookID    primitive.ObjectID `bson:"book_id"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// Match pairs a user with a partner who has what the user wants and wants
+// what the user has. Give and Get are seen from the user's side.
+type Match struct {
+	UserID    primitive.ObjectID
+	PartnerID primitive.ObjectID
+	Give      []primitive.ObjectID
+	Get       []primitive.ObjectID
+}
+
+// Score is the number of books that would change hands.
+func (m *Match) Score() int {
+	return len(m.Give) + len(m.Get)
+}
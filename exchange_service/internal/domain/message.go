@@ -0,0 +1,12 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// OfferMessage is one entry of the chat between the two parties of an offer.
+type OfferMessage struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	OfferID   primitive.ObjectID `bson:"offer_id"`
+	SenderID  primitive.ObjectID `bson:"sender_id"`
+	Body      string             `bson:"body"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Rating is the score one party of an exchange gives the other.
+type Rating struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	OfferID   primitive.ObjectID `bson:"offer_id"`
+	RaterID   primitive.ObjectID `bson:"rater_id"`
+	RateeID   primitive.ObjectID `bson:"ratee_id"`
+	Score     int                `bson:"score"`
+	Comment   string             `bson:"comment,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// Reputation sums up how a user has done in past exchanges.
+type Reputation struct {
+	UserID   primitive.ObjectID
+	Average  float64
+	Ratings  int64
+	Trades   int64
+	Disputes int64
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Offer fields that can be edited while an offer is pending, named as in the
+// API's field masks.
+const (
+	FieldCounterparty   = "counterparty_id"
+	FieldOfferedBooks   = "offered_book_ids"
+	FieldRequestedBooks = "requested_book_ids"
+	FieldExpiresAt      = "expires_at"
+)
+
+// OfferRevision records one edit of a pending offer: who made it, when, and
+// what changed.
+type OfferRevision struct {
+	Number   int                `bson:"number"`
+	EditorID primitive.ObjectID `bson:"editor_id"`
+	EditedAt primitive.DateTime `bson:"edited_at"`
+	Changes  []FieldChange      `bson:"changes"`
+}
+
+// FieldChange is the diff of one field. Book lists record the IDs added and
+// removed; the other fields their old and new value.
+type FieldChange struct {
+	Field   string               `bson:"field"`
+	Added   []primitive.ObjectID `bson:"added,omitempty"`
+	Removed []primitive.ObjectID `bson:"removed,omitempty"`
+	From    string               `bson:"from,omitempty"`
+	To      string               `bson:"to,omitempty"`
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+)
+
+func (h *ExchangeHandler) OpenDispute(ctx context.Context, req *exchangepb.OpenDisputeRequest) (*exchangepb.DisputeResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	d, offer, err := h.disputes.OpenDispute(ctx, req.OfferId, caller, req.Reason)
+	if err != nil {
+		return nil, offerError(err, "cannot open dispute")
+	}
+	events.Emit(h.nc, events.ExchangeDisputeOpened, disputeEvent(d, offer, caller, d.Reason))
+	return disputeResponse(d, offer), nil
+}
+
+func (h *ExchangeHandler) AddDisputeEvidence(ctx context.Context, req *exchangepb.DisputeEvidenceRequest) (*exchangepb.DisputeResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.DisputeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "dispute_id is required")
+	}
+	d, offer, err := h.disputes.AddEvidence(ctx, req.DisputeId, caller, req.Note, isAdmin(ctx))
+	if err != nil {
+		return nil, offerError(err, "cannot add dispute evidence")
+	}
+	note := d.Evidence[len(d.Evidence)-1].Note
+	events.Emit(h.nc, events.ExchangeDisputeEvidence, disputeEvent(d, offer, caller, note))
+	return disputeResponse(d, offer), nil
+}
+
+// ResolveDispute is reserved to admins.
+func (h *ExchangeHandler) ResolveDispute(ctx context.Context, req *exchangepb.ResolveDisputeRequest) (*exchangepb.DisputeResponse, error) {
+	admin, err := auth.RequireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.DisputeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "dispute_id is required")
+	}
+	d, offer, err := h.disputes.ResolveDispute(ctx, req.DisputeId, admin, req.Resolution, req.ReverseSwap)
+	if err != nil {
+		return nil, offerError(err, "cannot resolve dispute")
+	}
+	events.Emit(h.nc, events.ExchangeDisputeResolved, disputeEvent(d, offer, admin, d.Resolution))
+	return disputeResponse(d, offer), nil
+}
+
+func (h *ExchangeHandler) GetDispute(ctx context.Context, req *exchangepb.DisputeID) (*exchangepb.DisputeResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "dispute id is required")
+	}
+	d, offer, err := h.disputes.GetDispute(ctx, req.Id, caller, isAdmin(ctx))
+	if err != nil {
+		return nil, offerError(err, "cannot load dispute")
+	}
+	return disputeResponse(d, offer), nil
+}
+
+func isAdmin(ctx context.Context) bool {
+	return auth.RoleFromContext(ctx) == auth.RoleAdmin
+}
+
+func disputeResponse(d *domain.Dispute, offer *domain.ExchangeOffer) *exchangepb.DisputeResponse {
+	return &exchangepb.DisputeResponse{Dispute: mapDispute(d), Offer: mapDomain(offer)}
+}
+
+func mapDispute(d *domain.Dispute) *exchangepb.Dispute {
+	out := &exchangepb.Dispute{
+		Id:         d.ID.Hex(),
+		OfferId:    d.OfferID.Hex(),
+		OpenedBy:   d.OpenedBy.Hex(),
+		AgainstId:  d.AgainstID.Hex(),
+		Reason:     d.Reason,
+		Status:     d.Status,
+		Resolution: d.Resolution,
+		Reversed:   d.Reversed,
+		CreatedAt:  d.CreatedAt.Time().String(),
+	}
+	if !d.ResolvedBy.IsZero() {
+		out.ResolvedBy = d.ResolvedBy.Hex()
+		out.ResolvedAt = d.ResolvedAt.Time().String()
+	}
+	for _, ev := range d.Evidence {
+		out.Evidence = append(out.Evidence, &exchangepb.DisputeEvidence{
+			AuthorId:  ev.AuthorID.Hex(),
+			Note:      ev.Note,
+			CreatedAt: ev.CreatedAt.Time().String(),
+		})
+	}
+	return out
+}
+
+func disputeEvent(d *domain.Dispute, offer *domain.ExchangeOffer, actorID, note string) events.DisputeEvent {
+	return events.DisputeEvent{
+		DisputeID:      d.ID.Hex(),
+		OfferID:        offer.ID.Hex(),
+		OwnerID:        offer.OwnerID.Hex(),
+		CounterpartyID: offer.CounterpartyID.Hex(),
+		ActorID:        actorID,
+		Status:         d.Status,
+		Note:           note,
+		Reversed:       d.Reversed,
+	}
+}
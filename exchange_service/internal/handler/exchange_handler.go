@@ -2,33 +2,64 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/usecase"
 	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type ExchangeHandler struct {
 	exchangepb.UnimplementedExchangeServiceServer
-	uc usecase.ExchangeUseCase
-	nc *nats.Conn
+	uc         usecase.ExchangeUseCase
+	matches    usecase.MatchUseCase
+	reputation usecase.ReputationUseCase
+	disputes   usecase.DisputeUseCase
+	messages   usecase.MessageUseCase
+	nc         *nats.Conn
+	offerTTL   time.Duration
 }
 
-func NewExchangeHandler(uc usecase.ExchangeUseCase, nc *nats.Conn) *ExchangeHandler {
-	return &ExchangeHandler{uc: uc, nc: nc}
+// NewExchangeHandler creates the gRPC handler; offerTTL is the lifetime given
+// to offers created without expires_at.
+func NewExchangeHandler(
+	uc usecase.ExchangeUseCase,
+	matches usecase.MatchUseCase,
+	reputation usecase.ReputationUseCase,
+	disputes usecase.DisputeUseCase,
+	messages usecase.MessageUseCase,
+	nc *nats.Conn,
+	offerTTL time.Duration,
+) *ExchangeHandler {
+	return &ExchangeHandler{uc: uc, matches: matches, reputation: reputation, disputes: disputes, messages: messages, nc: nc, offerTTL: offerTTL}
 }
 
 func (h *ExchangeHandler) CreateOffer(ctx context.Context, req *exchangepb.CreateOfferRequest) (*exchangepb.OfferResponse, error) {
-	if req == nil || req.OwnerId == "" || req.CounterpartyId == "" ||
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.CounterpartyId == "" ||
 		len(req.OfferedBookIds) == 0 || len(req.RequestedBookIds) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "owner_id, counterparty_id, offered_book_ids and requested_book_ids are required")
+		return nil, status.Error(codes.InvalidArgument, "counterparty_id, offered_book_ids and requested_book_ids are required")
+	}
+	if req.OwnerId == "" {
+		req.OwnerId = caller
+	} else if req.OwnerId != caller {
+		return nil, status.Error(codes.PermissionDenied, "cannot create an offer on behalf of another user")
 	}
 
 	ownerOID, err := primitive.ObjectIDFromHex(req.OwnerId)
@@ -40,31 +71,43 @@ func (h *ExchangeHandler) CreateOffer(ctx context.Context, req *exchangepb.Creat
 		return nil, status.Error(codes.InvalidArgument, "invalid counterparty_id")
 	}
 
-	offered := toObjectIDs(req.OfferedBookIds)
-	requested := toObjectIDs(req.RequestedBookIds)
+	offered, err := toObjectIDs(req.OfferedBookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "offered_book_ids: %v", err)
+	}
+	requested, err := toObjectIDs(req.RequestedBookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "requested_book_ids: %v", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(h.offerTTL)
+	if req.ExpiresAt != "" {
+		if expiresAt, err = time.Parse(time.RFC3339, req.ExpiresAt); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be an RFC 3339 timestamp")
+		}
+		if !expiresAt.After(now) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+	}
 
-	now := primitive.NewDateTimeFromTime(time.Now())
 	offer := &domain.ExchangeOffer{
 		OwnerID:          ownerOID,
 		CounterpartyID:   cpOID,
 		OfferedBookIDs:   offered,
 		RequestedBookIDs: requested,
-		Status:           "PENDING",
-		CreatedAt:        now,
-		UpdatedAt:        now,
+		Status:           domain.StatusPending,
+		CreatedAt:        primitive.NewDateTimeFromTime(now),
+		UpdatedAt:        primitive.NewDateTimeFromTime(now),
+		ExpiresAt:        primitive.NewDateTimeFromTime(expiresAt),
 	}
 
 	created, err := h.uc.CreateOffer(ctx, offer)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot create offer: %v", err)
+		return nil, offerError(err, "cannot create offer")
 	}
 
-	evt := struct {
-		OfferID string `json:"offer_id"`
-	}{OfferID: created.ID.Hex()}
-	if data, _ := json.Marshal(evt); data != nil {
-		h.nc.Publish("exchange.created", data)
-	}
+	events.Emit(h.nc, events.ExchangeOffered, offerEvent(created))
 
 	return &exchangepb.OfferResponse{Offer: mapDomain(created)}, nil
 }
@@ -91,47 +134,160 @@ func (h *ExchangeHandler) ListOffersByUser(ctx context.Context, req *exchangepb.
 	return &exchangepb.OfferList{Offers: mapDomainList(offers)}, nil
 }
 
-func (h *ExchangeHandler) ListPendingOffers(ctx context.Context, _ *exchangepb.Empty) (*exchangepb.OfferList, error) {
+// ListPendingOffers lists pending offers, with each owner's reputation when
+// include_reputation is set.
+func (h *ExchangeHandler) ListPendingOffers(ctx context.Context, req *exchangepb.ListPendingRequest) (*exchangepb.OfferList, error) {
 	offers, err := h.uc.ListPendingOffers(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot list pending offers: %v", err)
 	}
-	return &exchangepb.OfferList{Offers: mapDomainList(offers)}, nil
+	list := mapDomainList(offers)
+	if req == nil || !req.IncludeReputation || len(offers) == 0 {
+		return &exchangepb.OfferList{Offers: list}, nil
+	}
+
+	owners := make([]primitive.ObjectID, len(offers))
+	for i, o := range offers {
+		owners[i] = o.OwnerID
+	}
+	reps, err := h.reputation.Reputations(ctx, owners)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot load owner reputations: %v", err)
+	}
+	for i, o := range offers {
+		list[i].OwnerReputation = mapReputation(reps[o.OwnerID])
+	}
+	return &exchangepb.OfferList{Offers: list}, nil
 }
 
 func (h *ExchangeHandler) AcceptOffer(ctx context.Context, req *exchangepb.AcceptOfferRequest) (*exchangepb.OfferResponse, error) {
-	if req == nil || req.OfferId == "" || req.RequesterId == "" {
-		return nil, status.Error(codes.InvalidArgument, "offer_id and requester_id are required")
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	if req.RequesterId == "" {
+		req.RequesterId = caller
+	} else if req.RequesterId != caller {
+		return nil, status.Error(codes.PermissionDenied, "cannot accept an offer on behalf of another user")
 	}
 	offer, err := h.uc.AcceptOffer(ctx, req.OfferId, req.RequesterId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot accept offer: %v", err)
+		return nil, offerError(err, "cannot accept offer")
 	}
+	events.Emit(h.nc, events.ExchangeAccepted, offerEvent(offer))
 	return &exchangepb.OfferResponse{Offer: mapDomain(offer)}, nil
 }
 
 func (h *ExchangeHandler) DeclineOffer(ctx context.Context, req *exchangepb.OfferID) (*exchangepb.OfferResponse, error) {
+	return h.changeStatus(ctx, req, h.uc.DeclineOffer, events.ExchangeDeclined, "cannot decline offer")
+}
+
+func (h *ExchangeHandler) CancelOffer(ctx context.Context, req *exchangepb.OfferID) (*exchangepb.OfferResponse, error) {
+	return h.changeStatus(ctx, req, h.uc.CancelOffer, events.ExchangeCancelled, "cannot cancel offer")
+}
+
+func (h *ExchangeHandler) CompleteOffer(ctx context.Context, req *exchangepb.OfferID) (*exchangepb.OfferResponse, error) {
+	return h.changeStatus(ctx, req, h.uc.CompleteOffer, events.ExchangeCompleted, "cannot complete offer")
+}
+
+// changeStatus runs a caller-authorized status change and emits its event.
+func (h *ExchangeHandler) changeStatus(
+	ctx context.Context,
+	req *exchangepb.OfferID,
+	change func(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error),
+	subject events.Subject[events.OfferEvent],
+	failure string,
+) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if req == nil || req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "offer id is required")
 	}
-	offer, err := h.uc.DeclineOffer(ctx, req.Id)
+	offer, err := change(ctx, req.Id, caller)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot decline offer: %v", err)
+		return nil, offerError(err, failure)
 	}
+	events.Emit(h.nc, subject, offerEvent(offer))
 	return &exchangepb.OfferResponse{Offer: mapDomain(offer)}, nil
 }
 
+func (h *ExchangeHandler) CounterOffer(ctx context.Context, req *exchangepb.CounterOfferRequest) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	offered, err := toObjectIDs(req.OfferedBookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "offered_book_ids: %v", err)
+	}
+	requested, err := toObjectIDs(req.RequestedBookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "requested_book_ids: %v", err)
+	}
+
+	parent, created, err := h.uc.CounterOffer(ctx, req.OfferId, &domain.ExchangeOffer{
+		OfferedBookIDs:   offered,
+		RequestedBookIDs: requested,
+		ExpiresAt:        primitive.NewDateTimeFromTime(time.Now().Add(h.offerTTL)),
+	}, caller)
+	if err != nil {
+		return nil, offerError(err, "cannot counter offer")
+	}
+	events.Emit(h.nc, events.ExchangeCountered, offerEvent(parent))
+	events.Emit(h.nc, events.ExchangeOffered, offerEvent(created))
+	return &exchangepb.OfferResponse{Offer: mapDomain(created)}, nil
+}
+
+// OfferExpired announces an offer that the expiry sweeper has expired.
+func (h *ExchangeHandler) OfferExpired(o *domain.ExchangeOffer) {
+	events.Emit(h.nc, events.ExchangeExpired, offerEvent(o))
+}
+
+func (h *ExchangeHandler) GetNegotiation(ctx context.Context, req *exchangepb.OfferID) (*exchangepb.OfferList, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer id is required")
+	}
+	chain, err := h.uc.GetNegotiation(ctx, req.Id)
+	if err != nil {
+		return nil, offerError(err, "cannot load negotiation")
+	}
+	return &exchangepb.OfferList{Offers: mapDomainList(chain)}, nil
+}
+
 func (h *ExchangeHandler) DeleteOffer(ctx context.Context, req *exchangepb.OfferID) (*exchangepb.Empty, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if req == nil || req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "offer id is required")
 	}
-	if err := h.uc.DeleteOffer(ctx, req.Id); err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot delete offer: %v", err)
+	offer, err := h.uc.GetOfferByID(ctx, req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "offer not found: %v", err)
+	}
+	if err := h.uc.DeleteOffer(ctx, req.Id, caller); err != nil {
+		return nil, offerError(err, "cannot delete offer")
 	}
+	events.Emit(h.nc, events.ExchangeDeleted, offerEvent(offer))
 	return &exchangepb.Empty{}, nil
 }
 
+// UpdateOffer edits a pending offer. The owner cannot be changed and the
+// status only moves through the dedicated RPCs; empty fields are kept.
 func (h *ExchangeHandler) UpdateOffer(ctx context.Context, req *exchangepb.UpdateOfferRequest) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if req == nil || req.Offer == nil || req.Offer.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "offer with id is required")
 	}
@@ -140,60 +296,176 @@ func (h *ExchangeHandler) UpdateOffer(ctx context.Context, req *exchangepb.Updat
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid offer id")
 	}
-	ownerOID, err := primitive.ObjectIDFromHex(req.Offer.OwnerId)
+	if req.Offer.OwnerId != "" && req.Offer.OwnerId != caller {
+		return nil, status.Error(codes.PermissionDenied, "offer owner cannot be changed")
+	}
+	var cpOID primitive.ObjectID
+	if req.Offer.CounterpartyId != "" {
+		if cpOID, err = primitive.ObjectIDFromHex(req.Offer.CounterpartyId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid counterparty_id")
+		}
+	}
+	offered, err := toObjectIDs(req.Offer.OfferedBookIds)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid owner_id")
+		return nil, status.Errorf(codes.InvalidArgument, "offered_book_ids: %v", err)
 	}
-	cpOID, err := primitive.ObjectIDFromHex(req.Offer.CounterpartyId)
+	requested, err := toObjectIDs(req.Offer.RequestedBookIds)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid counterparty_id")
+		return nil, status.Errorf(codes.InvalidArgument, "requested_book_ids: %v", err)
 	}
 
 	dom := &domain.ExchangeOffer{
 		ID:               oid,
-		OwnerID:          ownerOID,
 		CounterpartyID:   cpOID,
-		OfferedBookIDs:   toObjectIDs(req.Offer.OfferedBookIds),
-		RequestedBookIDs: toObjectIDs(req.Offer.RequestedBookIds),
+		OfferedBookIDs:   offered,
+		RequestedBookIDs: requested,
 		Status:           req.Offer.Status,
 		UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
 	}
 
-	updated, err := h.uc.UpdateOffer(ctx, dom)
+	updated, rev, err := h.uc.UpdateOffer(ctx, dom, caller)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot update offer: %v", err)
+		return nil, offerError(err, "cannot update offer")
 	}
+	h.offerEdited(updated, rev)
 	return &exchangepb.OfferResponse{Offer: mapDomain(updated)}, nil
 }
 
-func (h *ExchangeHandler) AddOfferedBook(ctx context.Context, req *exchangepb.BookOpRequest) (*exchangepb.OfferResponse, error) {
-	if req == nil || req.OfferId == "" || req.BookId == "" {
-		return nil, status.Error(codes.InvalidArgument, "offer_id and book_id are required")
+// PatchOffer edits only the fields named in update_mask; values of the other
+// fields are ignored.
+func (h *ExchangeHandler) PatchOffer(ctx context.Context, req *exchangepb.PatchOfferRequest) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
 	}
-	offer, err := h.uc.AddOfferedBook(ctx, req.OfferId, req.BookId)
+	if req == nil || req.Offer == nil || req.Offer.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer with id is required")
+	}
+	oid, err := primitive.ObjectIDFromHex(req.Offer.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot add offered book: %v", err)
+		return nil, status.Error(codes.InvalidArgument, "invalid offer id")
 	}
-	return &exchangepb.OfferResponse{Offer: mapDomain(offer)}, nil
+
+	paths := req.GetUpdateMask().GetPaths()
+	patch := &domain.ExchangeOffer{ID: oid}
+	for _, p := range paths {
+		switch p {
+		case domain.FieldCounterparty:
+			if patch.CounterpartyID, err = primitive.ObjectIDFromHex(req.Offer.CounterpartyId); err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid counterparty_id")
+			}
+		case domain.FieldOfferedBooks:
+			if patch.OfferedBookIDs, err = toObjectIDs(req.Offer.OfferedBookIds); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "offered_book_ids: %v", err)
+			}
+		case domain.FieldRequestedBooks:
+			if patch.RequestedBookIDs, err = toObjectIDs(req.Offer.RequestedBookIds); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "requested_book_ids: %v", err)
+			}
+		case domain.FieldExpiresAt:
+			t, err := time.Parse(time.RFC3339, req.Offer.ExpiresAt)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "expires_at must be an RFC 3339 timestamp")
+			}
+			patch.ExpiresAt = primitive.NewDateTimeFromTime(t)
+		}
+	}
+
+	updated, rev, err := h.uc.PatchOffer(ctx, patch, paths, caller)
+	if err != nil {
+		return nil, offerError(err, "cannot patch offer")
+	}
+	h.offerEdited(updated, rev)
+	return &exchangepb.OfferResponse{Offer: mapDomain(updated)}, nil
+}
+
+func (h *ExchangeHandler) AddOfferedBook(ctx context.Context, req *exchangepb.BookOpRequest) (*exchangepb.OfferResponse, error) {
+	return h.editBooks(ctx, req, h.uc.AddOfferedBook, "cannot add offered book")
 }
 
 func (h *ExchangeHandler) RemoveOfferedBook(ctx context.Context, req *exchangepb.BookOpRequest) (*exchangepb.OfferResponse, error) {
+	return h.editBooks(ctx, req, h.uc.RemoveOfferedBook, "cannot remove offered book")
+}
+
+func (h *ExchangeHandler) AddRequestedBook(ctx context.Context, req *exchangepb.BookOpRequest) (*exchangepb.OfferResponse, error) {
+	return h.editBooks(ctx, req, h.uc.AddRequestedBook, "cannot add requested book")
+}
+
+func (h *ExchangeHandler) RemoveRequestedBook(ctx context.Context, req *exchangepb.BookOpRequest) (*exchangepb.OfferResponse, error) {
+	return h.editBooks(ctx, req, h.uc.RemoveRequestedBook, "cannot remove requested book")
+}
+
+type bookEdit func(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+
+func (h *ExchangeHandler) editBooks(ctx context.Context, req *exchangepb.BookOpRequest, edit bookEdit, failure string) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if req == nil || req.OfferId == "" || req.BookId == "" {
 		return nil, status.Error(codes.InvalidArgument, "offer_id and book_id are required")
 	}
-	offer, err := h.uc.RemoveOfferedBook(ctx, req.OfferId, req.BookId)
+	if _, err := primitive.ObjectIDFromHex(req.BookId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid book_id")
+	}
+	offer, rev, err := edit(ctx, req.OfferId, req.BookId, caller)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot remove offered book: %v", err)
+		return nil, offerError(err, failure)
 	}
+	h.offerEdited(offer, rev)
 	return &exchangepb.OfferResponse{Offer: mapDomain(offer)}, nil
 }
 
-func (h *ExchangeHandler) ListAllOffers(ctx context.Context, _ *exchangepb.Empty) (*exchangepb.OfferList, error) {
-	offers, err := h.uc.ListAllOffers(ctx)
+// offerEdited tells the counterparty about a recorded revision. Edits that
+// changed nothing are not announced.
+func (h *ExchangeHandler) offerEdited(offer *domain.ExchangeOffer, rev *domain.OfferRevision) {
+	if rev == nil {
+		return
+	}
+	evt := offerEvent(offer)
+	evt.Revision = rev.Number
+	evt.EditorID = rev.EditorID.Hex()
+	for _, c := range rev.Changes {
+		evt.Changed = append(evt.Changed, c.Field)
+	}
+	events.Emit(h.nc, events.ExchangeUpdated, evt)
+}
+
+func (h *ExchangeHandler) ListAllOffers(ctx context.Context, req *exchangepb.ListOffersRequest) (*exchangepb.OfferList, error) {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.OfferSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f := domain.OfferFilter{Status: req.GetStatus()}
+	if req.GetUserId() != "" {
+		if f.UserID, err = primitive.ObjectIDFromHex(req.UserId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+	}
+	if f.CreatedAfter, err = parseBound("created_after", req.GetCreatedAfter()); err != nil {
+		return nil, err
+	}
+	if f.CreatedBefore, err = parseBound("created_before", req.GetCreatedBefore()); err != nil {
+		return nil, err
+	}
+
+	offers, next, err := h.uc.ListAllOffers(ctx, f, q)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot list all offers: %v", err)
 	}
-	return &exchangepb.OfferList{Offers: mapDomainList(offers)}, nil
+	return &exchangepb.OfferList{Offers: mapDomainList(offers), NextPageToken: next}, nil
+}
+
+// parseBound parses an optional RFC 3339 range bound.
+func parseBound(name, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 timestamp", name)
+	}
+	return t, nil
 }
 
 func (h *ExchangeHandler) ListOffersByStatus(ctx context.Context, req *exchangepb.StatusRequest) (*exchangepb.OfferList, error) {
@@ -208,6 +480,13 @@ func (h *ExchangeHandler) ListOffersByStatus(ctx context.Context, req *exchangep
 }
 
 func mapDomain(o *domain.ExchangeOffer) *exchangepb.ExchangeOffer {
+	var parentID, expiresAt string
+	if !o.ParentID.IsZero() {
+		parentID = o.ParentID.Hex()
+	}
+	if o.ExpiresAt != 0 {
+		expiresAt = o.ExpiresAt.Time().UTC().Format(time.RFC3339)
+	}
 	return &exchangepb.ExchangeOffer{
 		Id:               o.ID.Hex(),
 		OwnerId:          o.OwnerID.Hex(),
@@ -217,6 +496,41 @@ func mapDomain(o *domain.ExchangeOffer) *exchangepb.ExchangeOffer {
 		Status:           o.Status,
 		CreatedAt:        o.CreatedAt.Time().String(),
 		UpdatedAt:        o.UpdatedAt.Time().String(),
+		ParentId:         parentID,
+		ExpiresAt:        expiresAt,
+		Revision:         int32(o.Revision),
+		Revisions:        mapRevisions(o.Revisions),
+	}
+}
+
+func mapRevisions(revs []domain.OfferRevision) []*exchangepb.OfferRevision {
+	out := make([]*exchangepb.OfferRevision, len(revs))
+	for i, r := range revs {
+		pr := &exchangepb.OfferRevision{
+			Number:   int32(r.Number),
+			EditorId: r.EditorID.Hex(),
+			EditedAt: r.EditedAt.Time().String(),
+		}
+		for _, c := range r.Changes {
+			pr.Changes = append(pr.Changes, &exchangepb.FieldChange{
+				Field:   c.Field,
+				Added:   toHexs(c.Added),
+				Removed: toHexs(c.Removed),
+				From:    c.From,
+				To:      c.To,
+			})
+		}
+		out[i] = pr
+	}
+	return out
+}
+
+func offerEvent(o *domain.ExchangeOffer) events.OfferEvent {
+	return events.OfferEvent{
+		OfferID:        o.ID.Hex(),
+		OwnerID:        o.OwnerID.Hex(),
+		CounterpartyID: o.CounterpartyID.Hex(),
+		Status:         o.Status,
 	}
 }
 
@@ -236,12 +550,101 @@ func toHexs(ids []primitive.ObjectID) []string {
 	return res
 }
 
-func toObjectIDs(strs []string) []primitive.ObjectID {
+// toObjectIDs parses every id and reports all the malformed ones at once.
+func toObjectIDs(strs []string) ([]primitive.ObjectID, error) {
 	out := make([]primitive.ObjectID, len(strs))
+	var invalid []string
 	for i, s := range strs {
-		if oid, err := primitive.ObjectIDFromHex(s); err == nil {
-			out[i] = oid
+		oid, err := primitive.ObjectIDFromHex(s)
+		if err != nil {
+			invalid = append(invalid, s)
+			continue
 		}
+		out[i] = oid
 	}
-	return out
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid ids: %s", strings.Join(invalid, ", "))
+	}
+	return out, nil
+}
+
+// offerError maps usecase errors to gRPC statuses; anything unknown is Internal.
+func offerError(err error, failure string) error {
+	var (
+		oe *usecase.OwnershipError
+		re *usecase.ReservedError
+		te *usecase.TransitionError
+	)
+	switch {
+	case errors.As(err, &oe):
+		return ownershipStatus(oe)
+	case errors.As(err, &re):
+		return reservedStatus(re)
+	case errors.Is(err, usecase.ErrInvalidScore), errors.Is(err, usecase.ErrCommentTooLong),
+		errors.Is(err, usecase.ErrNoteRequired), errors.Is(err, usecase.ErrNoteTooLong),
+		errors.Is(err, usecase.ErrMessageRequired), errors.Is(err, usecase.ErrMessageTooLong),
+		errors.Is(err, usecase.ErrNoBooks), errors.Is(err, usecase.ErrEmptyMask),
+		errors.Is(err, usecase.ErrFieldReadOnly), errors.Is(err, usecase.ErrInvalidExpiry):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrAlreadyRated), errors.Is(err, usecase.ErrDisputeExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", failure, err)
+	case errors.As(err, &te),
+		errors.Is(err, usecase.ErrNotRateable),
+		errors.Is(err, usecase.ErrOfferDisputed),
+		errors.Is(err, usecase.ErrDisputeClosed),
+		errors.Is(err, usecase.ErrOfferExpired),
+		errors.Is(err, usecase.ErrNotEditable),
+		errors.Is(err, usecase.ErrNotDeletable),
+		errors.Is(err, usecase.ErrStatusReadOnly):
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrNotOwner),
+		errors.Is(err, usecase.ErrNotCounterparty),
+		errors.Is(err, usecase.ErrNotParticipant),
+		errors.Is(err, usecase.ErrNotDisputeParty):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrOfferChanged), errors.Is(err, usecase.ErrSwapFailed),
+		errors.Is(err, usecase.ErrReversalFailed):
+		return status.Errorf(codes.Aborted, "%s: %v", failure, err)
+	case errors.Is(err, usecase.ErrDisputeNotFound):
+		return status.Error(codes.NotFound, "dispute not found")
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, "offer not found")
+	}
+	return status.Errorf(codes.Internal, "%s: %v", failure, err)
+}
+
+// ownershipStatus turns an OwnershipError into FailedPrecondition with one
+// PreconditionFailure violation per book.
+func ownershipStatus(oe *usecase.OwnershipError) error {
+	st := status.New(codes.FailedPrecondition, oe.Error())
+	pf := &errdetails.PreconditionFailure{}
+	for _, v := range oe.Violations {
+		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "NOT_OWNED",
+			Subject:     "book:" + v.BookID,
+			Description: "book is not in the library of user " + v.UserID,
+		})
+	}
+	if withDetails, err := st.WithDetails(pf); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// reservedStatus turns a ReservedError into FailedPrecondition with one
+// PreconditionFailure violation per book held by another offer.
+func reservedStatus(re *usecase.ReservedError) error {
+	st := status.New(codes.FailedPrecondition, re.Error())
+	pf := &errdetails.PreconditionFailure{}
+	for _, b := range re.Books {
+		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "RESERVED",
+			Subject:     "book:" + b.BookID,
+			Description: "book is reserved by exchange offer " + b.OfferID,
+		})
+	}
+	if withDetails, err := st.WithDetails(pf); err == nil {
+		st = withDetails
+	}
+	return st.Err()
 }
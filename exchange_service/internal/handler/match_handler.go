@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/usecase"
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+)
+
+func (h *ExchangeHandler) AddWant(ctx context.Context, req *exchangepb.WantRequest) (*exchangepb.WantList, error) {
+	userID, err := h.wantOwner(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	wants, err := h.matches.AddWant(ctx, userID, req.BookId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot add want: %v", err)
+	}
+	return mapWants(userID, wants), nil
+}
+
+func (h *ExchangeHandler) RemoveWant(ctx context.Context, req *exchangepb.WantRequest) (*exchangepb.WantList, error) {
+	userID, err := h.wantOwner(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	wants, err := h.matches.RemoveWant(ctx, userID, req.BookId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot remove want: %v", err)
+	}
+	return mapWants(userID, wants), nil
+}
+
+func (h *ExchangeHandler) ListWants(ctx context.Context, req *exchangepb.UserID) (*exchangepb.WantList, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	wants, err := h.matches.ListWants(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list wants: %v", err)
+	}
+	return mapWants(req.UserId, wants), nil
+}
+
+func (h *ExchangeHandler) FindMatches(ctx context.Context, req *exchangepb.FindMatchesRequest) (*exchangepb.MatchList, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = &exchangepb.FindMatchesRequest{}
+	}
+	if req.UserId == "" {
+		req.UserId = caller
+	}
+	matches, err := h.matches.FindMatches(ctx, req.UserId, int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot find matches: %v", err)
+	}
+	out := &exchangepb.MatchList{Matches: make([]*exchangepb.Match, len(matches))}
+	for i, m := range matches {
+		out.Matches[i] = mapMatch(m)
+	}
+	return out, nil
+}
+
+// CreateOfferFromMatch turns the current match between the caller and
+// partner_id into a regular offer: the caller offers the books the partner
+// wants and requests the ones the caller wants.
+func (h *ExchangeHandler) CreateOfferFromMatch(ctx context.Context, req *exchangepb.MatchOfferRequest) (*exchangepb.OfferResponse, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.PartnerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "partner_id is required")
+	}
+	m, err := h.matches.MatchWith(ctx, caller, req.PartnerId)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoMatch) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "cannot match users: %v", err)
+	}
+	mp := mapMatch(m)
+	return h.CreateOffer(ctx, &exchangepb.CreateOfferRequest{
+		OwnerId:          caller,
+		CounterpartyId:   mp.PartnerId,
+		OfferedBookIds:   mp.OfferedBookIds,
+		RequestedBookIds: mp.RequestedBookIds,
+		ExpiresAt:        req.ExpiresAt,
+	})
+}
+
+// wantOwner resolves whose want list a request edits; users may only edit
+// their own.
+func (h *ExchangeHandler) wantOwner(ctx context.Context, req *exchangepb.WantRequest) (string, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if req == nil || req.BookId == "" {
+		return "", status.Error(codes.InvalidArgument, "book_id is required")
+	}
+	if _, err := primitive.ObjectIDFromHex(req.BookId); err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid book_id")
+	}
+	if req.UserId != "" && req.UserId != caller {
+		return "", status.Error(codes.PermissionDenied, "cannot edit the want list of another user")
+	}
+	return caller, nil
+}
+
+func mapWants(userID string, wants []*domain.Want) *exchangepb.WantList {
+	out := &exchangepb.WantList{UserId: userID, BookIds: make([]string, len(wants))}
+	for i, w := range wants {
+		out.BookIds[i] = w.BookID.Hex()
+	}
+	return out
+}
+
+func mapMatch(m *domain.Match) *exchangepb.Match {
+	return &exchangepb.Match{
+		PartnerId:        m.PartnerID.Hex(),
+		OfferedBookIds:   toHexs(m.Give),
+		RequestedBookIds: toHexs(m.Get),
+		Score:            int32(m.Score()),
+	}
+}
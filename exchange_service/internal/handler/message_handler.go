@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+)
+
+// watchBuffer is how many live messages a slow watcher may lag behind before
+// its stream is closed.
+const watchBuffer = 64
+
+func (h *ExchangeHandler) SendOfferMessage(ctx context.Context, req *exchangepb.SendOfferMessageRequest) (*exchangepb.OfferMessage, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	m, offer, err := h.messages.SendMessage(ctx, req.OfferId, caller, req.Body)
+	if err != nil {
+		return nil, offerError(err, "cannot send message")
+	}
+	recipient := offer.CounterpartyID
+	if recipient == m.SenderID {
+		recipient = offer.OwnerID
+	}
+	out := mapMessage(m)
+	events.Emit(h.nc, events.ExchangeMessageSent, events.OfferMessageEvent{
+		MessageID:   out.Id,
+		OfferID:     out.OfferId,
+		SenderID:    out.SenderId,
+		RecipientID: recipient.Hex(),
+		Body:        out.Body,
+		CreatedAt:   out.CreatedAt,
+	})
+	return out, nil
+}
+
+// WatchOfferMessages streams the offer's thread so far and then every new
+// message until the client goes away. The live subscription is opened before
+// the history is read, so nothing posted in between is missed; messages seen
+// in both are sent once.
+func (h *ExchangeHandler) WatchOfferMessages(req *exchangepb.OfferID, stream exchangepb.ExchangeService_WatchOfferMessagesServer) error {
+	ctx := stream.Context()
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if req == nil || req.Id == "" {
+		return status.Error(codes.InvalidArgument, "offer id is required")
+	}
+
+	live := make(chan events.OfferMessageEvent, watchBuffer)
+	lagged := make(chan struct{}, 1)
+	sub, err := events.Subscribe(h.nc, events.ExchangeMessageSent, func(e events.OfferMessageEvent) {
+		if e.OfferID != req.Id {
+			return
+		}
+		select {
+		case live <- e:
+		default:
+			select {
+			case lagged <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "cannot watch messages: %v", err)
+	}
+	defer func() {
+		if err := sub.Unsubscribe(); err != nil {
+			log.Printf("unsubscribe message watcher for offer %s: %v", req.Id, err)
+		}
+	}()
+
+	history, err := h.messages.ListMessages(ctx, req.Id, caller)
+	if err != nil {
+		return offerError(err, "cannot load messages")
+	}
+	sent := make(map[string]bool, len(history))
+	for _, m := range history {
+		if err := stream.Send(mapMessage(m)); err != nil {
+			return err
+		}
+		sent[m.ID.Hex()] = true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-lagged:
+			return status.Error(codes.ResourceExhausted, "watcher fell behind, reconnect to resume")
+		case e := <-live:
+			if sent[e.MessageID] {
+				continue
+			}
+			if err := stream.Send(&exchangepb.OfferMessage{
+				Id:        e.MessageID,
+				OfferId:   e.OfferID,
+				SenderId:  e.SenderID,
+				Body:      e.Body,
+				CreatedAt: e.CreatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func mapMessage(m *domain.OfferMessage) *exchangepb.OfferMessage {
+	return &exchangepb.OfferMessage{
+		Id:        m.ID.Hex(),
+		OfferId:   m.OfferID.Hex(),
+		SenderId:  m.SenderID.Hex(),
+		Body:      m.Body,
+		CreatedAt: m.CreatedAt.Time().String(),
+	}
+}
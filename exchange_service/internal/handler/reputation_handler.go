@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	exchangepb "github.com/OshakbayAigerim/read_space/exchange_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+)
+
+// RateExchangePartner lets the caller rate the other party of an accepted
+// or completed offer.
+func (h *ExchangeHandler) RateExchangePartner(ctx context.Context, req *exchangepb.RateRequest) (*exchangepb.Rating, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	rating, err := h.reputation.RatePartner(ctx, req.OfferId, caller, int(req.Score), req.Comment)
+	if err != nil {
+		return nil, offerError(err, "cannot rate exchange partner")
+	}
+	return mapRating(rating), nil
+}
+
+func (h *ExchangeHandler) GetUserReputation(ctx context.Context, req *exchangepb.UserID) (*exchangepb.Reputation, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	rep, err := h.reputation.GetReputation(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot load reputation: %v", err)
+	}
+	return mapReputation(rep), nil
+}
+
+func mapRating(r *domain.Rating) *exchangepb.Rating {
+	return &exchangepb.Rating{
+		Id:        r.ID.Hex(),
+		OfferId:   r.OfferID.Hex(),
+		RaterId:   r.RaterID.Hex(),
+		RateeId:   r.RateeID.Hex(),
+		Score:     int32(r.Score),
+		Comment:   r.Comment,
+		CreatedAt: r.CreatedAt.Time().String(),
+	}
+}
+
+func mapReputation(r *domain.Reputation) *exchangepb.Reputation {
+	if r == nil {
+		return nil
+	}
+	return &exchangepb.Reputation{
+		UserId:       r.UserID.Hex(),
+		AverageScore: r.Average,
+		RatingCount:  r.Ratings,
+		TradeCount:   r.Trades,
+		DisputeCount: r.Disputes,
+	}
+}
@@ -0,0 +1,149 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateWantIndexes keeps one want per user and book, and lets the matcher
+// look wants up by book.
+func CreateWantIndexes(db *mongo.Database) {
+	collection := db.Collection("wants")
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "book_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "book_id", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for wants collection")
+}
+
+// CreateRatingIndexes lets each party rate an offer once and keeps the
+// reputation aggregation on ratee_id indexed.
+func CreateRatingIndexes(db *mongo.Database) {
+	collection := db.Collection("ratings")
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "offer_id", Value: 1}, {Key: "rater_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "ratee_id", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for ratings collection")
+}
+
+// CreateDisputeIndexes allows a single dispute per offer and keeps the
+// reputation's dispute count indexed.
+func CreateDisputeIndexes(db *mongo.Database) {
+	collection := db.Collection("disputes")
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "offer_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "against_id", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for disputes collection")
+}
+
+// CreateMessageIndexes serves an offer's thread in order from the index.
+func CreateMessageIndexes(db *mongo.Database) {
+	collection := db.Collection("offer_messages")
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "offer_id", Value: 1}, {Key: "_id", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for offer_messages collection")
+}
+
+// CreateOfferIndexes backs the keyset pagination of ListAllOffers: every sort
+// order ends in _id, and the filters are indexed ahead of the default order.
+func CreateOfferIndexes(db *mongo.Database) {
+	collection := db.Collection("exchange_offers")
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "counterparty_id", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for exchange_offers collection")
+}
+
+// BackfillOfferExpiry gives the pending offers created before offers
+// expired the expires_at they would have been created with, so the sweeper
+// expires them too. Offers without created_at are dated by their _id.
+func BackfillOfferExpiry(db *mongo.Database, ttl time.Duration) {
+	collection := db.Collection("exchange_offers")
+	filter := bson.M{"status": "PENDING", "expires_at": bson.M{"$exists": false}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"expires_at": bson.M{"$add": bson.A{
+			bson.M{"$ifNull": bson.A{"$created_at", bson.M{"$toDate": "$_id"}}},
+			ttl.Milliseconds(),
+		}},
+	}}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Fatalf("Failed to backfill offer expiry: %v", err)
+	}
+
+	log.Printf("Backfilled expires_at of %d pending offers", res.ModifiedCount)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DisputeRepository stores disputes. AddEvidence and Resolve only match an
+// open dispute and return mongo.ErrNoDocuments otherwise.
+type DisputeRepository interface {
+	// CreateDispute fails with a duplicate key error when the offer already
+	// has a dispute.
+	CreateDispute(ctx context.Context, d *domain.Dispute) error
+	GetDispute(ctx context.Context, id string) (*domain.Dispute, error)
+	AddEvidence(ctx context.Context, id primitive.ObjectID, ev domain.Evidence) (*domain.Dispute, error)
+	Resolve(ctx context.Context, id primitive.ObjectID, resolution string, reversed bool, by primitive.ObjectID) (*domain.Dispute, error)
+	// Reopen undoes Resolve when the resolution could not be carried out.
+	Reopen(ctx context.Context, id primitive.ObjectID) error
+	CountAgainst(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
@@ -2,22 +2,33 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ExchangeRepository interface {
 	CreateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error)
 	GetOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error)
+	GetCounterOffer(ctx context.Context, parentID primitive.ObjectID) (*domain.ExchangeOffer, error)
 	ListOffersByUser(ctx context.Context, ownerID string) ([]*domain.ExchangeOffer, error)
 	ListPendingOffers(ctx context.Context) ([]*domain.ExchangeOffer, error)
 	AcceptOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error)
+	TransitionStatus(ctx context.Context, id, from, to string) (*domain.ExchangeOffer, error)
 	DeclineOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error)
 	DeleteOffer(ctx context.Context, id string) error
 
-	UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error)
-	AddOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error)
-	RemoveOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error)
-	ListAllOffers(ctx context.Context) ([]*domain.ExchangeOffer, error)
+	// UpdateOffer returns mongo.ErrNoDocuments when the offer is no longer
+	// pending or was edited since it was read.
+	UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer, rev domain.OfferRevision) (*domain.ExchangeOffer, error)
+	// ListAllOffers returns one page of the offers matching f and the token
+	// of the next page.
+	ListAllOffers(ctx context.Context, f domain.OfferFilter, q *paging.Query) ([]*domain.ExchangeOffer, string, error)
 	ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error)
+	ListOverdueOffers(ctx context.Context, now time.Time) ([]*domain.ExchangeOffer, error)
+	// CountTrades counts the offers userID took part in that were accepted,
+	// including those since completed, disputed or reversed.
+	CountTrades(ctx context.Context, userID primitive.ObjectID) (int64, error)
 }
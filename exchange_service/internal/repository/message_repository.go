@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MessageRepository interface {
+	AddMessage(ctx context.Context, m *domain.OfferMessage) error
+	// ListMessages returns the thread of an offer, oldest first.
+	ListMessages(ctx context.Context, offerID primitive.ObjectID) ([]*domain.OfferMessage, error)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDisputeRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDisputeRepository(db *mongo.Database) DisputeRepository {
+	return &mongoDisputeRepo{
+		collection: db.Collection("disputes"),
+	}
+}
+
+func (r *mongoDisputeRepo) CreateDispute(ctx context.Context, d *domain.Dispute) error {
+	d.ID = primitive.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, d)
+	return err
+}
+
+func (r *mongoDisputeRepo) GetDispute(ctx context.Context, id string) (*domain.Dispute, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var d domain.Dispute
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *mongoDisputeRepo) AddEvidence(ctx context.Context, id primitive.ObjectID, ev domain.Evidence) (*domain.Dispute, error) {
+	return r.updateOpen(ctx, id, bson.M{"$push": bson.M{"evidence": ev}})
+}
+
+func (r *mongoDisputeRepo) Resolve(ctx context.Context, id primitive.ObjectID, resolution string, reversed bool, by primitive.ObjectID) (*domain.Dispute, error) {
+	return r.updateOpen(ctx, id, bson.M{"$set": bson.M{
+		"status":      domain.DisputeResolved,
+		"resolution":  resolution,
+		"reversed":    reversed,
+		"resolved_by": by,
+		"resolved_at": primitive.NewDateTimeFromTime(time.Now()),
+	}})
+}
+
+func (r *mongoDisputeRepo) Reopen(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": domain.DisputeResolved},
+		bson.M{
+			"$set":   bson.M{"status": domain.DisputeOpen, "reversed": false},
+			"$unset": bson.M{"resolution": "", "resolved_by": "", "resolved_at": ""},
+		})
+	return err
+}
+
+func (r *mongoDisputeRepo) CountAgainst(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"against_id": userID})
+}
+
+func (r *mongoDisputeRepo) updateOpen(ctx context.Context, id primitive.ObjectID, update bson.M) (*domain.Dispute, error) {
+	after := options.After
+	opts := options.FindOneAndUpdateOptions{ReturnDocument: &after}
+	var d domain.Dispute
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id, "status": domain.DisputeOpen}, update, &opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
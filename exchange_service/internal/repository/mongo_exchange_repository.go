@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,11 +23,13 @@ func NewMongoExchangeRepository(db *mongo.Database) ExchangeRepository {
 }
 
 func (r *mongoExchangeRepo) CreateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error) {
-	offer.ID = primitive.NewObjectID()
+	if offer.ID.IsZero() {
+		offer.ID = primitive.NewObjectID()
+	}
 	now := primitive.NewDateTimeFromTime(time.Now())
 	offer.CreatedAt = now
 	offer.UpdatedAt = now
-	offer.Status = "PENDING"
+	offer.Status = domain.StatusPending
 
 	if _, err := r.collection.InsertOne(ctx, offer); err != nil {
 		return nil, err
@@ -46,6 +49,15 @@ func (r *mongoExchangeRepo) GetOffer(ctx context.Context, id string) (*domain.Ex
 	return &offer, nil
 }
 
+// GetCounterOffer returns the offer that answers parentID.
+func (r *mongoExchangeRepo) GetCounterOffer(ctx context.Context, parentID primitive.ObjectID) (*domain.ExchangeOffer, error) {
+	var offer domain.ExchangeOffer
+	if err := r.collection.FindOne(ctx, bson.M{"parent_id": parentID}).Decode(&offer); err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
 func (r *mongoExchangeRepo) ListOffersByUser(ctx context.Context, ownerID string) ([]*domain.ExchangeOffer, error) {
 	oid, err := primitive.ObjectIDFromHex(ownerID)
 	if err != nil {
@@ -69,7 +81,7 @@ func (r *mongoExchangeRepo) ListOffersByUser(ctx context.Context, ownerID string
 }
 
 func (r *mongoExchangeRepo) ListPendingOffers(ctx context.Context) ([]*domain.ExchangeOffer, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "PENDING"})
+	cursor, err := r.collection.Find(ctx, bson.M{"status": domain.StatusPending})
 	if err != nil {
 		return nil, err
 	}
@@ -86,15 +98,19 @@ func (r *mongoExchangeRepo) ListPendingOffers(ctx context.Context) ([]*domain.Ex
 	return offers, nil
 }
 
+// AcceptOffer only matches a PENDING offer, so two concurrent accepts
+// cannot both win and swap the books twice.
 func (r *mongoExchangeRepo) AcceptOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error) {
-	return r.updateStatus(ctx, id, "ACCEPTED")
+	return r.TransitionStatus(ctx, id, domain.StatusPending, domain.StatusAccepted)
 }
 
 func (r *mongoExchangeRepo) DeclineOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error) {
-	return r.updateStatus(ctx, id, "DECLINED")
+	return r.TransitionStatus(ctx, id, domain.StatusPending, domain.StatusDeclined)
 }
 
-func (r *mongoExchangeRepo) updateStatus(ctx context.Context, id, status string) (*domain.ExchangeOffer, error) {
+// TransitionStatus sets the offer status to status if it is currently from;
+// otherwise it returns mongo.ErrNoDocuments.
+func (r *mongoExchangeRepo) TransitionStatus(ctx context.Context, id, from, status string) (*domain.ExchangeOffer, error) {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
@@ -103,7 +119,7 @@ func (r *mongoExchangeRepo) updateStatus(ctx context.Context, id, status string)
 	after := options.After
 	opts := options.FindOneAndUpdateOptions{ReturnDocument: &after}
 
-	filter := bson.M{"_id": objID}
+	filter := bson.M{"_id": objID, "status": from}
 	update := bson.M{"$set": bson.M{
 		"status":     status,
 		"updated_at": now,
@@ -127,46 +143,31 @@ func (r *mongoExchangeRepo) DeleteOffer(ctx context.Context, id string) error {
 
 // --- New methods ---
 
-func (r *mongoExchangeRepo) UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error) {
-	now := primitive.NewDateTimeFromTime(time.Now())
+// UpdateOffer stores the edited content of a pending offer and appends rev.
+// It only matches while the offer is still pending and at the revision it was
+// read at, so concurrent edits cannot overwrite each other.
+func (r *mongoExchangeRepo) UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer, rev domain.OfferRevision) (*domain.ExchangeOffer, error) {
 	after := options.After
 	opts := options.FindOneAndUpdateOptions{ReturnDocument: &after}
 
-	filter := bson.M{"_id": offer.ID}
-	update := bson.M{"$set": bson.M{
-		"owner_id":           offer.OwnerID,
+	filter := bson.M{
+		"_id":      offer.ID,
+		"status":   domain.StatusPending,
+		"revision": revisionFilter(offer.Revision),
+	}
+	set := bson.M{
 		"counterparty_id":    offer.CounterpartyID,
 		"offered_book_ids":   offer.OfferedBookIDs,
 		"requested_book_ids": offer.RequestedBookIDs,
-		"status":             offer.Status,
-		"updated_at":         now,
-	}}
-
-	var o domain.ExchangeOffer
-	if err := r.collection.FindOneAndUpdate(ctx, filter, update, &opts).Decode(&o); err != nil {
-		return nil, err
+		"revision":           rev.Number,
+		"updated_at":         rev.EditedAt,
 	}
-	return &o, nil
-}
-
-func (r *mongoExchangeRepo) AddOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error) {
-	objID, err := primitive.ObjectIDFromHex(offerID)
-	if err != nil {
-		return nil, err
-	}
-	bid, err := primitive.ObjectIDFromHex(bookID)
-	if err != nil {
-		return nil, err
+	if offer.ExpiresAt != 0 {
+		set["expires_at"] = offer.ExpiresAt
 	}
-
-	now := primitive.NewDateTimeFromTime(time.Now())
-	after := options.After
-	opts := options.FindOneAndUpdateOptions{ReturnDocument: &after}
-
-	filter := bson.M{"_id": objID}
 	update := bson.M{
-		"$push": bson.M{"offered_book_ids": bid},
-		"$set":  bson.M{"updated_at": now},
+		"$set":  set,
+		"$push": bson.M{"revisions": rev},
 	}
 
 	var o domain.ExchangeOffer
@@ -176,35 +177,50 @@ func (r *mongoExchangeRepo) AddOfferedBook(ctx context.Context, offerID, bookID
 	return &o, nil
 }
 
-func (r *mongoExchangeRepo) RemoveOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error) {
-	objID, err := primitive.ObjectIDFromHex(offerID)
-	if err != nil {
-		return nil, err
-	}
-	bid, err := primitive.ObjectIDFromHex(bookID)
-	if err != nil {
-		return nil, err
+// revisionFilter matches offers still at revision n. Offers stored before
+// edits were recorded have no revision field and count as revision 0.
+func revisionFilter(n int) any {
+	if n == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
 	}
+	return n
+}
 
-	now := primitive.NewDateTimeFromTime(time.Now())
-	after := options.After
-	opts := options.FindOneAndUpdateOptions{ReturnDocument: &after}
+func (r *mongoExchangeRepo) ListAllOffers(ctx context.Context, f domain.OfferFilter, q *paging.Query) ([]*domain.ExchangeOffer, string, error) {
+	filter := bson.M{}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if !f.UserID.IsZero() {
+		filter["$or"] = bson.A{bson.M{"owner_id": f.UserID}, bson.M{"counterparty_id": f.UserID}}
+	}
+	created := bson.M{}
+	if !f.CreatedAfter.IsZero() {
+		created["$gte"] = primitive.NewDateTimeFromTime(f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		created["$lt"] = primitive.NewDateTimeFromTime(f.CreatedBefore)
+	}
+	if len(created) > 0 {
+		filter["created_at"] = created
+	}
 
-	filter := bson.M{"_id": objID}
-	update := bson.M{
-		"$pull": bson.M{"offered_book_ids": bid},
-		"$set":  bson.M{"updated_at": now},
+	filter, opts := q.Find(filter)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, "", err
 	}
+	defer cursor.Close(ctx)
 
-	var o domain.ExchangeOffer
-	if err := r.collection.FindOneAndUpdate(ctx, filter, update, &opts).Decode(&o); err != nil {
-		return nil, err
+	var offers []*domain.ExchangeOffer
+	if err := cursor.All(ctx, &offers); err != nil {
+		return nil, "", err
 	}
-	return &o, nil
+	return paging.Page(q, offers)
 }
 
-func (r *mongoExchangeRepo) ListAllOffers(ctx context.Context) ([]*domain.ExchangeOffer, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+func (r *mongoExchangeRepo) ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status})
 	if err != nil {
 		return nil, err
 	}
@@ -221,8 +237,13 @@ func (r *mongoExchangeRepo) ListAllOffers(ctx context.Context) ([]*domain.Exchan
 	return offers, nil
 }
 
-func (r *mongoExchangeRepo) ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"status": status})
+// ListOverdueOffers returns pending offers whose expires_at is not after now.
+func (r *mongoExchangeRepo) ListOverdueOffers(ctx context.Context, now time.Time) ([]*domain.ExchangeOffer, error) {
+	filter := bson.M{
+		"status":     domain.StatusPending,
+		"expires_at": bson.M{"$lte": primitive.NewDateTimeFromTime(now)},
+	}
+	cursor, err := r.collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -238,3 +259,15 @@ func (r *mongoExchangeRepo) ListOffersByStatus(ctx context.Context, status strin
 	}
 	return offers, nil
 }
+
+func (r *mongoExchangeRepo) CountTrades(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{
+		"status": bson.M{"$in": []string{
+			domain.StatusAccepted, domain.StatusCompleted, domain.StatusDisputed, domain.StatusReversed,
+		}},
+		"$or": []bson.M{
+			{"owner_id": userID},
+			{"counterparty_id": userID},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoMessageRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoMessageRepository(db *mongo.Database) MessageRepository {
+	return &mongoMessageRepo{
+		collection: db.Collection("offer_messages"),
+	}
+}
+
+func (r *mongoMessageRepo) AddMessage(ctx context.Context, m *domain.OfferMessage) error {
+	m.ID = primitive.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, m)
+	return err
+}
+
+func (r *mongoMessageRepo) ListMessages(ctx context.Context, offerID primitive.ObjectID) ([]*domain.OfferMessage, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"offer_id": offerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*domain.OfferMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
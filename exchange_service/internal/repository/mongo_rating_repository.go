@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mongoRatingRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRatingRepository(db *mongo.Database) RatingRepository {
+	return &mongoRatingRepo{
+		collection: db.Collection("ratings"),
+	}
+}
+
+func (r *mongoRatingRepo) AddRating(ctx context.Context, rating *domain.Rating) error {
+	rating.ID = primitive.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, rating)
+	return err
+}
+
+func (r *mongoRatingRepo) Summaries(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]*domain.Reputation, error) {
+	out := make(map[primitive.ObjectID]*domain.Reputation, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"ratee_id": bson.M{"$in": userIDs}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$ratee_id",
+			"average": bson.M{"$avg": "$score"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			UserID  primitive.ObjectID `bson:"_id"`
+			Average float64            `bson:"average"`
+			Count   int64              `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		out[row.UserID] = &domain.Reputation{UserID: row.UserID, Average: row.Average, Ratings: row.Count}
+	}
+	return out, cursor.Err()
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoWantRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMongoWantRepository(db *mongo.Database) WantRepository {
+	return &mongoWantRepo{
+		collection: db.Collection("wants"),
+	}
+}
+
+func (r *mongoWantRepo) AddWant(ctx context.Context, userID, bookID primitive.ObjectID) error {
+	filter := bson.M{"user_id": userID, "book_id": bookID}
+	update := bson.M{"$setOnInsert": bson.M{
+		"created_at": primitive.NewDateTimeFromTime(time.Now()),
+	}}
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *mongoWantRepo) RemoveWant(ctx context.Context, userID, bookID primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "book_id": bookID})
+	return err
+}
+
+func (r *mongoWantRepo) ListWants(ctx context.Context, userID primitive.ObjectID) ([]*domain.Want, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+func (r *mongoWantRepo) ListWantsForBooks(ctx context.Context, bookIDs []primitive.ObjectID, excludeUser primitive.ObjectID) ([]*domain.Want, error) {
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(ctx, bson.M{
+		"book_id": bson.M{"$in": bookIDs},
+		"user_id": bson.M{"$ne": excludeUser},
+	})
+}
+
+func (r *mongoWantRepo) find(ctx context.Context, filter bson.M) ([]*domain.Want, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var wants []*domain.Want
+	for cursor.Next(ctx) {
+		var w domain.Want
+		if err := cursor.Decode(&w); err != nil {
+			return nil, err
+		}
+		wants = append(wants, &w)
+	}
+	return wants, cursor.Err()
+}
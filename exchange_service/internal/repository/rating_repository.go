@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RatingRepository interface {
+	// AddRating fails with a duplicate key error when the rater has already
+	// rated this offer.
+	AddRating(ctx context.Context, r *domain.Rating) error
+	// Summaries returns the average score and rating count of every user in
+	// userIDs that has been rated at least once.
+	Summaries(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]*domain.Reputation, error)
+}
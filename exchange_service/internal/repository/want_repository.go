@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WantRepository interface {
+	AddWant(ctx context.Context, userID, bookID primitive.ObjectID) error
+	RemoveWant(ctx context.Context, userID, bookID primitive.ObjectID) error
+	ListWants(ctx context.Context, userID primitive.ObjectID) ([]*domain.Want, error)
+	// ListWantsForBooks returns the wants of every user except excludeUser
+	// that target one of bookIDs.
+	ListWantsForBooks(ctx context.Context, bookIDs []primitive.ObjectID, excludeUser primitive.ObjectID) ([]*domain.Want, error)
+}
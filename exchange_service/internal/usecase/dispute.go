@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/cache"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const MaxDisputeNoteLength = 2000
+
+var (
+	ErrDisputeNotFound = errors.New("dispute not found")
+	ErrDisputeExists   = errors.New("offer already has a dispute")
+	ErrDisputeClosed   = errors.New("dispute is already resolved")
+	ErrNoteRequired    = errors.New("dispute reason or note is required")
+	ErrNoteTooLong     = errors.New("dispute note is too long")
+	ErrReversalFailed  = errors.New("swap reversal failed")
+	ErrNotDisputeParty = errors.New("only the offer participants or an admin may do this")
+)
+
+// DisputeUseCase handles complaints about accepted exchanges. Opening a
+// dispute freezes the offer; an admin resolves it, optionally giving every
+// book back.
+type DisputeUseCase interface {
+	OpenDispute(ctx context.Context, offerID, callerID, reason string) (*domain.Dispute, *domain.ExchangeOffer, error)
+	AddEvidence(ctx context.Context, disputeID, callerID, note string, admin bool) (*domain.Dispute, *domain.ExchangeOffer, error)
+	ResolveDispute(ctx context.Context, disputeID, adminID, resolution string, reverse bool) (*domain.Dispute, *domain.ExchangeOffer, error)
+	GetDispute(ctx context.Context, disputeID, callerID string, admin bool) (*domain.Dispute, *domain.ExchangeOffer, error)
+}
+
+type disputeUseCase struct {
+	*exchangeUseCase
+	disputes repository.DisputeRepository
+}
+
+func NewDisputeUseCase(
+	d repository.DisputeRepository,
+	r repository.ExchangeRepository,
+	c cache.ExchangeCache,
+	lc userlibpb.UserLibraryServiceClient,
+) DisputeUseCase {
+	return &disputeUseCase{
+		exchangeUseCase: &exchangeUseCase{repo: r, cache: c, libClient: lc},
+		disputes:        d,
+	}
+}
+
+// OpenDispute lets a party of an accepted or completed offer complain about
+// the other party. The offer moves to DISPUTED until an admin resolves it.
+func (u *disputeUseCase) OpenDispute(ctx context.Context, offerID, callerID, reason string) (*domain.Dispute, *domain.ExchangeOffer, error) {
+	reason, err := checkNote(reason)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, err := u.repo.GetOffer(ctx, offerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := requireParticipant(current, callerID); err != nil {
+		return nil, nil, err
+	}
+	if err := checkTransition(current.Status, domain.StatusDisputed); err != nil {
+		return nil, nil, err
+	}
+
+	opener, against := current.OwnerID, current.CounterpartyID
+	if callerID == current.CounterpartyID.Hex() {
+		opener, against = against, opener
+	}
+	now := primitive.NewDateTimeFromTime(time.Now())
+	d := &domain.Dispute{
+		OfferID:   current.ID,
+		OpenedBy:  opener,
+		AgainstID: against,
+		Reason:    reason,
+		Status:    domain.DisputeOpen,
+		Evidence:  []domain.Evidence{},
+		CreatedAt: now,
+	}
+
+	offer, err := u.transition(ctx, current, domain.StatusDisputed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.disputes.CreateDispute(ctx, d); err != nil {
+		if _, rerr := u.repo.TransitionStatus(context.WithoutCancel(ctx), offerID, domain.StatusDisputed, current.Status); rerr != nil {
+			log.Printf("cannot unfreeze offer %s after failed dispute: %v", offerID, rerr)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, nil, ErrDisputeExists
+		}
+		return nil, nil, err
+	}
+	return d, offer, nil
+}
+
+func (u *disputeUseCase) AddEvidence(ctx context.Context, disputeID, callerID, note string, admin bool) (*domain.Dispute, *domain.ExchangeOffer, error) {
+	note, err := checkNote(note)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, offer, err := u.GetDispute(ctx, disputeID, callerID, admin)
+	if err != nil {
+		return nil, nil, err
+	}
+	author, err := primitive.ObjectIDFromHex(callerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err = u.disputes.AddEvidence(ctx, d.ID, domain.Evidence{
+		AuthorID:  author,
+		Note:      note,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, ErrDisputeClosed
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, offer, nil
+}
+
+// ResolveDispute closes an open dispute. With reverse, every book goes back
+// to the user who had it before the exchange and the offer ends REVERSED;
+// otherwise the exchange stands and the offer ends COMPLETED. If the books
+// cannot be moved back, the dispute stays open.
+func (u *disputeUseCase) ResolveDispute(ctx context.Context, disputeID, adminID, resolution string, reverse bool) (*domain.Dispute, *domain.ExchangeOffer, error) {
+	d, err := u.loadDispute(ctx, disputeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.Status != domain.DisputeOpen {
+		return nil, nil, ErrDisputeClosed
+	}
+	offer, err := u.repo.GetOffer(ctx, d.OfferID.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	target := domain.StatusCompleted
+	if reverse {
+		target = domain.StatusReversed
+	}
+	if err := checkTransition(offer.Status, target); err != nil {
+		return nil, nil, err
+	}
+	admin, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err = u.disputes.Resolve(ctx, d.ID, strings.TrimSpace(resolution), reverse, admin)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, ErrDisputeClosed
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if reverse {
+		if err := u.moveBooks(ctx, offer.ID.Hex(), reverseMoves(offer)); err != nil {
+			u.reopen(ctx, d, "failed reversal")
+			return nil, nil, fmt.Errorf("%w: %v", ErrReversalFailed, err)
+		}
+	}
+	resolved, err := u.transition(ctx, offer, target)
+	if err != nil {
+		// Swap the books back as well, so that resolving again starts from
+		// the state the dispute was opened in.
+		if reverse {
+			if merr := u.moveBooks(context.WithoutCancel(ctx), offer.ID.Hex(), swapMoves(offer)); merr != nil {
+				log.Printf("cannot undo reversal of offer %s after failed transition: %v", offer.ID.Hex(), merr)
+			}
+		}
+		u.reopen(ctx, d, "failed transition")
+		return nil, nil, err
+	}
+	return d, resolved, nil
+}
+
+// reopen puts a dispute whose resolution could not be carried out back to
+// open, so it can be resolved again.
+func (u *disputeUseCase) reopen(ctx context.Context, d *domain.Dispute, reason string) {
+	if err := u.disputes.Reopen(context.WithoutCancel(ctx), d.ID); err != nil {
+		log.Printf("cannot reopen dispute %s after %s: %v", d.ID.Hex(), reason, err)
+	}
+}
+
+// GetDispute returns a dispute and its offer to the offer's parties and admins.
+func (u *disputeUseCase) GetDispute(ctx context.Context, disputeID, callerID string, admin bool) (*domain.Dispute, *domain.ExchangeOffer, error) {
+	d, err := u.loadDispute(ctx, disputeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	offer, err := u.repo.GetOffer(ctx, d.OfferID.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !admin && requireParticipant(offer, callerID) != nil {
+		return nil, nil, ErrNotDisputeParty
+	}
+	return d, offer, nil
+}
+
+func (u *disputeUseCase) loadDispute(ctx context.Context, id string) (*domain.Dispute, error) {
+	d, err := u.disputes.GetDispute(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrDisputeNotFound
+	}
+	return d, err
+}
+
+func checkNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return "", ErrNoteRequired
+	}
+	if len(note) > MaxDisputeNoteLength {
+		return "", ErrNoteTooLong
+	}
+	return note, nil
+}
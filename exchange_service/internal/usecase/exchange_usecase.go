@@ -2,27 +2,68 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// ErrSwapFailed is returned by AcceptOffer when the books could not change
+// hands; the completed steps have been rolled back and the offer is pending again.
+var ErrSwapFailed = errors.New("book ownership swap failed")
+
+// OwnershipViolation is a book that is not in the given user's library.
+type OwnershipViolation struct {
+	UserID string
+	BookID string
+}
+
+// OwnershipError is returned when an offer references books its parties do not hold.
+type OwnershipError struct {
+	Violations []OwnershipViolation
+}
+
+func (e *OwnershipError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("%s (user %s)", v.BookID, v.UserID)
+	}
+	return "books not owned: " + strings.Join(parts, ", ")
+}
+
 type ExchangeUseCase interface {
 	CreateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error)
 	GetOfferByID(ctx context.Context, id string) (*domain.ExchangeOffer, error)
 	ListOffersByUser(ctx context.Context, ownerID string) ([]*domain.ExchangeOffer, error)
 	ListPendingOffers(ctx context.Context) ([]*domain.ExchangeOffer, error)
 	AcceptOffer(ctx context.Context, offerID, requesterID string) (*domain.ExchangeOffer, error)
-	DeclineOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error)
-	DeleteOffer(ctx context.Context, id string) error
+	DeclineOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error)
+	CancelOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error)
+	CompleteOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error)
+	CounterOffer(ctx context.Context, parentID string, counter *domain.ExchangeOffer, callerID string) (parent, created *domain.ExchangeOffer, err error)
+	GetNegotiation(ctx context.Context, id string) ([]*domain.ExchangeOffer, error)
+	DeleteOffer(ctx context.Context, id, callerID string) error
 
-	UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error)
-	AddOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error)
-	RemoveOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error)
-	ListAllOffers(ctx context.Context) ([]*domain.ExchangeOffer, error)
+	// The edits below return the revision they recorded, or nil when the
+	// offer already looked as requested.
+	UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	PatchOffer(ctx context.Context, patch *domain.ExchangeOffer, fields []string, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	AddOfferedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	RemoveOfferedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	AddRequestedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	RemoveRequestedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error)
+	ListAllOffers(ctx context.Context, f domain.OfferFilter, q *paging.Query) ([]*domain.ExchangeOffer, string, error)
 	ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error)
+	ExpireOverdue(ctx context.Context, now time.Time) ([]*domain.ExchangeOffer, error)
 }
 
 type exchangeUseCase struct {
@@ -43,9 +84,21 @@ func NewExchangeUseCase(
 	}
 }
 
+// CreateOffer stores a new offer and reserves its offered books, so they
+// cannot be offered elsewhere or leave the owner's library while it is pending.
 func (u *exchangeUseCase) CreateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error) {
+	if err := u.checkOwnership(ctx, offer); err != nil {
+		return nil, err
+	}
+	if offer.ID.IsZero() {
+		offer.ID = primitive.NewObjectID()
+	}
+	if err := u.reserve(ctx, offer.ID, offer.OwnerID, offer.OfferedBookIDs); err != nil {
+		return nil, err
+	}
 	created, err := u.repo.CreateOffer(ctx, offer)
 	if err != nil {
+		u.release(ctx, offer.ID)
 		return nil, err
 	}
 	u.cache.InvalidatePending(ctx)
@@ -66,73 +119,409 @@ func (u *exchangeUseCase) ListPendingOffers(ctx context.Context) ([]*domain.Exch
 }
 
 func (u *exchangeUseCase) AcceptOffer(ctx context.Context, offerID, requesterID string) (*domain.ExchangeOffer, error) {
-	offer, err := u.repo.AcceptOffer(ctx, offerID)
+	current, err := u.repo.GetOffer(ctx, offerID)
 	if err != nil {
 		return nil, err
 	}
+	if err := requireCounterparty(current, requesterID); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(current.Status, domain.StatusAccepted); err != nil {
+		return nil, err
+	}
+	if err := checkNotExpired(current, time.Now()); err != nil {
+		return nil, err
+	}
+
+	offer, err := u.repo.AcceptOffer(ctx, offerID)
+	if err != nil {
+		return nil, conditional(err)
+	}
+	if err := u.swapBooks(ctx, offer); err != nil {
+		if _, rerr := u.repo.TransitionStatus(context.WithoutCancel(ctx), offerID, domain.StatusAccepted, domain.StatusPending); rerr != nil {
+			log.Printf("cannot reopen offer %s after failed swap: %v", offerID, rerr)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSwapFailed, err)
+	}
+	u.release(ctx, offer.ID)
 	u.cache.InvalidatePending(ctx)
 	u.cache.InvalidateUser(ctx, offer.OwnerID.Hex())
 	u.cache.InvalidateUser(ctx, requesterID)
 	return offer, nil
 }
 
-func (u *exchangeUseCase) DeclineOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error) {
-	o, err := u.repo.DeclineOffer(ctx, id)
+func (u *exchangeUseCase) DeclineOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error) {
+	current, err := u.repo.GetOffer(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := requireCounterparty(current, callerID); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(current.Status, domain.StatusDeclined); err != nil {
+		return nil, err
+	}
+	o, err := u.repo.DeclineOffer(ctx, id)
+	if err != nil {
+		return nil, conditional(err)
+	}
+	u.release(ctx, o.ID)
 	u.cache.InvalidatePending(ctx)
 	u.cache.InvalidateUser(ctx, o.OwnerID.Hex())
 	return o, nil
 }
 
-func (u *exchangeUseCase) DeleteOffer(ctx context.Context, id string) error {
+func (u *exchangeUseCase) CancelOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error) {
+	current, err := u.repo.GetOffer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOwner(current, callerID); err != nil {
+		return nil, err
+	}
+	o, err := u.transition(ctx, current, domain.StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	u.release(ctx, o.ID)
+	return o, nil
+}
+
+// CompleteOffer confirms that the books of an accepted offer were handed over.
+func (u *exchangeUseCase) CompleteOffer(ctx context.Context, id, callerID string) (*domain.ExchangeOffer, error) {
+	current, err := u.repo.GetOffer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireParticipant(current, callerID); err != nil {
+		return nil, err
+	}
+	if err := checkNotDisputed(current); err != nil {
+		return nil, err
+	}
+	return u.transition(ctx, current, domain.StatusCompleted)
+}
+
+// CounterOffer answers a pending offer with a new one from its counterparty:
+// the roles are swapped and the parent is marked COUNTERED. Empty book lists
+// in counter mirror the parent.
+func (u *exchangeUseCase) CounterOffer(ctx context.Context, parentID string, counter *domain.ExchangeOffer, callerID string) (*domain.ExchangeOffer, *domain.ExchangeOffer, error) {
+	parent, err := u.repo.GetOffer(ctx, parentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := requireCounterparty(parent, callerID); err != nil {
+		return nil, nil, err
+	}
+	if err := checkTransition(parent.Status, domain.StatusCountered); err != nil {
+		return nil, nil, err
+	}
+	if err := checkNotExpired(parent, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	counter.ParentID = parent.ID
+	counter.OwnerID = parent.CounterpartyID
+	counter.CounterpartyID = parent.OwnerID
+	if len(counter.OfferedBookIDs) == 0 {
+		counter.OfferedBookIDs = parent.RequestedBookIDs
+	}
+	if len(counter.RequestedBookIDs) == 0 {
+		counter.RequestedBookIDs = parent.OfferedBookIDs
+	}
+	if err := u.checkOwnership(ctx, counter); err != nil {
+		return nil, nil, err
+	}
+	counter.ID = primitive.NewObjectID()
+	if err := u.reserve(ctx, counter.ID, counter.OwnerID, counter.OfferedBookIDs); err != nil {
+		return nil, nil, err
+	}
+
+	parent, err = u.repo.TransitionStatus(ctx, parentID, domain.StatusPending, domain.StatusCountered)
+	if err != nil {
+		u.release(ctx, counter.ID)
+		return nil, nil, conditional(err)
+	}
+	created, err := u.repo.CreateOffer(ctx, counter)
+	if err != nil {
+		if _, rerr := u.repo.TransitionStatus(context.WithoutCancel(ctx), parentID, domain.StatusCountered, domain.StatusPending); rerr != nil {
+			log.Printf("cannot reopen offer %s after failed counter-offer: %v", parentID, rerr)
+		}
+		u.release(ctx, counter.ID)
+		return nil, nil, err
+	}
+	u.release(ctx, parent.ID)
+	u.cache.InvalidatePending(ctx)
+	u.cache.InvalidateUser(ctx, parent.OwnerID.Hex())
+	u.cache.InvalidateUser(ctx, created.OwnerID.Hex())
+	return parent, created, nil
+}
+
+// GetNegotiation returns the chain of offers and counter-offers that id
+// belongs to, from the first offer to the latest.
+func (u *exchangeUseCase) GetNegotiation(ctx context.Context, id string) ([]*domain.ExchangeOffer, error) {
+	offer, err := u.repo.GetOffer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for !offer.ParentID.IsZero() {
+		if offer, err = u.repo.GetOffer(ctx, offer.ParentID.Hex()); err != nil {
+			return nil, err
+		}
+	}
+
+	chain := []*domain.ExchangeOffer{offer}
+	for offer.Status == domain.StatusCountered {
+		next, err := u.repo.GetCounterOffer(ctx, offer.ID)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, next)
+		offer = next
+	}
+	return chain, nil
+}
+
+func (u *exchangeUseCase) DeleteOffer(ctx context.Context, id, callerID string) error {
 	o, err := u.repo.GetOffer(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := requireOwner(o, callerID); err != nil {
+		return err
+	}
+	if err := checkDeletable(o); err != nil {
+		return err
+	}
 	if err := u.repo.DeleteOffer(ctx, id); err != nil {
 		return err
 	}
+	u.release(ctx, o.ID)
 	u.cache.InvalidatePending(ctx)
 	u.cache.InvalidateUser(ctx, o.OwnerID.Hex())
 	return nil
 }
 
-func (u *exchangeUseCase) UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error) {
-	updated, err := u.repo.UpdateOffer(ctx, offer)
+// UpdateOffer edits the content of a pending offer. The owner and status
+// are kept; empty fields keep their current values.
+func (u *exchangeUseCase) UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	return u.edit(ctx, offer.ID.Hex(), callerID, func(next *domain.ExchangeOffer) error {
+		if offer.Status != "" && offer.Status != next.Status {
+			return ErrStatusReadOnly
+		}
+		if !offer.CounterpartyID.IsZero() {
+			next.CounterpartyID = offer.CounterpartyID
+		}
+		if len(offer.OfferedBookIDs) > 0 {
+			next.OfferedBookIDs = offer.OfferedBookIDs
+		}
+		if len(offer.RequestedBookIDs) > 0 {
+			next.RequestedBookIDs = offer.RequestedBookIDs
+		}
+		return nil
+	})
+}
+
+func (u *exchangeUseCase) AddOfferedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	return u.editBooks(ctx, offerID, bookID, callerID, func(next *domain.ExchangeOffer, bid primitive.ObjectID) {
+		next.OfferedBookIDs = append(next.OfferedBookIDs, bid)
+	})
+}
+
+func (u *exchangeUseCase) RemoveOfferedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	return u.editBooks(ctx, offerID, bookID, callerID, func(next *domain.ExchangeOffer, bid primitive.ObjectID) {
+		next.OfferedBookIDs = without(next.OfferedBookIDs, []primitive.ObjectID{bid})
+	})
+}
+
+func (u *exchangeUseCase) AddRequestedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	return u.editBooks(ctx, offerID, bookID, callerID, func(next *domain.ExchangeOffer, bid primitive.ObjectID) {
+		next.RequestedBookIDs = append(next.RequestedBookIDs, bid)
+	})
+}
+
+func (u *exchangeUseCase) RemoveRequestedBook(ctx context.Context, offerID, bookID, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	return u.editBooks(ctx, offerID, bookID, callerID, func(next *domain.ExchangeOffer, bid primitive.ObjectID) {
+		next.RequestedBookIDs = without(next.RequestedBookIDs, []primitive.ObjectID{bid})
+	})
+}
+
+func (u *exchangeUseCase) ListAllOffers(ctx context.Context, f domain.OfferFilter, q *paging.Query) ([]*domain.ExchangeOffer, string, error) {
+	return u.repo.ListAllOffers(ctx, f, q)
+}
+
+func (u *exchangeUseCase) ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error) {
+	return u.repo.ListOffersByStatus(ctx, status)
+}
+
+// ExpireOverdue moves every pending offer that is past its expires_at to
+// EXPIRED and returns the offers it expired. Offers that changed status in
+// the meantime are skipped.
+func (u *exchangeUseCase) ExpireOverdue(ctx context.Context, now time.Time) ([]*domain.ExchangeOffer, error) {
+	overdue, err := u.repo.ListOverdueOffers(ctx, now)
 	if err != nil {
 		return nil, err
 	}
-	u.cache.InvalidatePending(ctx)
-	u.cache.InvalidateUser(ctx, offer.OwnerID.Hex())
-	return updated, nil
+	var (
+		expired []*domain.ExchangeOffer
+		errs    []error
+	)
+	for _, o := range overdue {
+		e, err := u.repo.TransitionStatus(ctx, o.ID.Hex(), domain.StatusPending, domain.StatusExpired)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire offer %s: %w", o.ID.Hex(), err))
+			continue
+		}
+		expired = append(expired, e)
+		u.release(ctx, e.ID)
+		u.cache.InvalidateUser(ctx, e.OwnerID.Hex())
+		u.cache.InvalidateUser(ctx, e.CounterpartyID.Hex())
+	}
+	if len(expired) > 0 {
+		u.cache.InvalidatePending(ctx)
+	}
+	return expired, errors.Join(errs...)
 }
 
-func (u *exchangeUseCase) AddOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error) {
-	updated, err := u.repo.AddOfferedBook(ctx, offerID, bookID)
-	if err != nil {
+// transition moves current to status if the state machine allows it.
+func (u *exchangeUseCase) transition(ctx context.Context, current *domain.ExchangeOffer, status string) (*domain.ExchangeOffer, error) {
+	if err := checkTransition(current.Status, status); err != nil {
 		return nil, err
 	}
+	o, err := u.repo.TransitionStatus(ctx, current.ID.Hex(), current.Status, status)
+	if err != nil {
+		return nil, conditional(err)
+	}
 	u.cache.InvalidatePending(ctx)
-	u.cache.InvalidateUser(ctx, updated.OwnerID.Hex())
-	return updated, nil
+	u.cache.InvalidateUser(ctx, o.OwnerID.Hex())
+	u.cache.InvalidateUser(ctx, o.CounterpartyID.Hex())
+	return o, nil
 }
 
-func (u *exchangeUseCase) RemoveOfferedBook(ctx context.Context, offerID, bookID string) (*domain.ExchangeOffer, error) {
-	updated, err := u.repo.RemoveOfferedBook(ctx, offerID, bookID)
+// checkOwnership verifies that the owner holds every offered book and the
+// counterparty every requested one.
+func (u *exchangeUseCase) checkOwnership(ctx context.Context, offer *domain.ExchangeOffer) error {
+	var violations []OwnershipViolation
+	for _, side := range []struct {
+		user  primitive.ObjectID
+		books []primitive.ObjectID
+	}{
+		{offer.OwnerID, offer.OfferedBookIDs},
+		{offer.CounterpartyID, offer.RequestedBookIDs},
+	} {
+		v, err := u.notOwned(ctx, side.user, side.books)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, v...)
+	}
+	if len(violations) > 0 {
+		return &OwnershipError{Violations: violations}
+	}
+	return nil
+}
+
+// notOwned returns the books from the list that userID does not hold
+// according to UserLibraryService.
+func (u *exchangeUseCase) notOwned(ctx context.Context, userID primitive.ObjectID, books []primitive.ObjectID) ([]OwnershipViolation, error) {
+	if len(books) == 0 {
+		return nil, nil
+	}
+	resp, err := u.libClient.ListUserBooks(ctx, &userlibpb.ListUserBooksRequest{UserId: userID.Hex()})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("list books of %s: %w", userID.Hex(), err)
 	}
-	u.cache.InvalidatePending(ctx)
-	u.cache.InvalidateUser(ctx, updated.OwnerID.Hex())
-	return updated, nil
+	owned := make(map[string]bool, len(resp.Entries))
+	for _, e := range resp.Entries {
+		owned[e.BookId] = true
+	}
+	var out []OwnershipViolation
+	for _, b := range books {
+		if !owned[b.Hex()] {
+			out = append(out, OwnershipViolation{UserID: userID.Hex(), BookID: b.Hex()})
+		}
+	}
+	return out, nil
 }
 
-func (u *exchangeUseCase) ListAllOffers(ctx context.Context) ([]*domain.ExchangeOffer, error) {
-	return u.repo.ListAllOffers(ctx)
+type bookMove struct {
+	bookID, from, to string
 }
 
-func (u *exchangeUseCase) ListOffersByStatus(ctx context.Context, status string) ([]*domain.ExchangeOffer, error) {
-	return u.repo.ListOffersByStatus(ctx, status)
+// swapMoves moves the offered books to the counterparty and the requested
+// books to the owner.
+func swapMoves(offer *domain.ExchangeOffer) []bookMove {
+	owner, counterparty := offer.OwnerID.Hex(), offer.CounterpartyID.Hex()
+	var moves []bookMove
+	for _, id := range offer.OfferedBookIDs {
+		moves = append(moves, bookMove{bookID: id.Hex(), from: owner, to: counterparty})
+	}
+	for _, id := range offer.RequestedBookIDs {
+		moves = append(moves, bookMove{bookID: id.Hex(), from: counterparty, to: owner})
+	}
+	return moves
+}
+
+// reverseMoves gives every book of a swapped offer back.
+func reverseMoves(offer *domain.ExchangeOffer) []bookMove {
+	moves := swapMoves(offer)
+	for i := range moves {
+		moves[i].from, moves[i].to = moves[i].to, moves[i].from
+	}
+	return moves
+}
+
+func (u *exchangeUseCase) swapBooks(ctx context.Context, offer *domain.ExchangeOffer) error {
+	return u.moveBooks(ctx, offer.ID.Hex(), swapMoves(offer))
+}
+
+// moveBooks carries out moves on behalf of offerID. Every move is an unassign
+// followed by an assign; when a step fails, the steps already done are undone
+// in reverse order.
+func (u *exchangeUseCase) moveBooks(ctx context.Context, offerID string, moves []bookMove) error {
+	var undo []func(context.Context) error
+	for _, m := range moves {
+		if err := u.unassign(ctx, m.from, m.bookID, offerID); err != nil {
+			return u.compensate(ctx, undo, fmt.Errorf("unassign book %s from %s: %w", m.bookID, m.from, err))
+		}
+		undo = append(undo, func(ctx context.Context) error { return u.assign(ctx, m.from, m.bookID) })
+
+		if err := u.assign(ctx, m.to, m.bookID); err != nil {
+			return u.compensate(ctx, undo, fmt.Errorf("assign book %s to %s: %w", m.bookID, m.to, err))
+		}
+		undo = append(undo, func(ctx context.Context) error { return u.unassign(ctx, m.to, m.bookID, offerID) })
+	}
+	return nil
+}
+
+// compensate runs undo in reverse order, even if the request context has
+// already been cancelled, and returns cause together with any rollback errors.
+func (u *exchangeUseCase) compensate(ctx context.Context, undo []func(context.Context) error, cause error) error {
+	ctx = context.WithoutCancel(ctx)
+	errs := []error{cause}
+	for i := len(undo) - 1; i >= 0; i-- {
+		if err := undo[i](ctx); err != nil {
+			log.Printf("swap rollback step failed: %v", err)
+			errs = append(errs, fmt.Errorf("rollback: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (u *exchangeUseCase) assign(ctx context.Context, userID, bookID string) error {
+	_, err := u.libClient.AssignBook(ctx, &userlibpb.AssignBookRequest{UserId: userID, BookId: bookID})
+	return err
+}
+
+// unassign removes a book from userID's library on behalf of offerID, which
+// may move the books it has reserved.
+func (u *exchangeUseCase) unassign(ctx context.Context, userID, bookID, offerID string) error {
+	_, err := u.libClient.UnassignBook(ctx, &userlibpb.UnassignBookRequest{UserId: userID, BookId: bookID, OfferId: offerID})
+	return err
 }
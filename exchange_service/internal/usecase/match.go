@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultMatchLimit = 20
+	MaxMatchLimit     = 100
+)
+
+var ErrNoMatch = errors.New("users have nothing to trade with each other")
+
+// MatchUseCase keeps want lists and pairs users whose haves and wants
+// complement each other. Haves are the books in a user's library.
+// Only direct two-party trades are found; longer cycles are not searched.
+type MatchUseCase interface {
+	AddWant(ctx context.Context, userID, bookID string) ([]*domain.Want, error)
+	RemoveWant(ctx context.Context, userID, bookID string) ([]*domain.Want, error)
+	ListWants(ctx context.Context, userID string) ([]*domain.Want, error)
+	FindMatches(ctx context.Context, userID string, limit int) ([]*domain.Match, error)
+	MatchWith(ctx context.Context, userID, partnerID string) (*domain.Match, error)
+}
+
+type matchUseCase struct {
+	wants     repository.WantRepository
+	libClient userlibpb.UserLibraryServiceClient
+}
+
+func NewMatchUseCase(w repository.WantRepository, lc userlibpb.UserLibraryServiceClient) MatchUseCase {
+	return &matchUseCase{wants: w, libClient: lc}
+}
+
+func (u *matchUseCase) AddWant(ctx context.Context, userID, bookID string) ([]*domain.Want, error) {
+	uid, bid, err := parseIDs(userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.wants.AddWant(ctx, uid, bid); err != nil {
+		return nil, err
+	}
+	return u.wants.ListWants(ctx, uid)
+}
+
+func (u *matchUseCase) RemoveWant(ctx context.Context, userID, bookID string) ([]*domain.Want, error) {
+	uid, bid, err := parseIDs(userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.wants.RemoveWant(ctx, uid, bid); err != nil {
+		return nil, err
+	}
+	return u.wants.ListWants(ctx, uid)
+}
+
+func (u *matchUseCase) ListWants(ctx context.Context, userID string) ([]*domain.Want, error) {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.wants.ListWants(ctx, uid)
+}
+
+// FindMatches returns the partners of userID ranked by how many books would
+// change hands, preferring balanced trades on ties.
+func (u *matchUseCase) FindMatches(ctx context.Context, userID string, limit int) ([]*domain.Match, error) {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultMatchLimit
+	} else if limit > MaxMatchLimit {
+		limit = MaxMatchLimit
+	}
+
+	haves, err := u.owned(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	myWants, err := u.wants.ListWants(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Books the others want from me, grouped by who wants them.
+	theirWants, err := u.wants.ListWantsForBooks(ctx, haves, uid)
+	if err != nil {
+		return nil, err
+	}
+	give := map[primitive.ObjectID][]primitive.ObjectID{}
+	for _, w := range theirWants {
+		give[w.UserID] = append(give[w.UserID], w.BookID)
+	}
+
+	// Books I want, grouped by who holds them; only partners who want
+	// something from me are interesting.
+	get := map[primitive.ObjectID][]primitive.ObjectID{}
+	for _, w := range myWants {
+		resp, err := u.libClient.ListByBook(ctx, &userlibpb.ListByBookRequest{BookId: w.BookID.Hex()})
+		if err != nil {
+			return nil, fmt.Errorf("list owners of %s: %w", w.BookID.Hex(), err)
+		}
+		// An owner of several copies is listed once per copy.
+		seen := map[primitive.ObjectID]struct{}{}
+		for _, e := range resp.Entries {
+			owner, err := primitive.ObjectIDFromHex(e.UserId)
+			if err != nil || owner == uid {
+				continue
+			}
+			if _, ok := seen[owner]; ok {
+				continue
+			}
+			seen[owner] = struct{}{}
+			if _, ok := give[owner]; ok {
+				get[owner] = append(get[owner], w.BookID)
+			}
+		}
+	}
+
+	matches := make([]*domain.Match, 0, len(get))
+	for partner, books := range get {
+		matches = append(matches, &domain.Match{
+			UserID:    uid,
+			PartnerID: partner,
+			Give:      give[partner],
+			Get:       books,
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score() != b.Score() {
+			return a.Score() > b.Score()
+		}
+		if ma, mb := min(len(a.Give), len(a.Get)), min(len(b.Give), len(b.Get)); ma != mb {
+			return ma > mb
+		}
+		return a.PartnerID.Hex() < b.PartnerID.Hex()
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// MatchWith computes the trade between userID and partnerID, or ErrNoMatch
+// if either side has nothing the other wants.
+func (u *matchUseCase) MatchWith(ctx context.Context, userID, partnerID string) (*domain.Match, error) {
+	uid, pid, err := parseIDs(userID, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	give, err := u.wantedFrom(ctx, pid, uid)
+	if err != nil {
+		return nil, err
+	}
+	get, err := u.wantedFrom(ctx, uid, pid)
+	if err != nil {
+		return nil, err
+	}
+	if len(give) == 0 || len(get) == 0 {
+		return nil, ErrNoMatch
+	}
+	return &domain.Match{UserID: uid, PartnerID: pid, Give: give, Get: get}, nil
+}
+
+// wantedFrom returns the books wanter wants that holder owns.
+func (u *matchUseCase) wantedFrom(ctx context.Context, wanter, holder primitive.ObjectID) ([]primitive.ObjectID, error) {
+	wants, err := u.wants.ListWants(ctx, wanter)
+	if err != nil {
+		return nil, err
+	}
+	haves, err := u.owned(ctx, holder)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[primitive.ObjectID]bool, len(haves))
+	for _, b := range haves {
+		held[b] = true
+	}
+	var out []primitive.ObjectID
+	for _, w := range wants {
+		if held[w.BookID] {
+			out = append(out, w.BookID)
+		}
+	}
+	return out, nil
+}
+
+func (u *matchUseCase) owned(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	resp, err := u.libClient.ListUserBooks(ctx, &userlibpb.ListUserBooksRequest{UserId: userID.Hex()})
+	if err != nil {
+		return nil, fmt.Errorf("list books of %s: %w", userID.Hex(), err)
+	}
+	out := make([]primitive.ObjectID, 0, len(resp.Entries))
+	for _, e := range resp.Entries {
+		if id, err := primitive.ObjectIDFromHex(e.BookId); err == nil {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func parseIDs(a, b string) (primitive.ObjectID, primitive.ObjectID, error) {
+	x, err := primitive.ObjectIDFromHex(a)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, err
+	}
+	y, err := primitive.ObjectIDFromHex(b)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, err
+	}
+	return x, y, nil
+}
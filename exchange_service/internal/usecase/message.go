@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const MaxMessageLength = 2000
+
+var (
+	ErrMessageRequired = errors.New("message body is required")
+	ErrMessageTooLong  = errors.New("message is too long")
+)
+
+// MessageUseCase is the chat attached to every offer. Only the owner and the
+// counterparty may read or post, whatever the offer's status.
+type MessageUseCase interface {
+	SendMessage(ctx context.Context, offerID, callerID, body string) (*domain.OfferMessage, *domain.ExchangeOffer, error)
+	ListMessages(ctx context.Context, offerID, callerID string) ([]*domain.OfferMessage, error)
+}
+
+type messageUseCase struct {
+	messages repository.MessageRepository
+	offers   repository.ExchangeRepository
+}
+
+func NewMessageUseCase(m repository.MessageRepository, o repository.ExchangeRepository) MessageUseCase {
+	return &messageUseCase{messages: m, offers: o}
+}
+
+func (u *messageUseCase) SendMessage(ctx context.Context, offerID, callerID, body string) (*domain.OfferMessage, *domain.ExchangeOffer, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, nil, ErrMessageRequired
+	}
+	if len(body) > MaxMessageLength {
+		return nil, nil, ErrMessageTooLong
+	}
+	offer, err := u.participantOffer(ctx, offerID, callerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sender, err := primitive.ObjectIDFromHex(callerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &domain.OfferMessage{
+		OfferID:   offer.ID,
+		SenderID:  sender,
+		Body:      body,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+	if err := u.messages.AddMessage(ctx, m); err != nil {
+		return nil, nil, err
+	}
+	return m, offer, nil
+}
+
+func (u *messageUseCase) ListMessages(ctx context.Context, offerID, callerID string) ([]*domain.OfferMessage, error) {
+	offer, err := u.participantOffer(ctx, offerID, callerID)
+	if err != nil {
+		return nil, err
+	}
+	return u.messages.ListMessages(ctx, offer.ID)
+}
+
+func (u *messageUseCase) participantOffer(ctx context.Context, offerID, callerID string) (*domain.ExchangeOffer, error) {
+	offer, err := u.offers.GetOffer(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireParticipant(offer, callerID); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
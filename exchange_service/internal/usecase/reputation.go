@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	MinScore         = 1
+	MaxScore         = 5
+	MaxCommentLength = 1000
+)
+
+var (
+	ErrInvalidScore   = errors.New("score must be between 1 and 5")
+	ErrCommentTooLong = errors.New("comment is too long")
+	ErrNotRateable    = errors.New("only accepted or completed exchanges can be rated")
+	ErrAlreadyRated   = errors.New("exchange partner already rated for this offer")
+)
+
+// ReputationUseCase collects the ratings exchange partners give each other
+// and sums them up per user.
+type ReputationUseCase interface {
+	RatePartner(ctx context.Context, offerID, callerID string, score int, comment string) (*domain.Rating, error)
+	GetReputation(ctx context.Context, userID string) (*domain.Reputation, error)
+	Reputations(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]*domain.Reputation, error)
+}
+
+type reputationUseCase struct {
+	ratings  repository.RatingRepository
+	offers   repository.ExchangeRepository
+	disputes repository.DisputeRepository
+}
+
+func NewReputationUseCase(r repository.RatingRepository, o repository.ExchangeRepository, d repository.DisputeRepository) ReputationUseCase {
+	return &reputationUseCase{ratings: r, offers: o, disputes: d}
+}
+
+// RatePartner lets a party of an accepted or completed offer rate the other
+// party, once per offer.
+func (u *reputationUseCase) RatePartner(ctx context.Context, offerID, callerID string, score int, comment string) (*domain.Rating, error) {
+	if score < MinScore || score > MaxScore {
+		return nil, ErrInvalidScore
+	}
+	comment = strings.TrimSpace(comment)
+	if len(comment) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+	offer, err := u.offers.GetOffer(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireParticipant(offer, callerID); err != nil {
+		return nil, err
+	}
+	if offer.Status != domain.StatusAccepted && offer.Status != domain.StatusCompleted {
+		return nil, ErrNotRateable
+	}
+
+	rater, ratee := offer.OwnerID, offer.CounterpartyID
+	if callerID == offer.CounterpartyID.Hex() {
+		rater, ratee = ratee, rater
+	}
+	rating := &domain.Rating{
+		OfferID:   offer.ID,
+		RaterID:   rater,
+		RateeID:   ratee,
+		Score:     score,
+		Comment:   comment,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+	if err := u.ratings.AddRating(ctx, rating); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyRated
+		}
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (u *reputationUseCase) GetReputation(ctx context.Context, userID string) (*domain.Reputation, error) {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	reps, err := u.Reputations(ctx, []primitive.ObjectID{uid})
+	if err != nil {
+		return nil, err
+	}
+	return reps[uid], nil
+}
+
+// Reputations returns a reputation for every user in userIDs; users nobody
+// has rated yet get a zero average. Disputes count those opened against the user.
+func (u *reputationUseCase) Reputations(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]*domain.Reputation, error) {
+	summaries, err := u.ratings.Summaries(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[primitive.ObjectID]*domain.Reputation, len(userIDs))
+	for _, id := range userIDs {
+		if _, done := out[id]; done {
+			continue
+		}
+		rep := summaries[id]
+		if rep == nil {
+			rep = &domain.Reputation{UserID: id}
+		}
+		if rep.Trades, err = u.offers.CountTrades(ctx, id); err != nil {
+			return nil, err
+		}
+		if rep.Disputes, err = u.disputes.CountAgainst(ctx, id); err != nil {
+			return nil, err
+		}
+		out[id] = rep
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReservedBook is an offered book that another offer already holds.
+type ReservedBook struct {
+	BookID  string
+	OfferID string
+}
+
+// ReservedError is returned when offered books are reserved by other offers.
+type ReservedError struct {
+	Books []ReservedBook
+}
+
+func (e *ReservedError) Error() string {
+	parts := make([]string, len(e.Books))
+	for i, b := range e.Books {
+		parts[i] = fmt.Sprintf("%s (offer %s)", b.BookID, b.OfferID)
+	}
+	return "books already reserved: " + strings.Join(parts, ", ")
+}
+
+// reserve holds books in userID's library for offerID. Reservations left
+// behind by offers that are no longer pending are released and retried once.
+func (u *exchangeUseCase) reserve(ctx context.Context, offerID, userID primitive.ObjectID, books []primitive.ObjectID) error {
+	if len(books) == 0 {
+		return nil
+	}
+	req := &userlibpb.ReserveBooksRequest{UserId: userID.Hex(), BookIds: hexIDs(books), OfferId: offerID.Hex()}
+	resp, err := u.libClient.ReserveBooks(ctx, req)
+	if err != nil {
+		return fmt.Errorf("reserve books: %w", err)
+	}
+	if len(resp.Conflicts) == 0 {
+		return nil
+	}
+
+	var held []ReservedBook
+	stale := false
+	for _, c := range resp.Conflicts {
+		live, err := u.holdsBooks(ctx, c.OfferId)
+		if err != nil {
+			return err
+		}
+		if live {
+			held = append(held, ReservedBook{BookID: c.BookId, OfferID: c.OfferId})
+			continue
+		}
+		if _, err := u.libClient.ReleaseBooks(ctx, &userlibpb.ReleaseBooksRequest{OfferId: c.OfferId}); err != nil {
+			return fmt.Errorf("release stale reservation of offer %s: %w", c.OfferId, err)
+		}
+		stale = true
+	}
+	if len(held) > 0 {
+		return &ReservedError{Books: held}
+	}
+	if stale {
+		if resp, err = u.libClient.ReserveBooks(ctx, req); err != nil {
+			return fmt.Errorf("reserve books: %w", err)
+		}
+		for _, c := range resp.Conflicts {
+			held = append(held, ReservedBook{BookID: c.BookId, OfferID: c.OfferId})
+		}
+		if len(held) > 0 {
+			return &ReservedError{Books: held}
+		}
+	}
+	return nil
+}
+
+// holdsBooks reports whether the offer may still keep its books reserved:
+// it exists and is pending or in the middle of its swap.
+func (u *exchangeUseCase) holdsBooks(ctx context.Context, offerID string) (bool, error) {
+	o, err := u.repo.GetOffer(ctx, offerID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("load reserving offer %s: %w", offerID, err)
+	}
+	return o.Status == domain.StatusPending || o.Status == domain.StatusAccepted, nil
+}
+
+// release drops the offer's reservations for books, or all of them when no
+// books are given. The offer has already changed, so a failure is only
+// logged; the leftover reservation is cleared by the next reserve that hits it.
+func (u *exchangeUseCase) release(ctx context.Context, offerID primitive.ObjectID, books ...primitive.ObjectID) {
+	req := &userlibpb.ReleaseBooksRequest{OfferId: offerID.Hex(), BookIds: hexIDs(books)}
+	if _, err := u.libClient.ReleaseBooks(context.WithoutCancel(ctx), req); err != nil {
+		log.Printf("cannot release books of offer %s: %v", offerID.Hex(), err)
+	}
+}
+
+// without returns the ids from a that are not in b.
+func without(a, b []primitive.ObjectID) []primitive.ObjectID {
+	drop := make(map[primitive.ObjectID]bool, len(b))
+	for _, id := range b {
+		drop[id] = true
+	}
+	var out []primitive.ObjectID
+	for _, id := range a {
+		if !drop[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func hexIDs(ids []primitive.ObjectID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.Hex()
+	}
+	return out
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrNoBooks       = errors.New("an offer needs at least one offered and one requested book")
+	ErrEmptyMask     = errors.New("update mask names no fields")
+	ErrFieldReadOnly = errors.New("field cannot be edited")
+	ErrInvalidExpiry = errors.New("expires_at must be in the future")
+)
+
+// PatchOffer copies the listed fields from patch onto a pending offer. Unlike
+// UpdateOffer, a listed field is always overwritten, so a book list can be
+// replaced as a whole.
+func (u *exchangeUseCase) PatchOffer(ctx context.Context, patch *domain.ExchangeOffer, fields []string, callerID string) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	if len(fields) == 0 {
+		return nil, nil, ErrEmptyMask
+	}
+	for _, f := range fields {
+		switch f {
+		case domain.FieldCounterparty, domain.FieldOfferedBooks, domain.FieldRequestedBooks, domain.FieldExpiresAt:
+		case "status":
+			return nil, nil, ErrStatusReadOnly
+		default:
+			return nil, nil, fmt.Errorf("%w: %q", ErrFieldReadOnly, f)
+		}
+	}
+	return u.edit(ctx, patch.ID.Hex(), callerID, func(next *domain.ExchangeOffer) error {
+		for _, f := range fields {
+			switch f {
+			case domain.FieldCounterparty:
+				next.CounterpartyID = patch.CounterpartyID
+			case domain.FieldOfferedBooks:
+				next.OfferedBookIDs = patch.OfferedBookIDs
+			case domain.FieldRequestedBooks:
+				next.RequestedBookIDs = patch.RequestedBookIDs
+			case domain.FieldExpiresAt:
+				if !patch.ExpiresAt.Time().After(time.Now()) {
+					return ErrInvalidExpiry
+				}
+				next.ExpiresAt = patch.ExpiresAt
+			}
+		}
+		return nil
+	})
+}
+
+// editBooks runs a single-book edit of one of the offer's book lists.
+func (u *exchangeUseCase) editBooks(ctx context.Context, offerID, bookID, callerID string, change func(next *domain.ExchangeOffer, bid primitive.ObjectID)) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	bid, err := primitive.ObjectIDFromHex(bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u.edit(ctx, offerID, callerID, func(next *domain.ExchangeOffer) error {
+		change(next, bid)
+		return nil
+	})
+}
+
+// edit is the one path by which a pending offer's content changes. change
+// works on a copy of the stored offer; the result is checked, diffed against
+// the original and saved together with a revision naming the editor. Books
+// that enter the offer must be owned by the right side, and offered ones are
+// reserved. When change alters nothing, the offer is returned as it was and
+// no revision is recorded.
+func (u *exchangeUseCase) edit(ctx context.Context, offerID, callerID string, change func(next *domain.ExchangeOffer) error) (*domain.ExchangeOffer, *domain.OfferRevision, error) {
+	current, err := u.repo.GetOffer(ctx, offerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := requireOwner(current, callerID); err != nil {
+		return nil, nil, err
+	}
+	if err := checkEditable(current); err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if err := checkNotExpired(current, now); err != nil {
+		return nil, nil, err
+	}
+
+	next := *current
+	next.OfferedBookIDs = slices.Clone(current.OfferedBookIDs)
+	next.RequestedBookIDs = slices.Clone(current.RequestedBookIDs)
+	if err := change(&next); err != nil {
+		return nil, nil, err
+	}
+	next.OfferedBookIDs = uniqueIDs(next.OfferedBookIDs)
+	next.RequestedBookIDs = uniqueIDs(next.RequestedBookIDs)
+	if len(next.OfferedBookIDs) == 0 || len(next.RequestedBookIDs) == 0 {
+		return nil, nil, ErrNoBooks
+	}
+	changes := diffOffers(current, &next)
+	if len(changes) == 0 {
+		return current, nil, nil
+	}
+	editor, err := primitive.ObjectIDFromHex(callerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	added := without(next.OfferedBookIDs, current.OfferedBookIDs)
+	requested := without(next.RequestedBookIDs, current.RequestedBookIDs)
+	if next.CounterpartyID != current.CounterpartyID {
+		requested = next.RequestedBookIDs
+	}
+	if err := u.checkOwnership(ctx, &domain.ExchangeOffer{
+		OwnerID:          next.OwnerID,
+		CounterpartyID:   next.CounterpartyID,
+		OfferedBookIDs:   added,
+		RequestedBookIDs: requested,
+	}); err != nil {
+		return nil, nil, err
+	}
+	if len(added) > 0 {
+		if err := u.reserve(ctx, next.ID, next.OwnerID, added); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rev := domain.OfferRevision{
+		Number:   current.Revision + 1,
+		EditorID: editor,
+		EditedAt: primitive.NewDateTimeFromTime(now),
+		Changes:  changes,
+	}
+	updated, err := u.repo.UpdateOffer(ctx, &next, rev)
+	if err != nil {
+		if len(added) > 0 {
+			u.release(ctx, next.ID, added...)
+		}
+		return nil, nil, conditional(err)
+	}
+	if dropped := without(current.OfferedBookIDs, next.OfferedBookIDs); len(dropped) > 0 {
+		u.release(ctx, next.ID, dropped...)
+	}
+	u.cache.InvalidatePending(ctx)
+	u.cache.InvalidateUser(ctx, updated.OwnerID.Hex())
+	return updated, &rev, nil
+}
+
+// diffOffers lists the editable fields that differ between before and after.
+// Book lists are compared as sets.
+func diffOffers(before, after *domain.ExchangeOffer) []domain.FieldChange {
+	var changes []domain.FieldChange
+	if before.CounterpartyID != after.CounterpartyID {
+		changes = append(changes, domain.FieldChange{
+			Field: domain.FieldCounterparty,
+			From:  before.CounterpartyID.Hex(),
+			To:    after.CounterpartyID.Hex(),
+		})
+	}
+	for _, list := range []struct {
+		field         string
+		before, after []primitive.ObjectID
+	}{
+		{domain.FieldOfferedBooks, before.OfferedBookIDs, after.OfferedBookIDs},
+		{domain.FieldRequestedBooks, before.RequestedBookIDs, after.RequestedBookIDs},
+	} {
+		added, removed := without(list.after, list.before), without(list.before, list.after)
+		if len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, domain.FieldChange{Field: list.field, Added: added, Removed: removed})
+		}
+	}
+	if before.ExpiresAt != after.ExpiresAt {
+		changes = append(changes, domain.FieldChange{
+			Field: domain.FieldExpiresAt,
+			From:  formatTime(before.ExpiresAt),
+			To:    formatTime(after.ExpiresAt),
+		})
+	}
+	return changes
+}
+
+func uniqueIDs(ids []primitive.ObjectID) []primitive.ObjectID {
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	out := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func formatTime(t primitive.DateTime) string {
+	if t == 0 {
+		return ""
+	}
+	return t.Time().UTC().Format(time.RFC3339)
+}
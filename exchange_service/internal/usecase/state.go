@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// transitions lists, for every offer status, the statuses it may move to.
+// Statuses without an entry are final.
+var transitions = map[string][]string{
+	domain.StatusPending: {
+		domain.StatusAccepted,
+		domain.StatusDeclined,
+		domain.StatusCancelled,
+		domain.StatusExpired,
+		domain.StatusCountered,
+	},
+	domain.StatusAccepted: {
+		domain.StatusCompleted,
+		domain.StatusDisputed,
+	},
+	domain.StatusCompleted: {
+		domain.StatusDisputed,
+	},
+	// Only ResolveDispute moves a disputed offer on.
+	domain.StatusDisputed: {
+		domain.StatusCompleted,
+		domain.StatusReversed,
+	},
+}
+
+var (
+	ErrNotOwner        = errors.New("only the offer owner may do this")
+	ErrNotCounterparty = errors.New("only the offer counterparty may do this")
+	ErrNotParticipant  = errors.New("only the offer participants may do this")
+	ErrOfferChanged    = errors.New("offer was changed concurrently, retry")
+	ErrStatusReadOnly  = errors.New("status cannot be changed by editing the offer")
+	ErrNotEditable     = errors.New("only pending offers can be edited")
+	ErrOfferExpired    = errors.New("offer has expired")
+	ErrOfferDisputed   = errors.New("offer is frozen by an open dispute")
+	ErrNotDeletable    = errors.New("only pending, declined, cancelled or expired offers can be deleted")
+)
+
+// TransitionError is returned when an offer cannot move from its current status.
+type TransitionError struct {
+	From, To string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("offer cannot move from %s to %s", e.From, e.To)
+}
+
+func canTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+func checkTransition(from, to string) error {
+	if !canTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// checkEditable allows content changes only to PENDING offers.
+func checkEditable(offer *domain.ExchangeOffer) error {
+	if offer.Status != domain.StatusPending {
+		return fmt.Errorf("%w: offer is %s", ErrNotEditable, offer.Status)
+	}
+	return nil
+}
+
+// checkNotExpired rejects offers past their expires_at that the sweeper has
+// not reached yet.
+func checkNotExpired(offer *domain.ExchangeOffer, now time.Time) error {
+	if offer.ExpiresAt != 0 && !offer.ExpiresAt.Time().After(now) {
+		return ErrOfferExpired
+	}
+	return nil
+}
+
+// checkDeletable keeps offers that led to a swap, which disputes and
+// ratings refer back to.
+func checkDeletable(offer *domain.ExchangeOffer) error {
+	switch offer.Status {
+	case domain.StatusPending, domain.StatusDeclined, domain.StatusCancelled, domain.StatusExpired:
+		return nil
+	}
+	return fmt.Errorf("%w: offer is %s", ErrNotDeletable, offer.Status)
+}
+
+// checkNotDisputed keeps the parties from moving a disputed offer themselves.
+func checkNotDisputed(offer *domain.ExchangeOffer) error {
+	if offer.Status == domain.StatusDisputed {
+		return ErrOfferDisputed
+	}
+	return nil
+}
+
+func requireOwner(offer *domain.ExchangeOffer, callerID string) error {
+	if offer.OwnerID.Hex() != callerID {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+func requireCounterparty(offer *domain.ExchangeOffer, callerID string) error {
+	if offer.CounterpartyID.Hex() != callerID {
+		return ErrNotCounterparty
+	}
+	return nil
+}
+
+func requireParticipant(offer *domain.ExchangeOffer, callerID string) error {
+	if offer.OwnerID.Hex() != callerID && offer.CounterpartyID.Hex() != callerID {
+		return ErrNotParticipant
+	}
+	return nil
+}
+
+// conditional reports a status-guarded update that matched nothing as a
+// concurrent change: the offer existed a moment ago but left the expected status.
+func conditional(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrOfferChanged
+	}
+	return err
+}
@@ -3,17 +3,39 @@ package usecase
 import (
 	"context"
 	"errors"
+	"google.golang.org/grpc"
+	"slices"
+
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/exchange_service/internal/repository"
 	userlibpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"testing"
+	"time"
 )
 
 type fakeRepo struct {
 	repository.ExchangeRepository
 	acceptCalled, declineCalled, deleteCalled bool
+	reopenCalled                              bool
+	transitionErr                             error
+	id, owner, counterparty                   primitive.ObjectID
+	status                                    string
+	expiresAt                                 primitive.DateTime
+	offered, requested                        []primitive.ObjectID
+	overdue                                   []*domain.ExchangeOffer
+	revisions                                 []domain.OfferRevision
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		id:           primitive.NewObjectID(),
+		owner:        primitive.NewObjectID(),
+		counterparty: primitive.NewObjectID(),
+		status:       domain.StatusPending,
+	}
 }
 
 func (r *fakeRepo) CreateOffer(ctx context.Context, offer *domain.ExchangeOffer) (*domain.ExchangeOffer, error) {
@@ -23,7 +45,28 @@ func (r *fakeRepo) GetOffer(ctx context.Context, id string) (*domain.ExchangeOff
 	if id == "err" {
 		return nil, errors.New("not found")
 	}
-	return &domain.ExchangeOffer{ID: primitive.NewObjectID(), OwnerID: primitive.NewObjectID()}, nil
+	return &domain.ExchangeOffer{
+		ID:               r.id,
+		OwnerID:          r.owner,
+		CounterpartyID:   r.counterparty,
+		Status:           r.status,
+		ExpiresAt:        r.expiresAt,
+		OfferedBookIDs:   r.offered,
+		RequestedBookIDs: r.requested,
+		Revision:         len(r.revisions),
+		Revisions:        r.revisions,
+	}, nil
+}
+func (r *fakeRepo) UpdateOffer(ctx context.Context, offer *domain.ExchangeOffer, rev domain.OfferRevision) (*domain.ExchangeOffer, error) {
+	if offer.Revision != len(r.revisions) {
+		return nil, mongo.ErrNoDocuments
+	}
+	r.counterparty, r.offered, r.requested = offer.CounterpartyID, offer.OfferedBookIDs, offer.RequestedBookIDs
+	r.revisions = append(r.revisions, rev)
+	return r.GetOffer(ctx, offer.ID.Hex())
+}
+func (r *fakeRepo) ListOverdueOffers(ctx context.Context, now time.Time) ([]*domain.ExchangeOffer, error) {
+	return r.overdue, nil
 }
 func (r *fakeRepo) ListOffersByUser(ctx context.Context, ownerID string) ([]*domain.ExchangeOffer, error) {
 	return []*domain.ExchangeOffer{{ID: primitive.NewObjectID()}}, nil
@@ -33,17 +76,27 @@ func (r *fakeRepo) ListPendingOffers(ctx context.Context) ([]*domain.ExchangeOff
 }
 func (r *fakeRepo) AcceptOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error) {
 	r.acceptCalled = true
-	owner := primitive.NewObjectID()
 	return &domain.ExchangeOffer{
 		ID:               primitive.NewObjectID(),
-		OwnerID:          owner,
+		OwnerID:          r.owner,
+		CounterpartyID:   r.counterparty,
+		Status:           domain.StatusAccepted,
 		OfferedBookIDs:   []primitive.ObjectID{primitive.NewObjectID()},
 		RequestedBookIDs: []primitive.ObjectID{primitive.NewObjectID()},
 	}, nil
 }
+func (r *fakeRepo) TransitionStatus(ctx context.Context, id, from, to string) (*domain.ExchangeOffer, error) {
+	if r.transitionErr != nil {
+		return nil, r.transitionErr
+	}
+	if from == domain.StatusAccepted && to == domain.StatusPending {
+		r.reopenCalled = true
+	}
+	return &domain.ExchangeOffer{ID: primitive.NewObjectID(), OwnerID: r.owner, CounterpartyID: r.counterparty, Status: to}, nil
+}
 func (r *fakeRepo) DeclineOffer(ctx context.Context, id string) (*domain.ExchangeOffer, error) {
 	r.declineCalled = true
-	return &domain.ExchangeOffer{ID: primitive.NewObjectID(), OwnerID: primitive.NewObjectID()}, nil
+	return &domain.ExchangeOffer{ID: r.id, OwnerID: r.owner, Status: domain.StatusDeclined}, nil
 }
 func (r *fakeRepo) DeleteOffer(ctx context.Context, id string) error {
 	r.deleteCalled = true
@@ -78,25 +131,203 @@ type fakeLib struct {
 	userlibpb.UserLibraryServiceClient
 	unassignErr, assignErr     bool
 	unassignCalls, assignCalls int
+	failAssignAt               int
+	books                      map[string][]string // user -> owned book IDs
+	reserved                   map[string]string   // book -> offer holding it
+}
+
+func (f *fakeLib) ReserveBooks(ctx context.Context, req *userlibpb.ReserveBooksRequest, opts ...grpc.CallOption) (*userlibpb.ReserveBooksResponse, error) {
+	if f.reserved == nil {
+		f.reserved = map[string]string{}
+	}
+	resp := &userlibpb.ReserveBooksResponse{}
+	for _, b := range req.BookIds {
+		if holder, ok := f.reserved[b]; ok && holder != req.OfferId {
+			resp.Conflicts = append(resp.Conflicts, &userlibpb.Reservation{UserId: req.UserId, BookId: b, OfferId: holder})
+		}
+	}
+	if len(resp.Conflicts) == 0 {
+		for _, b := range req.BookIds {
+			f.reserved[b] = req.OfferId
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeLib) ReleaseBooks(ctx context.Context, req *userlibpb.ReleaseBooksRequest, opts ...grpc.CallOption) (*userlibpb.ReleaseBooksResponse, error) {
+	var n int64
+	for b, holder := range f.reserved {
+		if holder == req.OfferId && (len(req.BookIds) == 0 || slices.Contains(req.BookIds, b)) {
+			delete(f.reserved, b)
+			n++
+		}
+	}
+	return &userlibpb.ReleaseBooksResponse{Released: n}, nil
 }
 
-func (f *fakeLib) UnassignBook(ctx context.Context, req *userlibpb.UnassignBookRequest) (*userlibpb.UnassignBookResponse, error) {
+func (f *fakeLib) UnassignBook(ctx context.Context, req *userlibpb.UnassignBookRequest, opts ...grpc.CallOption) (*userlibpb.UnassignBookResponse, error) {
 	f.unassignCalls++
 	if f.unassignErr {
 		return nil, errors.New("unassign error")
 	}
 	return &userlibpb.UnassignBookResponse{Success: true}, nil
 }
-func (f *fakeLib) AssignBook(ctx context.Context, req *userlibpb.AssignBookRequest) (*userlibpb.AssignBookResponse, error) {
+func (f *fakeLib) AssignBook(ctx context.Context, req *userlibpb.AssignBookRequest, opts ...grpc.CallOption) (*userlibpb.AssignBookResponse, error) {
 	f.assignCalls++
-	if f.assignErr {
+	if f.assignErr || f.assignCalls == f.failAssignAt {
 		return nil, errors.New("assign error")
 	}
 	return &userlibpb.AssignBookResponse{Entry: &userlibpb.UserBook{}}, nil
 }
 
-func (f *fakeLib) ListUserBooks(ctx context.Context, req *userlibpb.ListUserBooksRequest) (*userlibpb.ListUserBooksResponse, error) {
-	return &userlibpb.ListUserBooksResponse{}, nil
+func (f *fakeLib) ListUserBooks(ctx context.Context, req *userlibpb.ListUserBooksRequest, opts ...grpc.CallOption) (*userlibpb.ListUserBooksResponse, error) {
+	resp := &userlibpb.ListUserBooksResponse{}
+	for _, b := range f.books[req.UserId] {
+		resp.Entries = append(resp.Entries, &userlibpb.UserBook{UserId: req.UserId, BookId: b})
+	}
+	return resp, nil
+}
+
+func (f *fakeLib) ListByBook(ctx context.Context, req *userlibpb.ListByBookRequest, opts ...grpc.CallOption) (*userlibpb.ListUserBooksResponse, error) {
+	resp := &userlibpb.ListUserBooksResponse{}
+	for user, books := range f.books {
+		for _, b := range books {
+			if b == req.BookId {
+				resp.Entries = append(resp.Entries, &userlibpb.UserBook{UserId: user, BookId: b})
+			}
+		}
+	}
+	return resp, nil
+}
+
+type fakeWants struct {
+	repository.WantRepository
+	wants []*domain.Want
+}
+
+func (w *fakeWants) ListWants(ctx context.Context, userID primitive.ObjectID) ([]*domain.Want, error) {
+	var out []*domain.Want
+	for _, x := range w.wants {
+		if x.UserID == userID {
+			out = append(out, x)
+		}
+	}
+	return out, nil
+}
+
+func (w *fakeWants) ListWantsForBooks(ctx context.Context, bookIDs []primitive.ObjectID, excludeUser primitive.ObjectID) ([]*domain.Want, error) {
+	var out []*domain.Want
+	for _, x := range w.wants {
+		for _, b := range bookIDs {
+			if x.BookID == b && x.UserID != excludeUser {
+				out = append(out, x)
+			}
+		}
+	}
+	return out, nil
+}
+
+type fakeRatings struct {
+	repository.RatingRepository
+	ratings []*domain.Rating
+}
+
+func (f *fakeRatings) AddRating(ctx context.Context, r *domain.Rating) error {
+	for _, x := range f.ratings {
+		if x.OfferID == r.OfferID && x.RaterID == r.RaterID {
+			return mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+		}
+	}
+	f.ratings = append(f.ratings, r)
+	return nil
+}
+
+func (f *fakeRatings) Summaries(ctx context.Context, userIDs []primitive.ObjectID) (map[primitive.ObjectID]*domain.Reputation, error) {
+	out := map[primitive.ObjectID]*domain.Reputation{}
+	for _, r := range f.ratings {
+		rep := out[r.RateeID]
+		if rep == nil {
+			rep = &domain.Reputation{UserID: r.RateeID}
+			out[r.RateeID] = rep
+		}
+		rep.Average = (rep.Average*float64(rep.Ratings) + float64(r.Score)) / float64(rep.Ratings+1)
+		rep.Ratings++
+	}
+	return out, nil
+}
+
+type fakeDisputes struct {
+	repository.DisputeRepository
+	byID map[primitive.ObjectID]*domain.Dispute
+}
+
+func (f *fakeDisputes) CreateDispute(ctx context.Context, d *domain.Dispute) error {
+	if f.byID == nil {
+		f.byID = map[primitive.ObjectID]*domain.Dispute{}
+	}
+	d.ID = primitive.NewObjectID()
+	f.byID[d.ID] = d
+	return nil
+}
+
+func (f *fakeDisputes) GetDispute(ctx context.Context, id string) (*domain.Dispute, error) {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	if d, ok := f.byID[oid]; ok {
+		return d, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (f *fakeDisputes) AddEvidence(ctx context.Context, id primitive.ObjectID, ev domain.Evidence) (*domain.Dispute, error) {
+	d, ok := f.byID[id]
+	if !ok || d.Status != domain.DisputeOpen {
+		return nil, mongo.ErrNoDocuments
+	}
+	d.Evidence = append(d.Evidence, ev)
+	return d, nil
+}
+
+func (f *fakeDisputes) Resolve(ctx context.Context, id primitive.ObjectID, resolution string, reversed bool, by primitive.ObjectID) (*domain.Dispute, error) {
+	d, ok := f.byID[id]
+	if !ok || d.Status != domain.DisputeOpen {
+		return nil, mongo.ErrNoDocuments
+	}
+	d.Status, d.Resolution, d.Reversed, d.ResolvedBy = domain.DisputeResolved, resolution, reversed, by
+	return d, nil
+}
+
+func (f *fakeDisputes) Reopen(ctx context.Context, id primitive.ObjectID) error {
+	d := f.byID[id]
+	d.Status, d.Resolution, d.Reversed, d.ResolvedBy = domain.DisputeOpen, "", false, primitive.NilObjectID
+	return nil
+}
+
+func (f *fakeDisputes) CountAgainst(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	var n int64
+	for _, d := range f.byID {
+		if d.AgainstID == userID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type fakeMessages struct {
+	thread []*domain.OfferMessage
+}
+
+func (f *fakeMessages) AddMessage(ctx context.Context, m *domain.OfferMessage) error {
+	m.ID = primitive.NewObjectID()
+	f.thread = append(f.thread, m)
+	return nil
+}
+
+func (f *fakeMessages) ListMessages(ctx context.Context, offerID primitive.ObjectID) ([]*domain.OfferMessage, error) {
+	return f.thread, nil
+}
+
+func (r *fakeRepo) CountTrades(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return 3, nil
 }
 
 func TestCreateOffer_InvalidatesCache(t *testing.T) {
@@ -120,6 +351,27 @@ func TestCreateOffer_InvalidatesCache(t *testing.T) {
 	}
 }
 
+func TestCreateOffer_RejectsUnownedBooks(t *testing.T) {
+	cache := &fakeCache{}
+	uc := NewExchangeUseCase(&fakeRepo{}, cache, &fakeLib{})
+
+	book := primitive.NewObjectID()
+	_, err := uc.CreateOffer(context.Background(), &domain.ExchangeOffer{
+		OwnerID:        primitive.NewObjectID(),
+		OfferedBookIDs: []primitive.ObjectID{book},
+	})
+	var oe *OwnershipError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected OwnershipError, got %v", err)
+	}
+	if len(oe.Violations) != 1 || oe.Violations[0].BookID != book.Hex() {
+		t.Errorf("unexpected violations %v", oe.Violations)
+	}
+	if cache.invalPending {
+		t.Error("cache must not be invalidated for a rejected offer")
+	}
+}
+
 func TestListMethods_UseCache(t *testing.T) {
 	uc := NewExchangeUseCase(&fakeRepo{}, &fakeCache{}, nil)
 
@@ -136,12 +388,12 @@ func TestListMethods_UseCache(t *testing.T) {
 }
 
 func TestAcceptOffer_FlowAndInvalidate(t *testing.T) {
-	repo := &fakeRepo{}
+	repo := newFakeRepo()
 	cache := &fakeCache{}
 	lib := &fakeLib{}
 	uc := NewExchangeUseCase(repo, cache, lib)
 
-	_, err := uc.AcceptOffer(context.Background(), "id", "req")
+	_, err := uc.AcceptOffer(context.Background(), "id", repo.counterparty.Hex())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -156,26 +408,422 @@ func TestAcceptOffer_FlowAndInvalidate(t *testing.T) {
 	}
 }
 
+func TestAcceptOffer_RollsBackFailedSwap(t *testing.T) {
+	repo := newFakeRepo()
+	cache := &fakeCache{}
+	lib := &fakeLib{failAssignAt: 2}
+	uc := NewExchangeUseCase(repo, cache, lib)
+
+	_, err := uc.AcceptOffer(context.Background(), "id", repo.counterparty.Hex())
+	if !errors.Is(err, ErrSwapFailed) {
+		t.Fatalf("expected ErrSwapFailed, got %v", err)
+	}
+	if !repo.reopenCalled {
+		t.Error("expected offer to be reopened")
+	}
+	// 2 unassign + 2 assign forward, then 1 unassign + 2 assign to undo.
+	if lib.unassignCalls != 3 || lib.assignCalls != 4 {
+		t.Errorf("expected 3 unassign & 4 assign, got %d/%d", lib.unassignCalls, lib.assignCalls)
+	}
+	if cache.invalPending || len(cache.invalUsers) != 0 {
+		t.Errorf("cache must not be invalidated on failure, got %v/%v", cache.invalPending, cache.invalUsers)
+	}
+}
+
 func TestDeclineAndDelete_Invalidation(t *testing.T) {
-	repo := &fakeRepo{}
+	repo := newFakeRepo()
 	cache := &fakeCache{}
-	uc := NewExchangeUseCase(repo, cache, nil)
+	uc := NewExchangeUseCase(repo, cache, &fakeLib{})
 
-	uc.DeclineOffer(context.Background(), "id")
+	uc.DeclineOffer(context.Background(), "id", repo.counterparty.Hex())
 	if !repo.declineCalled || !cache.invalPending || len(cache.invalUsers) != 1 {
 		t.Error("DeclineOffer failed cache/repo calls")
 	}
 
-	repo = &fakeRepo{}
+	repo = newFakeRepo()
 	cache = &fakeCache{}
-	uc = NewExchangeUseCase(repo, cache, nil)
+	uc = NewExchangeUseCase(repo, cache, &fakeLib{})
 
-	uc.DeleteOffer(context.Background(), "id")
+	uc.DeleteOffer(context.Background(), "id", repo.owner.Hex())
 	if !repo.deleteCalled || !cache.invalPending || len(cache.invalUsers) != 1 {
 		t.Error("DeleteOffer failed cache/repo calls")
 	}
 }
 
+func TestDeleteOffer_KeepsSwappedOffers(t *testing.T) {
+	for _, st := range []string{domain.StatusAccepted, domain.StatusCompleted, domain.StatusDisputed, domain.StatusReversed, domain.StatusCountered} {
+		repo := newFakeRepo()
+		repo.status = st
+		uc := NewExchangeUseCase(repo, &fakeCache{}, &fakeLib{})
+
+		if err := uc.DeleteOffer(context.Background(), "id", repo.owner.Hex()); !errors.Is(err, ErrNotDeletable) {
+			t.Errorf("deleting a %s offer: expected ErrNotDeletable, got %v", st, err)
+		}
+		if repo.deleteCalled {
+			t.Errorf("%s offer must not be deleted", st)
+		}
+	}
+}
+
+func TestStateMachine_RolesAndTransitions(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newFakeRepo()
+	uc := NewExchangeUseCase(repo, &fakeCache{}, &fakeLib{})
+	if _, err := uc.AcceptOffer(ctx, "id", repo.owner.Hex()); !errors.Is(err, ErrNotCounterparty) {
+		t.Errorf("owner accepting: expected ErrNotCounterparty, got %v", err)
+	}
+	if _, err := uc.DeclineOffer(ctx, "id", repo.owner.Hex()); !errors.Is(err, ErrNotCounterparty) {
+		t.Errorf("owner declining: expected ErrNotCounterparty, got %v", err)
+	}
+	if _, err := uc.CancelOffer(ctx, "id", repo.counterparty.Hex()); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("counterparty cancelling: expected ErrNotOwner, got %v", err)
+	}
+	if _, _, err := uc.UpdateOffer(ctx, &domain.ExchangeOffer{Status: domain.StatusAccepted}, repo.owner.Hex()); !errors.Is(err, ErrStatusReadOnly) {
+		t.Errorf("editing status: expected ErrStatusReadOnly, got %v", err)
+	}
+	if repo.acceptCalled || repo.declineCalled {
+		t.Error("repository must not be touched by rejected calls")
+	}
+
+	repo = newFakeRepo()
+	repo.status = domain.StatusAccepted
+	uc = NewExchangeUseCase(repo, &fakeCache{}, &fakeLib{})
+	var te *TransitionError
+	if _, err := uc.DeclineOffer(ctx, "id", repo.counterparty.Hex()); !errors.As(err, &te) {
+		t.Errorf("declining accepted offer: expected TransitionError, got %v", err)
+	}
+	if _, _, err := uc.AddOfferedBook(ctx, "id", primitive.NewObjectID().Hex(), repo.owner.Hex()); !errors.Is(err, ErrNotEditable) {
+		t.Errorf("editing accepted offer: expected ErrNotEditable, got %v", err)
+	}
+	o, err := uc.CompleteOffer(ctx, "id", repo.counterparty.Hex())
+	if err != nil || o.Status != domain.StatusCompleted {
+		t.Errorf("completing accepted offer: got %v, %v", o, err)
+	}
+}
+
+func TestCounterOffer_SwapsRolesAndMarksParent(t *testing.T) {
+	repo := newFakeRepo()
+	uc := NewExchangeUseCase(repo, &fakeCache{}, &fakeLib{})
+
+	if _, _, err := uc.CounterOffer(context.Background(), "id", &domain.ExchangeOffer{}, repo.owner.Hex()); !errors.Is(err, ErrNotCounterparty) {
+		t.Errorf("owner countering: expected ErrNotCounterparty, got %v", err)
+	}
+
+	parent, created, err := uc.CounterOffer(context.Background(), "id", &domain.ExchangeOffer{}, repo.counterparty.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent.Status != domain.StatusCountered {
+		t.Errorf("expected parent COUNTERED, got %s", parent.Status)
+	}
+	if created.OwnerID != repo.counterparty || created.CounterpartyID != repo.owner {
+		t.Errorf("roles not swapped: owner %v, counterparty %v", created.OwnerID, created.CounterpartyID)
+	}
+	if created.ParentID.IsZero() {
+		t.Error("counter-offer not linked to its parent")
+	}
+}
+
+func TestExpireOverdue_ExpiresAndInvalidates(t *testing.T) {
+	repo := newFakeRepo()
+	repo.overdue = []*domain.ExchangeOffer{{ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}}
+	cache := &fakeCache{}
+	uc := NewExchangeUseCase(repo, cache, &fakeLib{})
+
+	expired, err := uc.ExpireOverdue(context.Background(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 2 || expired[0].Status != domain.StatusExpired {
+		t.Errorf("expected 2 EXPIRED offers, got %v", expired)
+	}
+	if !cache.invalPending || len(cache.invalUsers) != 4 {
+		t.Errorf("expected pending+4 users invalidated, got %v/%v", cache.invalPending, cache.invalUsers)
+	}
+}
+
+func TestAcceptOffer_RejectsOverdue(t *testing.T) {
+	repo := newFakeRepo()
+	repo.expiresAt = primitive.NewDateTimeFromTime(time.Now().Add(-time.Minute))
+	uc := NewExchangeUseCase(repo, &fakeCache{}, &fakeLib{})
+
+	if _, err := uc.AcceptOffer(context.Background(), "id", repo.counterparty.Hex()); !errors.Is(err, ErrOfferExpired) {
+		t.Errorf("expected ErrOfferExpired, got %v", err)
+	}
+	if repo.acceptCalled {
+		t.Error("overdue offer must not be accepted")
+	}
+}
+
+func TestReservations_HeldUntilDecline(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	book := primitive.NewObjectID()
+	lib := &fakeLib{books: map[string][]string{repo.owner.Hex(): {book.Hex()}}}
+	uc := NewExchangeUseCase(repo, &fakeCache{}, lib)
+
+	first, err := uc.CreateOffer(ctx, &domain.ExchangeOffer{ID: repo.id, OwnerID: repo.owner, OfferedBookIDs: []primitive.ObjectID{book}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lib.reserved[book.Hex()] != first.ID.Hex() {
+		t.Fatalf("book not reserved by the new offer: %v", lib.reserved)
+	}
+
+	_, err = uc.CreateOffer(ctx, &domain.ExchangeOffer{OwnerID: repo.owner, OfferedBookIDs: []primitive.ObjectID{book}})
+	var re *ReservedError
+	if !errors.As(err, &re) {
+		t.Fatalf("expected ReservedError, got %v", err)
+	}
+	if len(re.Books) != 1 || re.Books[0].OfferID != first.ID.Hex() {
+		t.Errorf("unexpected reserved books %v", re.Books)
+	}
+
+	if _, err := uc.DeclineOffer(ctx, first.ID.Hex(), repo.counterparty.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lib.reserved[book.Hex()]; ok {
+		t.Error("declined offer still holds its book")
+	}
+	if _, err := uc.CreateOffer(ctx, &domain.ExchangeOffer{OwnerID: repo.owner, OfferedBookIDs: []primitive.ObjectID{book}}); err != nil {
+		t.Errorf("book should be free after decline, got %v", err)
+	}
+}
+
+func TestFindMatches_MutualWantsOnly(t *testing.T) {
+	me, partner, holder := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	mine, theirs := primitive.NewObjectID(), primitive.NewObjectID()
+	lib := &fakeLib{books: map[string][]string{
+		me.Hex():      {mine.Hex()},
+		partner.Hex(): {theirs.Hex()},
+		holder.Hex():  {theirs.Hex()}, // has what I want but wants nothing of mine
+	}}
+	wants := &fakeWants{wants: []*domain.Want{
+		{UserID: me, BookID: theirs},
+		{UserID: partner, BookID: mine},
+	}}
+	uc := NewMatchUseCase(wants, lib)
+
+	matches, err := uc.FindMatches(context.Background(), me.Hex(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].PartnerID != partner {
+		t.Fatalf("expected a single match with partner, got %v", matches)
+	}
+	if m := matches[0]; m.Score() != 2 || m.Give[0] != mine || m.Get[0] != theirs {
+		t.Errorf("unexpected match %+v", m)
+	}
+
+	if _, err := uc.MatchWith(context.Background(), me.Hex(), holder.Hex()); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch with one-sided partner, got %v", err)
+	}
+}
+
+func TestFindMatches_CountsEachWantedBookOnce(t *testing.T) {
+	me, partner := primitive.NewObjectID(), primitive.NewObjectID()
+	mine, theirs := primitive.NewObjectID(), primitive.NewObjectID()
+	lib := &fakeLib{books: map[string][]string{
+		me.Hex():      {mine.Hex()},
+		partner.Hex(): {theirs.Hex(), theirs.Hex()}, // two copies
+	}}
+	wants := &fakeWants{wants: []*domain.Want{
+		{UserID: me, BookID: theirs},
+		{UserID: partner, BookID: mine},
+	}}
+
+	matches, err := NewMatchUseCase(wants, lib).FindMatches(context.Background(), me.Hex(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || len(matches[0].Get) != 1 || matches[0].Score() != 2 {
+		t.Fatalf("expected one match getting the book once, got %+v", matches)
+	}
+}
+
+func TestRatePartner_OncePerOfferAfterAccept(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	ratings := &fakeRatings{}
+	uc := NewReputationUseCase(ratings, repo, &fakeDisputes{})
+
+	if _, err := uc.RatePartner(ctx, "id", repo.counterparty.Hex(), 4, ""); !errors.Is(err, ErrNotRateable) {
+		t.Errorf("rating a pending offer: expected ErrNotRateable, got %v", err)
+	}
+	repo.status = domain.StatusAccepted
+	if _, err := uc.RatePartner(ctx, "id", repo.counterparty.Hex(), 6, ""); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("score 6: expected ErrInvalidScore, got %v", err)
+	}
+	if _, err := uc.RatePartner(ctx, "id", primitive.NewObjectID().Hex(), 4, ""); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("outsider rating: expected ErrNotParticipant, got %v", err)
+	}
+
+	r, err := uc.RatePartner(ctx, "id", repo.counterparty.Hex(), 4, " quick swap ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RaterID != repo.counterparty || r.RateeID != repo.owner || r.Comment != "quick swap" {
+		t.Errorf("unexpected rating %+v", r)
+	}
+	if _, err := uc.RatePartner(ctx, "id", repo.counterparty.Hex(), 5, ""); !errors.Is(err, ErrAlreadyRated) {
+		t.Errorf("second rating: expected ErrAlreadyRated, got %v", err)
+	}
+
+	rep, err := uc.GetReputation(ctx, repo.owner.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Average != 4 || rep.Ratings != 1 || rep.Trades != 3 {
+		t.Errorf("unexpected reputation %+v", rep)
+	}
+}
+
+func TestDispute_FreezesOfferAndReversesSwap(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	repo.status = domain.StatusAccepted
+	repo.offered = []primitive.ObjectID{primitive.NewObjectID()}
+	repo.requested = []primitive.ObjectID{primitive.NewObjectID()}
+	lib := &fakeLib{}
+	disputes := &fakeDisputes{}
+	uc := NewDisputeUseCase(disputes, repo, &fakeCache{}, lib)
+	admin := primitive.NewObjectID().Hex()
+
+	if _, _, err := uc.OpenDispute(ctx, "id", repo.counterparty.Hex(), "  "); !errors.Is(err, ErrNoteRequired) {
+		t.Errorf("empty reason: expected ErrNoteRequired, got %v", err)
+	}
+	d, offer, err := uc.OpenDispute(ctx, "id", repo.counterparty.Hex(), "book never arrived")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.Status != domain.StatusDisputed || d.AgainstID != repo.owner {
+		t.Errorf("unexpected dispute %+v on offer %s", d, offer.Status)
+	}
+
+	repo.status = domain.StatusDisputed
+	ex := NewExchangeUseCase(repo, &fakeCache{}, lib)
+	if _, err := ex.CompleteOffer(ctx, "id", repo.owner.Hex()); !errors.Is(err, ErrOfferDisputed) {
+		t.Errorf("completing disputed offer: expected ErrOfferDisputed, got %v", err)
+	}
+	if _, _, err := uc.AddEvidence(ctx, d.ID.Hex(), primitive.NewObjectID().Hex(), "photo", false); !errors.Is(err, ErrNotDisputeParty) {
+		t.Errorf("outsider evidence: expected ErrNotDisputeParty, got %v", err)
+	}
+
+	lib.unassignErr = true
+	if _, _, err := uc.ResolveDispute(ctx, d.ID.Hex(), admin, "refund", true); !errors.Is(err, ErrReversalFailed) {
+		t.Fatalf("expected ErrReversalFailed, got %v", err)
+	}
+	if d.Status != domain.DisputeOpen {
+		t.Errorf("dispute must stay open after a failed reversal, got %s", d.Status)
+	}
+
+	lib.unassignErr = false
+	lib.unassignCalls = 0
+	repo.transitionErr = mongo.ErrNoDocuments
+	if _, _, err := uc.ResolveDispute(ctx, d.ID.Hex(), admin, "refund", true); !errors.Is(err, ErrOfferChanged) {
+		t.Fatalf("expected ErrOfferChanged, got %v", err)
+	}
+	if d.Status != domain.DisputeOpen {
+		t.Errorf("dispute must stay open after a failed transition, got %s", d.Status)
+	}
+	if lib.unassignCalls != 4 || lib.assignCalls != 4 {
+		t.Errorf("expected the reversal to be undone, got %d unassign & %d assign", lib.unassignCalls, lib.assignCalls)
+	}
+
+	repo.transitionErr = nil
+	lib.unassignCalls, lib.assignCalls = 0, 0
+	d, offer, err = uc.ResolveDispute(ctx, d.ID.Hex(), admin, "refund", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.Status != domain.StatusReversed || !d.Reversed || d.Status != domain.DisputeResolved {
+		t.Errorf("unexpected resolution %+v on offer %s", d, offer.Status)
+	}
+	if lib.unassignCalls != 2 || lib.assignCalls != 2 {
+		t.Errorf("expected 2 unassign & 2 assign, got %d/%d", lib.unassignCalls, lib.assignCalls)
+	}
+}
+
+func TestOfferMessages_PartiesOnly(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	uc := NewMessageUseCase(&fakeMessages{}, repo)
+
+	if _, _, err := uc.SendMessage(ctx, "id", repo.owner.Hex(), " \n "); !errors.Is(err, ErrMessageRequired) {
+		t.Errorf("blank message: expected ErrMessageRequired, got %v", err)
+	}
+	outsider := primitive.NewObjectID().Hex()
+	if _, _, err := uc.SendMessage(ctx, "id", outsider, "hi"); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("outsider post: expected ErrNotParticipant, got %v", err)
+	}
+	if _, err := uc.ListMessages(ctx, "id", outsider); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("outsider read: expected ErrNotParticipant, got %v", err)
+	}
+
+	m, _, err := uc.SendMessage(ctx, "id", repo.counterparty.Hex(), " meet at the library? ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Body != "meet at the library?" || m.SenderID != repo.counterparty {
+		t.Errorf("unexpected message %+v", m)
+	}
+	thread, err := uc.ListMessages(ctx, "id", repo.owner.Hex())
+	if err != nil || len(thread) != 1 {
+		t.Errorf("owner should see 1 message, got %d (%v)", len(thread), err)
+	}
+}
+
+func TestEditOffer_RecordsRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	have, want, other := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	repo.offered = []primitive.ObjectID{have}
+	repo.requested = []primitive.ObjectID{want}
+	lib := &fakeLib{books: map[string][]string{
+		repo.owner.Hex():        {have.Hex()},
+		repo.counterparty.Hex(): {want.Hex(), other.Hex()},
+	}}
+	uc := NewExchangeUseCase(repo, &fakeCache{}, lib)
+	owner := repo.owner.Hex()
+
+	if _, _, err := uc.AddRequestedBook(ctx, "id", other.Hex(), repo.counterparty.Hex()); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("counterparty editing: expected ErrNotOwner, got %v", err)
+	}
+	var oe *OwnershipError
+	if _, _, err := uc.AddRequestedBook(ctx, "id", primitive.NewObjectID().Hex(), owner); !errors.As(err, &oe) {
+		t.Errorf("requesting a book the counterparty lacks: expected OwnershipError, got %v", err)
+	}
+
+	offer, rev, err := uc.AddRequestedBook(ctx, "id", other.Hex(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev == nil || rev.Number != 1 || rev.EditorID != repo.owner || len(offer.RequestedBookIDs) != 2 {
+		t.Fatalf("unexpected revision %+v for offer %+v", rev, offer)
+	}
+	if c := rev.Changes; len(c) != 1 || c[0].Field != domain.FieldRequestedBooks || len(c[0].Added) != 1 || c[0].Added[0] != other {
+		t.Errorf("unexpected diff %+v", c)
+	}
+	if _, rev, err := uc.AddRequestedBook(ctx, "id", other.Hex(), owner); err != nil || rev != nil {
+		t.Errorf("adding a book twice must not record a revision, got %+v (%v)", rev, err)
+	}
+
+	if _, _, err := uc.PatchOffer(ctx, &domain.ExchangeOffer{ID: repo.id}, []string{"status"}, owner); !errors.Is(err, ErrStatusReadOnly) {
+		t.Errorf("patching status: expected ErrStatusReadOnly, got %v", err)
+	}
+	if _, _, err := uc.PatchOffer(ctx, &domain.ExchangeOffer{ID: repo.id}, []string{domain.FieldOfferedBooks}, owner); !errors.Is(err, ErrNoBooks) {
+		t.Errorf("patching offered books to nothing: expected ErrNoBooks, got %v", err)
+	}
+	offer, rev, err = uc.PatchOffer(ctx, &domain.ExchangeOffer{ID: repo.id, RequestedBookIDs: []primitive.ObjectID{want}}, []string{domain.FieldRequestedBooks}, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev.Number != 2 || len(rev.Changes[0].Removed) != 1 || len(offer.Revisions) != 2 {
+		t.Errorf("unexpected second revision %+v, offer has %d revisions", rev, len(offer.Revisions))
+	}
+}
+
 func TestGetOffer_Error(t *testing.T) {
 	repo := &fakeRepo{}
 	cache := &fakeCache{}
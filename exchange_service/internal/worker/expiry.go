@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/exchange_service/internal/usecase"
+)
+
+// ExpirySweeper periodically expires overdue pending offers and passes each
+// one to onExpired.
+type ExpirySweeper struct {
+	uc        usecase.ExchangeUseCase
+	onExpired func(*domain.ExchangeOffer)
+	interval  time.Duration
+}
+
+func NewExpirySweeper(uc usecase.ExchangeUseCase, onExpired func(*domain.ExchangeOffer), interval time.Duration) *ExpirySweeper {
+	return &ExpirySweeper{uc: uc, onExpired: onExpired, interval: interval}
+}
+
+// Run sweeps once right away and then every interval until ctx is done.
+func (s *ExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ExpirySweeper) sweep(ctx context.Context) {
+	expired, err := s.uc.ExpireOverdue(ctx, time.Now())
+	if err != nil {
+		log.Printf("⚠ offer expiry sweep: %v", err)
+	}
+	for _, o := range expired {
+		s.onExpired(o)
+	}
+	if len(expired) > 0 {
+		log.Printf("expired %d offers", len(expired))
+	}
+}
@@ -9,6 +9,7 @@ package exchangepb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -31,8 +32,14 @@ type ExchangeOffer struct {
 	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
 	CreatedAt        string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	ParentId         string                 `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ExpiresAt        string                 `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Only set by ListPendingOffers when include_reputation is true.
+	OwnerReputation *Reputation      `protobuf:"bytes,11,opt,name=owner_reputation,json=ownerReputation,proto3" json:"owner_reputation,omitempty"`
+	Revision        int32            `protobuf:"varint,12,opt,name=revision,proto3" json:"revision,omitempty"`
+	Revisions       []*OfferRevision `protobuf:"bytes,13,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ExchangeOffer) Reset() {
@@ -121,30 +128,66 @@ func (x *ExchangeOffer) GetUpdatedAt() string {
 	return ""
 }
 
-type CreateOfferRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	OwnerId          string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	CounterpartyId   string                 `protobuf:"bytes,2,opt,name=counterparty_id,json=counterpartyId,proto3" json:"counterparty_id,omitempty"`
-	OfferedBookIds   []string               `protobuf:"bytes,3,rep,name=offered_book_ids,json=offeredBookIds,proto3" json:"offered_book_ids,omitempty"`
-	RequestedBookIds []string               `protobuf:"bytes,4,rep,name=requested_book_ids,json=requestedBookIds,proto3" json:"requested_book_ids,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *ExchangeOffer) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
 }
 
-func (x *CreateOfferRequest) Reset() {
-	*x = CreateOfferRequest{}
+func (x *ExchangeOffer) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ExchangeOffer) GetOwnerReputation() *Reputation {
+	if x != nil {
+		return x.OwnerReputation
+	}
+	return nil
+}
+
+func (x *ExchangeOffer) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ExchangeOffer) GetRevisions() []*OfferRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// OfferRevision is one recorded edit of a pending offer.
+type OfferRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	EditorId      string                 `protobuf:"bytes,2,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`
+	EditedAt      string                 `protobuf:"bytes,3,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferRevision) Reset() {
+	*x = OfferRevision{}
 	mi := &file_exchange_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOfferRequest) String() string {
+func (x *OfferRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOfferRequest) ProtoMessage() {}
+func (*OfferRevision) ProtoMessage() {}
 
-func (x *CreateOfferRequest) ProtoReflect() protoreflect.Message {
+func (x *OfferRevision) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -156,61 +199,66 @@ func (x *CreateOfferRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOfferRequest.ProtoReflect.Descriptor instead.
-func (*CreateOfferRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OfferRevision.ProtoReflect.Descriptor instead.
+func (*OfferRevision) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateOfferRequest) GetOwnerId() string {
+func (x *OfferRevision) GetNumber() int32 {
 	if x != nil {
-		return x.OwnerId
+		return x.Number
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateOfferRequest) GetCounterpartyId() string {
+func (x *OfferRevision) GetEditorId() string {
 	if x != nil {
-		return x.CounterpartyId
+		return x.EditorId
 	}
 	return ""
 }
 
-func (x *CreateOfferRequest) GetOfferedBookIds() []string {
+func (x *OfferRevision) GetEditedAt() string {
 	if x != nil {
-		return x.OfferedBookIds
+		return x.EditedAt
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateOfferRequest) GetRequestedBookIds() []string {
+func (x *OfferRevision) GetChanges() []*FieldChange {
 	if x != nil {
-		return x.RequestedBookIds
+		return x.Changes
 	}
 	return nil
 }
 
-type AcceptOfferRequest struct {
+// FieldChange is the diff of one field: added/removed IDs for book lists,
+// from/to for the other fields.
+type FieldChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
-	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Added         []string               `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []string               `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AcceptOfferRequest) Reset() {
-	*x = AcceptOfferRequest{}
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
 	mi := &file_exchange_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AcceptOfferRequest) String() string {
+func (x *FieldChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AcceptOfferRequest) ProtoMessage() {}
+func (*FieldChange) ProtoMessage() {}
 
-func (x *AcceptOfferRequest) ProtoReflect() protoreflect.Message {
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -222,46 +270,72 @@ func (x *AcceptOfferRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AcceptOfferRequest.ProtoReflect.Descriptor instead.
-func (*AcceptOfferRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *AcceptOfferRequest) GetOfferId() string {
+func (x *FieldChange) GetField() string {
 	if x != nil {
-		return x.OfferId
+		return x.Field
 	}
 	return ""
 }
 
-func (x *AcceptOfferRequest) GetRequesterId() string {
+func (x *FieldChange) GetAdded() []string {
 	if x != nil {
-		return x.RequesterId
+		return x.Added
+	}
+	return nil
+}
+
+func (x *FieldChange) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *FieldChange) GetFrom() string {
+	if x != nil {
+		return x.From
 	}
 	return ""
 }
 
-type UpdateOfferRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Offer         *ExchangeOffer         `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+func (x *FieldChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type CreateOfferRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId          string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	CounterpartyId   string                 `protobuf:"bytes,2,opt,name=counterparty_id,json=counterpartyId,proto3" json:"counterparty_id,omitempty"`
+	OfferedBookIds   []string               `protobuf:"bytes,3,rep,name=offered_book_ids,json=offeredBookIds,proto3" json:"offered_book_ids,omitempty"`
+	RequestedBookIds []string               `protobuf:"bytes,4,rep,name=requested_book_ids,json=requestedBookIds,proto3" json:"requested_book_ids,omitempty"`
+	// RFC 3339; the service default TTL applies when empty.
+	ExpiresAt     string `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOfferRequest) Reset() {
-	*x = UpdateOfferRequest{}
+func (x *CreateOfferRequest) Reset() {
+	*x = CreateOfferRequest{}
 	mi := &file_exchange_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOfferRequest) String() string {
+func (x *CreateOfferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOfferRequest) ProtoMessage() {}
+func (*CreateOfferRequest) ProtoMessage() {}
 
-func (x *UpdateOfferRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateOfferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -273,40 +347,68 @@ func (x *UpdateOfferRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOfferRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOfferRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateOfferRequest.ProtoReflect.Descriptor instead.
+func (*CreateOfferRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdateOfferRequest) GetOffer() *ExchangeOffer {
+func (x *CreateOfferRequest) GetOwnerId() string {
 	if x != nil {
-		return x.Offer
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreateOfferRequest) GetCounterpartyId() string {
+	if x != nil {
+		return x.CounterpartyId
+	}
+	return ""
+}
+
+func (x *CreateOfferRequest) GetOfferedBookIds() []string {
+	if x != nil {
+		return x.OfferedBookIds
 	}
 	return nil
 }
 
-type BookOpRequest struct {
+func (x *CreateOfferRequest) GetRequestedBookIds() []string {
+	if x != nil {
+		return x.RequestedBookIds
+	}
+	return nil
+}
+
+func (x *CreateOfferRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type AcceptOfferRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
-	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	RequesterId   string                 `protobuf:"bytes,2,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BookOpRequest) Reset() {
-	*x = BookOpRequest{}
+func (x *AcceptOfferRequest) Reset() {
+	*x = AcceptOfferRequest{}
 	mi := &file_exchange_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BookOpRequest) String() string {
+func (x *AcceptOfferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BookOpRequest) ProtoMessage() {}
+func (*AcceptOfferRequest) ProtoMessage() {}
 
-func (x *BookOpRequest) ProtoReflect() protoreflect.Message {
+func (x *AcceptOfferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -318,46 +420,50 @@ func (x *BookOpRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BookOpRequest.ProtoReflect.Descriptor instead.
-func (*BookOpRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AcceptOfferRequest.ProtoReflect.Descriptor instead.
+func (*AcceptOfferRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *BookOpRequest) GetOfferId() string {
+func (x *AcceptOfferRequest) GetOfferId() string {
 	if x != nil {
 		return x.OfferId
 	}
 	return ""
 }
 
-func (x *BookOpRequest) GetBookId() string {
+func (x *AcceptOfferRequest) GetRequesterId() string {
 	if x != nil {
-		return x.BookId
+		return x.RequesterId
 	}
 	return ""
 }
 
-type StatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// CounterOfferRequest answers offer_id with a new offer from its
+// counterparty. Empty book lists mirror the parent offer.
+type CounterOfferRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OfferId          string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	OfferedBookIds   []string               `protobuf:"bytes,2,rep,name=offered_book_ids,json=offeredBookIds,proto3" json:"offered_book_ids,omitempty"`
+	RequestedBookIds []string               `protobuf:"bytes,3,rep,name=requested_book_ids,json=requestedBookIds,proto3" json:"requested_book_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *StatusRequest) Reset() {
-	*x = StatusRequest{}
+func (x *CounterOfferRequest) Reset() {
+	*x = CounterOfferRequest{}
 	mi := &file_exchange_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StatusRequest) String() string {
+func (x *CounterOfferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusRequest) ProtoMessage() {}
+func (*CounterOfferRequest) ProtoMessage() {}
 
-func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+func (x *CounterOfferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,39 +475,53 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
-func (*StatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CounterOfferRequest.ProtoReflect.Descriptor instead.
+func (*CounterOfferRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *StatusRequest) GetStatus() string {
+func (x *CounterOfferRequest) GetOfferId() string {
 	if x != nil {
-		return x.Status
+		return x.OfferId
 	}
 	return ""
 }
 
-type OfferID struct {
+func (x *CounterOfferRequest) GetOfferedBookIds() []string {
+	if x != nil {
+		return x.OfferedBookIds
+	}
+	return nil
+}
+
+func (x *CounterOfferRequest) GetRequestedBookIds() []string {
+	if x != nil {
+		return x.RequestedBookIds
+	}
+	return nil
+}
+
+type UpdateOfferRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offer         *ExchangeOffer         `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OfferID) Reset() {
-	*x = OfferID{}
+func (x *UpdateOfferRequest) Reset() {
+	*x = UpdateOfferRequest{}
 	mi := &file_exchange_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OfferID) String() string {
+func (x *UpdateOfferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OfferID) ProtoMessage() {}
+func (*UpdateOfferRequest) ProtoMessage() {}
 
-func (x *OfferID) ProtoReflect() protoreflect.Message {
+func (x *UpdateOfferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -413,39 +533,42 @@ func (x *OfferID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OfferID.ProtoReflect.Descriptor instead.
-func (*OfferID) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateOfferRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOfferRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *OfferID) GetId() string {
+func (x *UpdateOfferRequest) GetOffer() *ExchangeOffer {
 	if x != nil {
-		return x.Id
+		return x.Offer
 	}
-	return ""
+	return nil
 }
 
-type UserID struct {
+// PatchOfferRequest overwrites only the fields named in update_mask:
+// counterparty_id, offered_book_ids, requested_book_ids and expires_at.
+type PatchOfferRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Offer         *ExchangeOffer         `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserID) Reset() {
-	*x = UserID{}
+func (x *PatchOfferRequest) Reset() {
+	*x = PatchOfferRequest{}
 	mi := &file_exchange_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserID) String() string {
+func (x *PatchOfferRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserID) ProtoMessage() {}
+func (*PatchOfferRequest) ProtoMessage() {}
 
-func (x *UserID) ProtoReflect() protoreflect.Message {
+func (x *PatchOfferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -457,39 +580,47 @@ func (x *UserID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserID.ProtoReflect.Descriptor instead.
-func (*UserID) Descriptor() ([]byte, []int) {
+// Deprecated: Use PatchOfferRequest.ProtoReflect.Descriptor instead.
+func (*PatchOfferRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UserID) GetUserId() string {
+func (x *PatchOfferRequest) GetOffer() *ExchangeOffer {
 	if x != nil {
-		return x.UserId
+		return x.Offer
 	}
-	return ""
+	return nil
 }
 
-type OfferResponse struct {
+func (x *PatchOfferRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type BookOpRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Offer         *ExchangeOffer         `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OfferResponse) Reset() {
-	*x = OfferResponse{}
+func (x *BookOpRequest) Reset() {
+	*x = BookOpRequest{}
 	mi := &file_exchange_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OfferResponse) String() string {
+func (x *BookOpRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OfferResponse) ProtoMessage() {}
+func (*BookOpRequest) ProtoMessage() {}
 
-func (x *OfferResponse) ProtoReflect() protoreflect.Message {
+func (x *BookOpRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,40 +632,1239 @@ func (x *OfferResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OfferResponse.ProtoReflect.Descriptor instead.
-func (*OfferResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BookOpRequest.ProtoReflect.Descriptor instead.
+func (*BookOpRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *OfferResponse) GetOffer() *ExchangeOffer {
+func (x *BookOpRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *BookOpRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	mi := &file_exchange_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type OfferID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferID) Reset() {
+	*x = OfferID{}
+	mi := &file_exchange_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferID) ProtoMessage() {}
+
+func (x *OfferID) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferID.ProtoReflect.Descriptor instead.
+func (*OfferID) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OfferID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UserID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserID) Reset() {
+	*x = UserID{}
+	mi := &file_exchange_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserID) ProtoMessage() {}
+
+func (x *UserID) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserID.ProtoReflect.Descriptor instead.
+func (*UserID) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UserID) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type OfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offer         *ExchangeOffer         `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferResponse) Reset() {
+	*x = OfferResponse{}
+	mi := &file_exchange_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferResponse) ProtoMessage() {}
+
+func (x *OfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferResponse.ProtoReflect.Descriptor instead.
+func (*OfferResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OfferResponse) GetOffer() *ExchangeOffer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type OfferList struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offers []*ExchangeOffer       `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	// Only set by ListAllOffers; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferList) Reset() {
+	*x = OfferList{}
+	mi := &file_exchange_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferList) ProtoMessage() {}
+
+func (x *OfferList) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferList.ProtoReflect.Descriptor instead.
+func (*OfferList) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OfferList) GetOffers() []*ExchangeOffer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+func (x *OfferList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ListOffersRequest pages through all offers. sort is created_at, updated_at
+// or expires_at, prefixed with "-" for descending order; the default is
+// creation order. user_id matches the owner or the counterparty, and
+// created_after/created_before (RFC 3339) bound created_at.
+type ListOffersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAfter  string                 `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore string                 `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOffersRequest) Reset() {
+	*x = ListOffersRequest{}
+	mi := &file_exchange_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOffersRequest) ProtoMessage() {}
+
+func (x *ListOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOffersRequest.ProtoReflect.Descriptor instead.
+func (*ListOffersRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListOffersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOffersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOffersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListOffersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOffersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOffersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListOffersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_exchange_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{15}
+}
+
+type DisputeEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeEvidence) Reset() {
+	*x = DisputeEvidence{}
+	mi := &file_exchange_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidence) ProtoMessage() {}
+
+func (x *DisputeEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEvidence.ProtoReflect.Descriptor instead.
+func (*DisputeEvidence) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DisputeEvidence) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Dispute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	OpenedBy      string                 `protobuf:"bytes,3,opt,name=opened_by,json=openedBy,proto3" json:"opened_by,omitempty"`
+	AgainstId     string                 `protobuf:"bytes,4,opt,name=against_id,json=againstId,proto3" json:"against_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Evidence      []*DisputeEvidence     `protobuf:"bytes,7,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	Resolution    string                 `protobuf:"bytes,8,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Reversed      bool                   `protobuf:"varint,9,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,10,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt    string                 `protobuf:"bytes,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dispute) Reset() {
+	*x = Dispute{}
+	mi := &file_exchange_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dispute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dispute) ProtoMessage() {}
+
+func (x *Dispute) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dispute.ProtoReflect.Descriptor instead.
+func (*Dispute) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Dispute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dispute) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *Dispute) GetOpenedBy() string {
+	if x != nil {
+		return x.OpenedBy
+	}
+	return ""
+}
+
+func (x *Dispute) GetAgainstId() string {
+	if x != nil {
+		return x.AgainstId
+	}
+	return ""
+}
+
+func (x *Dispute) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Dispute) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Dispute) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Dispute) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *Dispute) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+func (x *Dispute) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Dispute) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolvedAt() string {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return ""
+}
+
+type OpenDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenDisputeRequest) Reset() {
+	*x = OpenDisputeRequest{}
+	mi := &file_exchange_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenDisputeRequest) ProtoMessage() {}
+
+func (x *OpenDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenDisputeRequest.ProtoReflect.Descriptor instead.
+func (*OpenDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OpenDisputeRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DisputeEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeEvidenceRequest) Reset() {
+	*x = DisputeEvidenceRequest{}
+	mi := &file_exchange_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *DisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*DisputeEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DisputeEvidenceRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *DisputeEvidenceRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// ResolveDisputeRequest closes a dispute; reverse_swap gives every book
+// back to the user who had it before the exchange.
+type ResolveDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	ReverseSwap   bool                   `protobuf:"varint,3,opt,name=reverse_swap,json=reverseSwap,proto3" json:"reverse_swap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDisputeRequest) Reset() {
+	*x = ResolveDisputeRequest{}
+	mi := &file_exchange_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDisputeRequest) ProtoMessage() {}
+
+func (x *ResolveDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDisputeRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResolveDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetReverseSwap() bool {
+	if x != nil {
+		return x.ReverseSwap
+	}
+	return false
+}
+
+type DisputeID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeID) Reset() {
+	*x = DisputeID{}
+	mi := &file_exchange_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeID) ProtoMessage() {}
+
+func (x *DisputeID) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeID.ProtoReflect.Descriptor instead.
+func (*DisputeID) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DisputeID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DisputeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dispute       *Dispute               `protobuf:"bytes,1,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	Offer         *ExchangeOffer         `protobuf:"bytes,2,opt,name=offer,proto3" json:"offer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeResponse) Reset() {
+	*x = DisputeResponse{}
+	mi := &file_exchange_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeResponse) ProtoMessage() {}
+
+func (x *DisputeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeResponse.ProtoReflect.Descriptor instead.
+func (*DisputeResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DisputeResponse) GetDispute() *Dispute {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+func (x *DisputeResponse) GetOffer() *ExchangeOffer {
 	if x != nil {
 		return x.Offer
 	}
 	return nil
 }
 
-type OfferList struct {
+type ListPendingRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IncludeReputation bool                   `protobuf:"varint,1,opt,name=include_reputation,json=includeReputation,proto3" json:"include_reputation,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListPendingRequest) Reset() {
+	*x = ListPendingRequest{}
+	mi := &file_exchange_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingRequest) ProtoMessage() {}
+
+func (x *ListPendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListPendingRequest) GetIncludeReputation() bool {
+	if x != nil {
+		return x.IncludeReputation
+	}
+	return false
+}
+
+type RateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"` // 1 to 5
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateRequest) Reset() {
+	*x = RateRequest{}
+	mi := &file_exchange_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateRequest) ProtoMessage() {}
+
+func (x *RateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateRequest.ProtoReflect.Descriptor instead.
+func (*RateRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RateRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *RateRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RateRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type Rating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	RaterId       string                 `protobuf:"bytes,3,opt,name=rater_id,json=raterId,proto3" json:"rater_id,omitempty"`
+	RateeId       string                 `protobuf:"bytes,4,opt,name=ratee_id,json=rateeId,proto3" json:"ratee_id,omitempty"`
+	Score         int32                  `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`
+	Comment       string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	mi := &file_exchange_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Rating) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rating) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *Rating) GetRaterId() string {
+	if x != nil {
+		return x.RaterId
+	}
+	return ""
+}
+
+func (x *Rating) GetRateeId() string {
+	if x != nil {
+		return x.RateeId
+	}
+	return ""
+}
+
+func (x *Rating) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Rating) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Rating) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Reputation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AverageScore  float64                `protobuf:"fixed64,2,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	RatingCount   int64                  `protobuf:"varint,3,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	TradeCount    int64                  `protobuf:"varint,4,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	DisputeCount  int64                  `protobuf:"varint,5,opt,name=dispute_count,json=disputeCount,proto3" json:"dispute_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reputation) Reset() {
+	*x = Reputation{}
+	mi := &file_exchange_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reputation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reputation) ProtoMessage() {}
+
+func (x *Reputation) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reputation.ProtoReflect.Descriptor instead.
+func (*Reputation) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Reputation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reputation) GetAverageScore() float64 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+func (x *Reputation) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Reputation) GetTradeCount() int64 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+func (x *Reputation) GetDisputeCount() int64 {
+	if x != nil {
+		return x.DisputeCount
+	}
+	return 0
+}
+
+type WantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WantRequest) Reset() {
+	*x = WantRequest{}
+	mi := &file_exchange_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WantRequest) ProtoMessage() {}
+
+func (x *WantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WantRequest.ProtoReflect.Descriptor instead.
+func (*WantRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WantRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WantRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type WantList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookIds       []string               `protobuf:"bytes,2,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WantList) Reset() {
+	*x = WantList{}
+	mi := &file_exchange_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WantList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WantList) ProtoMessage() {}
+
+func (x *WantList) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WantList.ProtoReflect.Descriptor instead.
+func (*WantList) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WantList) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WantList) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+type FindMatchesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Offers        []*ExchangeOffer       `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OfferList) Reset() {
-	*x = OfferList{}
-	mi := &file_exchange_proto_msgTypes[9]
+func (x *FindMatchesRequest) Reset() {
+	*x = FindMatchesRequest{}
+	mi := &file_exchange_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OfferList) String() string {
+func (x *FindMatchesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OfferList) ProtoMessage() {}
+func (*FindMatchesRequest) ProtoMessage() {}
 
-func (x *OfferList) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_proto_msgTypes[9]
+func (x *FindMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -545,39 +1875,117 @@ func (x *OfferList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OfferList.ProtoReflect.Descriptor instead.
-func (*OfferList) Descriptor() ([]byte, []int) {
-	return file_exchange_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use FindMatchesRequest.ProtoReflect.Descriptor instead.
+func (*FindMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *OfferList) GetOffers() []*ExchangeOffer {
+func (x *FindMatchesRequest) GetUserId() string {
 	if x != nil {
-		return x.Offers
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FindMatchesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Match is a user who holds books the requester wants and wants books the
+// requester holds. Book lists are seen from the requester's side.
+type Match struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PartnerId        string                 `protobuf:"bytes,1,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	OfferedBookIds   []string               `protobuf:"bytes,2,rep,name=offered_book_ids,json=offeredBookIds,proto3" json:"offered_book_ids,omitempty"`
+	RequestedBookIds []string               `protobuf:"bytes,3,rep,name=requested_book_ids,json=requestedBookIds,proto3" json:"requested_book_ids,omitempty"`
+	Score            int32                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_exchange_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Match) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+func (x *Match) GetOfferedBookIds() []string {
+	if x != nil {
+		return x.OfferedBookIds
 	}
 	return nil
 }
 
-type Empty struct {
+func (x *Match) GetRequestedBookIds() []string {
+	if x != nil {
+		return x.RequestedBookIds
+	}
+	return nil
+}
+
+func (x *Match) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type MatchList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*Match               `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	mi := &file_exchange_proto_msgTypes[10]
+func (x *MatchList) Reset() {
+	*x = MatchList{}
+	mi := &file_exchange_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *MatchList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*MatchList) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_proto_msgTypes[10]
+func (x *MatchList) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,16 +1996,203 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_exchange_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use MatchList.ProtoReflect.Descriptor instead.
+func (*MatchList) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MatchList) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type MatchOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartnerId     string                 `protobuf:"bytes,1,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchOfferRequest) Reset() {
+	*x = MatchOfferRequest{}
+	mi := &file_exchange_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchOfferRequest) ProtoMessage() {}
+
+func (x *MatchOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchOfferRequest.ProtoReflect.Descriptor instead.
+func (*MatchOfferRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MatchOfferRequest) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+func (x *MatchOfferRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type OfferMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	SenderId      string                 `protobuf:"bytes,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferMessage) Reset() {
+	*x = OfferMessage{}
+	mi := &file_exchange_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferMessage) ProtoMessage() {}
+
+func (x *OfferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferMessage.ProtoReflect.Descriptor instead.
+func (*OfferMessage) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OfferMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OfferMessage) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OfferMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *OfferMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *OfferMessage) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type SendOfferMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendOfferMessageRequest) Reset() {
+	*x = SendOfferMessageRequest{}
+	mi := &file_exchange_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendOfferMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendOfferMessageRequest) ProtoMessage() {}
+
+func (x *SendOfferMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendOfferMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendOfferMessageRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SendOfferMessageRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *SendOfferMessageRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
 }
 
 var File_exchange_proto protoreflect.FileDescriptor
 
 const file_exchange_proto_rawDesc = "" +
 	"\n" +
-	"\x0eexchange.proto\x12\bexchange\"\x91\x02\n" +
+	"\x0eexchange.proto\x12\bexchange\x1a google/protobuf/field_mask.proto\"\xe1\x03\n" +
 	"\rExchangeOffer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12'\n" +
@@ -608,17 +2203,45 @@ const file_exchange_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"\xb0\x01\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\tR\texpiresAt\x12?\n" +
+	"\x10owner_reputation\x18\v \x01(\v2\x14.exchange.ReputationR\x0fownerReputation\x12\x1a\n" +
+	"\brevision\x18\f \x01(\x05R\brevision\x125\n" +
+	"\trevisions\x18\r \x03(\v2\x17.exchange.OfferRevisionR\trevisions\"\x92\x01\n" +
+	"\rOfferRevision\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1b\n" +
+	"\teditor_id\x18\x02 \x01(\tR\beditorId\x12\x1b\n" +
+	"\tedited_at\x18\x03 \x01(\tR\beditedAt\x12/\n" +
+	"\achanges\x18\x04 \x03(\v2\x15.exchange.FieldChangeR\achanges\"w\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05added\x18\x02 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\"\xcf\x01\n" +
 	"\x12CreateOfferRequest\x12\x19\n" +
 	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12'\n" +
 	"\x0fcounterparty_id\x18\x02 \x01(\tR\x0ecounterpartyId\x12(\n" +
 	"\x10offered_book_ids\x18\x03 \x03(\tR\x0eofferedBookIds\x12,\n" +
-	"\x12requested_book_ids\x18\x04 \x03(\tR\x10requestedBookIds\"R\n" +
+	"\x12requested_book_ids\x18\x04 \x03(\tR\x10requestedBookIds\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"R\n" +
 	"\x12AcceptOfferRequest\x12\x19\n" +
 	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12!\n" +
-	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"C\n" +
+	"\frequester_id\x18\x02 \x01(\tR\vrequesterId\"\x88\x01\n" +
+	"\x13CounterOfferRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12(\n" +
+	"\x10offered_book_ids\x18\x02 \x03(\tR\x0eofferedBookIds\x12,\n" +
+	"\x12requested_book_ids\x18\x03 \x03(\tR\x10requestedBookIds\"C\n" +
 	"\x12UpdateOfferRequest\x12-\n" +
-	"\x05offer\x18\x01 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\"C\n" +
+	"\x05offer\x18\x01 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\"\x7f\n" +
+	"\x11PatchOfferRequest\x12-\n" +
+	"\x05offer\x18\x01 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"C\n" +
 	"\rBookOpRequest\x12\x19\n" +
 	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x17\n" +
 	"\abook_id\x18\x02 \x01(\tR\x06bookId\"'\n" +
@@ -629,22 +2252,154 @@ const file_exchange_proto_rawDesc = "" +
 	"\x06UserID\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
 	"\rOfferResponse\x12-\n" +
-	"\x05offer\x18\x01 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\"<\n" +
+	"\x05offer\x18\x01 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\"d\n" +
 	"\tOfferList\x12/\n" +
-	"\x06offers\x18\x01 \x03(\v2\x17.exchange.ExchangeOfferR\x06offers\"\a\n" +
-	"\x05Empty2\x86\x06\n" +
+	"\x06offers\x18\x01 \x03(\v2\x17.exchange.ExchangeOfferR\x06offers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe0\x01\n" +
+	"\x11ListOffersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12#\n" +
+	"\rcreated_after\x18\x06 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\a \x01(\tR\rcreatedBefore\"\a\n" +
+	"\x05Empty\"a\n" +
+	"\x0fDisputeEvidence\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"\xf4\x02\n" +
+	"\aDispute\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x1b\n" +
+	"\topened_by\x18\x03 \x01(\tR\bopenedBy\x12\x1d\n" +
+	"\n" +
+	"against_id\x18\x04 \x01(\tR\tagainstId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x125\n" +
+	"\bevidence\x18\a \x03(\v2\x19.exchange.DisputeEvidenceR\bevidence\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\b \x01(\tR\n" +
+	"resolution\x12\x1a\n" +
+	"\breversed\x18\t \x01(\bR\breversed\x12\x1f\n" +
+	"\vresolved_by\x18\n" +
+	" \x01(\tR\n" +
+	"resolvedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vresolved_at\x18\f \x01(\tR\n" +
+	"resolvedAt\"G\n" +
+	"\x12OpenDisputeRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"K\n" +
+	"\x16DisputeEvidenceRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"y\n" +
+	"\x15ResolveDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x02 \x01(\tR\n" +
+	"resolution\x12!\n" +
+	"\freverse_swap\x18\x03 \x01(\bR\vreverseSwap\"\x1b\n" +
+	"\tDisputeID\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"m\n" +
+	"\x0fDisputeResponse\x12+\n" +
+	"\adispute\x18\x01 \x01(\v2\x11.exchange.DisputeR\adispute\x12-\n" +
+	"\x05offer\x18\x02 \x01(\v2\x17.exchange.ExchangeOfferR\x05offer\"C\n" +
+	"\x12ListPendingRequest\x12-\n" +
+	"\x12include_reputation\x18\x01 \x01(\bR\x11includeReputation\"X\n" +
+	"\vRateRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"\xb8\x01\n" +
+	"\x06Rating\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x19\n" +
+	"\brater_id\x18\x03 \x01(\tR\araterId\x12\x19\n" +
+	"\bratee_id\x18\x04 \x01(\tR\arateeId\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x05R\x05score\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\xb3\x01\n" +
+	"\n" +
+	"Reputation\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\raverage_score\x18\x02 \x01(\x01R\faverageScore\x12!\n" +
+	"\frating_count\x18\x03 \x01(\x03R\vratingCount\x12\x1f\n" +
+	"\vtrade_count\x18\x04 \x01(\x03R\n" +
+	"tradeCount\x12#\n" +
+	"\rdispute_count\x18\x05 \x01(\x03R\fdisputeCount\"?\n" +
+	"\vWantRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\abook_id\x18\x02 \x01(\tR\x06bookId\">\n" +
+	"\bWantList\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bbook_ids\x18\x02 \x03(\tR\abookIds\"C\n" +
+	"\x12FindMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x94\x01\n" +
+	"\x05Match\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x01 \x01(\tR\tpartnerId\x12(\n" +
+	"\x10offered_book_ids\x18\x02 \x03(\tR\x0eofferedBookIds\x12,\n" +
+	"\x12requested_book_ids\x18\x03 \x03(\tR\x10requestedBookIds\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\"6\n" +
+	"\tMatchList\x12)\n" +
+	"\amatches\x18\x01 \x03(\v2\x0f.exchange.MatchR\amatches\"Q\n" +
+	"\x11MatchOfferRequest\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x01 \x01(\tR\tpartnerId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\"\x89\x01\n" +
+	"\fOfferMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"H\n" +
+	"\x17SendOfferMessageRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body2\xd4\x10\n" +
 	"\x0fExchangeService\x12D\n" +
 	"\vCreateOffer\x12\x1c.exchange.CreateOfferRequest\x1a\x17.exchange.OfferResponse\x126\n" +
 	"\bGetOffer\x12\x11.exchange.OfferID\x1a\x17.exchange.OfferResponse\x129\n" +
-	"\x10ListOffersByUser\x12\x10.exchange.UserID\x1a\x13.exchange.OfferList\x129\n" +
-	"\x11ListPendingOffers\x12\x0f.exchange.Empty\x1a\x13.exchange.OfferList\x12D\n" +
+	"\x10ListOffersByUser\x12\x10.exchange.UserID\x1a\x13.exchange.OfferList\x12F\n" +
+	"\x11ListPendingOffers\x12\x1c.exchange.ListPendingRequest\x1a\x13.exchange.OfferList\x12D\n" +
 	"\vAcceptOffer\x12\x1c.exchange.AcceptOfferRequest\x1a\x17.exchange.OfferResponse\x12:\n" +
 	"\fDeclineOffer\x12\x11.exchange.OfferID\x1a\x17.exchange.OfferResponse\x121\n" +
-	"\vDeleteOffer\x12\x11.exchange.OfferID\x1a\x0f.exchange.Empty\x12D\n" +
+	"\vDeleteOffer\x12\x11.exchange.OfferID\x1a\x0f.exchange.Empty\x129\n" +
+	"\vCancelOffer\x12\x11.exchange.OfferID\x1a\x17.exchange.OfferResponse\x12;\n" +
+	"\rCompleteOffer\x12\x11.exchange.OfferID\x1a\x17.exchange.OfferResponse\x12F\n" +
+	"\fCounterOffer\x12\x1d.exchange.CounterOfferRequest\x1a\x17.exchange.OfferResponse\x128\n" +
+	"\x0eGetNegotiation\x12\x11.exchange.OfferID\x1a\x13.exchange.OfferList\x12>\n" +
+	"\x13RateExchangePartner\x12\x15.exchange.RateRequest\x1a\x10.exchange.Rating\x12;\n" +
+	"\x11GetUserReputation\x12\x10.exchange.UserID\x1a\x14.exchange.Reputation\x12F\n" +
+	"\vOpenDispute\x12\x1c.exchange.OpenDisputeRequest\x1a\x19.exchange.DisputeResponse\x12Q\n" +
+	"\x12AddDisputeEvidence\x12 .exchange.DisputeEvidenceRequest\x1a\x19.exchange.DisputeResponse\x12L\n" +
+	"\x0eResolveDispute\x12\x1f.exchange.ResolveDisputeRequest\x1a\x19.exchange.DisputeResponse\x12<\n" +
+	"\n" +
+	"GetDispute\x12\x13.exchange.DisputeID\x1a\x19.exchange.DisputeResponse\x12M\n" +
+	"\x10SendOfferMessage\x12!.exchange.SendOfferMessageRequest\x1a\x16.exchange.OfferMessage\x12A\n" +
+	"\x12WatchOfferMessages\x12\x11.exchange.OfferID\x1a\x16.exchange.OfferMessage0\x01\x124\n" +
+	"\aAddWant\x12\x15.exchange.WantRequest\x1a\x12.exchange.WantList\x127\n" +
+	"\n" +
+	"RemoveWant\x12\x15.exchange.WantRequest\x1a\x12.exchange.WantList\x121\n" +
+	"\tListWants\x12\x10.exchange.UserID\x1a\x12.exchange.WantList\x12@\n" +
+	"\vFindMatches\x12\x1c.exchange.FindMatchesRequest\x1a\x13.exchange.MatchList\x12L\n" +
+	"\x14CreateOfferFromMatch\x12\x1b.exchange.MatchOfferRequest\x1a\x17.exchange.OfferResponse\x12D\n" +
 	"\vUpdateOffer\x12\x1c.exchange.UpdateOfferRequest\x1a\x17.exchange.OfferResponse\x12B\n" +
 	"\x0eAddOfferedBook\x12\x17.exchange.BookOpRequest\x1a\x17.exchange.OfferResponse\x12E\n" +
-	"\x11RemoveOfferedBook\x12\x17.exchange.BookOpRequest\x1a\x17.exchange.OfferResponse\x125\n" +
-	"\rListAllOffers\x12\x0f.exchange.Empty\x1a\x13.exchange.OfferList\x12B\n" +
+	"\x11RemoveOfferedBook\x12\x17.exchange.BookOpRequest\x1a\x17.exchange.OfferResponse\x12D\n" +
+	"\x10AddRequestedBook\x12\x17.exchange.BookOpRequest\x1a\x17.exchange.OfferResponse\x12G\n" +
+	"\x13RemoveRequestedBook\x12\x17.exchange.BookOpRequest\x1a\x17.exchange.OfferResponse\x12B\n" +
+	"\n" +
+	"PatchOffer\x12\x1b.exchange.PatchOfferRequest\x1a\x17.exchange.OfferResponse\x12A\n" +
+	"\rListAllOffers\x12\x1b.exchange.ListOffersRequest\x1a\x13.exchange.OfferList\x12B\n" +
 	"\x12ListOffersByStatus\x12\x17.exchange.StatusRequest\x1a\x13.exchange.OfferListBTZRgithub.com/OshakbayAigerim/read_space/exchange_service/proto/exchangepb;exchangepbb\x06proto3"
 
 var (
@@ -659,53 +2414,127 @@ func file_exchange_proto_rawDescGZIP() []byte {
 	return file_exchange_proto_rawDescData
 }
 
-var file_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_exchange_proto_goTypes = []any{
-	(*ExchangeOffer)(nil),      // 0: exchange.ExchangeOffer
-	(*CreateOfferRequest)(nil), // 1: exchange.CreateOfferRequest
-	(*AcceptOfferRequest)(nil), // 2: exchange.AcceptOfferRequest
-	(*UpdateOfferRequest)(nil), // 3: exchange.UpdateOfferRequest
-	(*BookOpRequest)(nil),      // 4: exchange.BookOpRequest
-	(*StatusRequest)(nil),      // 5: exchange.StatusRequest
-	(*OfferID)(nil),            // 6: exchange.OfferID
-	(*UserID)(nil),             // 7: exchange.UserID
-	(*OfferResponse)(nil),      // 8: exchange.OfferResponse
-	(*OfferList)(nil),          // 9: exchange.OfferList
-	(*Empty)(nil),              // 10: exchange.Empty
+	(*ExchangeOffer)(nil),           // 0: exchange.ExchangeOffer
+	(*OfferRevision)(nil),           // 1: exchange.OfferRevision
+	(*FieldChange)(nil),             // 2: exchange.FieldChange
+	(*CreateOfferRequest)(nil),      // 3: exchange.CreateOfferRequest
+	(*AcceptOfferRequest)(nil),      // 4: exchange.AcceptOfferRequest
+	(*CounterOfferRequest)(nil),     // 5: exchange.CounterOfferRequest
+	(*UpdateOfferRequest)(nil),      // 6: exchange.UpdateOfferRequest
+	(*PatchOfferRequest)(nil),       // 7: exchange.PatchOfferRequest
+	(*BookOpRequest)(nil),           // 8: exchange.BookOpRequest
+	(*StatusRequest)(nil),           // 9: exchange.StatusRequest
+	(*OfferID)(nil),                 // 10: exchange.OfferID
+	(*UserID)(nil),                  // 11: exchange.UserID
+	(*OfferResponse)(nil),           // 12: exchange.OfferResponse
+	(*OfferList)(nil),               // 13: exchange.OfferList
+	(*ListOffersRequest)(nil),       // 14: exchange.ListOffersRequest
+	(*Empty)(nil),                   // 15: exchange.Empty
+	(*DisputeEvidence)(nil),         // 16: exchange.DisputeEvidence
+	(*Dispute)(nil),                 // 17: exchange.Dispute
+	(*OpenDisputeRequest)(nil),      // 18: exchange.OpenDisputeRequest
+	(*DisputeEvidenceRequest)(nil),  // 19: exchange.DisputeEvidenceRequest
+	(*ResolveDisputeRequest)(nil),   // 20: exchange.ResolveDisputeRequest
+	(*DisputeID)(nil),               // 21: exchange.DisputeID
+	(*DisputeResponse)(nil),         // 22: exchange.DisputeResponse
+	(*ListPendingRequest)(nil),      // 23: exchange.ListPendingRequest
+	(*RateRequest)(nil),             // 24: exchange.RateRequest
+	(*Rating)(nil),                  // 25: exchange.Rating
+	(*Reputation)(nil),              // 26: exchange.Reputation
+	(*WantRequest)(nil),             // 27: exchange.WantRequest
+	(*WantList)(nil),                // 28: exchange.WantList
+	(*FindMatchesRequest)(nil),      // 29: exchange.FindMatchesRequest
+	(*Match)(nil),                   // 30: exchange.Match
+	(*MatchList)(nil),               // 31: exchange.MatchList
+	(*MatchOfferRequest)(nil),       // 32: exchange.MatchOfferRequest
+	(*OfferMessage)(nil),            // 33: exchange.OfferMessage
+	(*SendOfferMessageRequest)(nil), // 34: exchange.SendOfferMessageRequest
+	(*fieldmaskpb.FieldMask)(nil),   // 35: google.protobuf.FieldMask
 }
 var file_exchange_proto_depIdxs = []int32{
-	0,  // 0: exchange.UpdateOfferRequest.offer:type_name -> exchange.ExchangeOffer
-	0,  // 1: exchange.OfferResponse.offer:type_name -> exchange.ExchangeOffer
-	0,  // 2: exchange.OfferList.offers:type_name -> exchange.ExchangeOffer
-	1,  // 3: exchange.ExchangeService.CreateOffer:input_type -> exchange.CreateOfferRequest
-	6,  // 4: exchange.ExchangeService.GetOffer:input_type -> exchange.OfferID
-	7,  // 5: exchange.ExchangeService.ListOffersByUser:input_type -> exchange.UserID
-	10, // 6: exchange.ExchangeService.ListPendingOffers:input_type -> exchange.Empty
-	2,  // 7: exchange.ExchangeService.AcceptOffer:input_type -> exchange.AcceptOfferRequest
-	6,  // 8: exchange.ExchangeService.DeclineOffer:input_type -> exchange.OfferID
-	6,  // 9: exchange.ExchangeService.DeleteOffer:input_type -> exchange.OfferID
-	3,  // 10: exchange.ExchangeService.UpdateOffer:input_type -> exchange.UpdateOfferRequest
-	4,  // 11: exchange.ExchangeService.AddOfferedBook:input_type -> exchange.BookOpRequest
-	4,  // 12: exchange.ExchangeService.RemoveOfferedBook:input_type -> exchange.BookOpRequest
-	10, // 13: exchange.ExchangeService.ListAllOffers:input_type -> exchange.Empty
-	5,  // 14: exchange.ExchangeService.ListOffersByStatus:input_type -> exchange.StatusRequest
-	8,  // 15: exchange.ExchangeService.CreateOffer:output_type -> exchange.OfferResponse
-	8,  // 16: exchange.ExchangeService.GetOffer:output_type -> exchange.OfferResponse
-	9,  // 17: exchange.ExchangeService.ListOffersByUser:output_type -> exchange.OfferList
-	9,  // 18: exchange.ExchangeService.ListPendingOffers:output_type -> exchange.OfferList
-	8,  // 19: exchange.ExchangeService.AcceptOffer:output_type -> exchange.OfferResponse
-	8,  // 20: exchange.ExchangeService.DeclineOffer:output_type -> exchange.OfferResponse
-	10, // 21: exchange.ExchangeService.DeleteOffer:output_type -> exchange.Empty
-	8,  // 22: exchange.ExchangeService.UpdateOffer:output_type -> exchange.OfferResponse
-	8,  // 23: exchange.ExchangeService.AddOfferedBook:output_type -> exchange.OfferResponse
-	8,  // 24: exchange.ExchangeService.RemoveOfferedBook:output_type -> exchange.OfferResponse
-	9,  // 25: exchange.ExchangeService.ListAllOffers:output_type -> exchange.OfferList
-	9,  // 26: exchange.ExchangeService.ListOffersByStatus:output_type -> exchange.OfferList
-	15, // [15:27] is the sub-list for method output_type
-	3,  // [3:15] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	26, // 0: exchange.ExchangeOffer.owner_reputation:type_name -> exchange.Reputation
+	1,  // 1: exchange.ExchangeOffer.revisions:type_name -> exchange.OfferRevision
+	2,  // 2: exchange.OfferRevision.changes:type_name -> exchange.FieldChange
+	0,  // 3: exchange.UpdateOfferRequest.offer:type_name -> exchange.ExchangeOffer
+	0,  // 4: exchange.PatchOfferRequest.offer:type_name -> exchange.ExchangeOffer
+	35, // 5: exchange.PatchOfferRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 6: exchange.OfferResponse.offer:type_name -> exchange.ExchangeOffer
+	0,  // 7: exchange.OfferList.offers:type_name -> exchange.ExchangeOffer
+	16, // 8: exchange.Dispute.evidence:type_name -> exchange.DisputeEvidence
+	17, // 9: exchange.DisputeResponse.dispute:type_name -> exchange.Dispute
+	0,  // 10: exchange.DisputeResponse.offer:type_name -> exchange.ExchangeOffer
+	30, // 11: exchange.MatchList.matches:type_name -> exchange.Match
+	3,  // 12: exchange.ExchangeService.CreateOffer:input_type -> exchange.CreateOfferRequest
+	10, // 13: exchange.ExchangeService.GetOffer:input_type -> exchange.OfferID
+	11, // 14: exchange.ExchangeService.ListOffersByUser:input_type -> exchange.UserID
+	23, // 15: exchange.ExchangeService.ListPendingOffers:input_type -> exchange.ListPendingRequest
+	4,  // 16: exchange.ExchangeService.AcceptOffer:input_type -> exchange.AcceptOfferRequest
+	10, // 17: exchange.ExchangeService.DeclineOffer:input_type -> exchange.OfferID
+	10, // 18: exchange.ExchangeService.DeleteOffer:input_type -> exchange.OfferID
+	10, // 19: exchange.ExchangeService.CancelOffer:input_type -> exchange.OfferID
+	10, // 20: exchange.ExchangeService.CompleteOffer:input_type -> exchange.OfferID
+	5,  // 21: exchange.ExchangeService.CounterOffer:input_type -> exchange.CounterOfferRequest
+	10, // 22: exchange.ExchangeService.GetNegotiation:input_type -> exchange.OfferID
+	24, // 23: exchange.ExchangeService.RateExchangePartner:input_type -> exchange.RateRequest
+	11, // 24: exchange.ExchangeService.GetUserReputation:input_type -> exchange.UserID
+	18, // 25: exchange.ExchangeService.OpenDispute:input_type -> exchange.OpenDisputeRequest
+	19, // 26: exchange.ExchangeService.AddDisputeEvidence:input_type -> exchange.DisputeEvidenceRequest
+	20, // 27: exchange.ExchangeService.ResolveDispute:input_type -> exchange.ResolveDisputeRequest
+	21, // 28: exchange.ExchangeService.GetDispute:input_type -> exchange.DisputeID
+	34, // 29: exchange.ExchangeService.SendOfferMessage:input_type -> exchange.SendOfferMessageRequest
+	10, // 30: exchange.ExchangeService.WatchOfferMessages:input_type -> exchange.OfferID
+	27, // 31: exchange.ExchangeService.AddWant:input_type -> exchange.WantRequest
+	27, // 32: exchange.ExchangeService.RemoveWant:input_type -> exchange.WantRequest
+	11, // 33: exchange.ExchangeService.ListWants:input_type -> exchange.UserID
+	29, // 34: exchange.ExchangeService.FindMatches:input_type -> exchange.FindMatchesRequest
+	32, // 35: exchange.ExchangeService.CreateOfferFromMatch:input_type -> exchange.MatchOfferRequest
+	6,  // 36: exchange.ExchangeService.UpdateOffer:input_type -> exchange.UpdateOfferRequest
+	8,  // 37: exchange.ExchangeService.AddOfferedBook:input_type -> exchange.BookOpRequest
+	8,  // 38: exchange.ExchangeService.RemoveOfferedBook:input_type -> exchange.BookOpRequest
+	8,  // 39: exchange.ExchangeService.AddRequestedBook:input_type -> exchange.BookOpRequest
+	8,  // 40: exchange.ExchangeService.RemoveRequestedBook:input_type -> exchange.BookOpRequest
+	7,  // 41: exchange.ExchangeService.PatchOffer:input_type -> exchange.PatchOfferRequest
+	14, // 42: exchange.ExchangeService.ListAllOffers:input_type -> exchange.ListOffersRequest
+	9,  // 43: exchange.ExchangeService.ListOffersByStatus:input_type -> exchange.StatusRequest
+	12, // 44: exchange.ExchangeService.CreateOffer:output_type -> exchange.OfferResponse
+	12, // 45: exchange.ExchangeService.GetOffer:output_type -> exchange.OfferResponse
+	13, // 46: exchange.ExchangeService.ListOffersByUser:output_type -> exchange.OfferList
+	13, // 47: exchange.ExchangeService.ListPendingOffers:output_type -> exchange.OfferList
+	12, // 48: exchange.ExchangeService.AcceptOffer:output_type -> exchange.OfferResponse
+	12, // 49: exchange.ExchangeService.DeclineOffer:output_type -> exchange.OfferResponse
+	15, // 50: exchange.ExchangeService.DeleteOffer:output_type -> exchange.Empty
+	12, // 51: exchange.ExchangeService.CancelOffer:output_type -> exchange.OfferResponse
+	12, // 52: exchange.ExchangeService.CompleteOffer:output_type -> exchange.OfferResponse
+	12, // 53: exchange.ExchangeService.CounterOffer:output_type -> exchange.OfferResponse
+	13, // 54: exchange.ExchangeService.GetNegotiation:output_type -> exchange.OfferList
+	25, // 55: exchange.ExchangeService.RateExchangePartner:output_type -> exchange.Rating
+	26, // 56: exchange.ExchangeService.GetUserReputation:output_type -> exchange.Reputation
+	22, // 57: exchange.ExchangeService.OpenDispute:output_type -> exchange.DisputeResponse
+	22, // 58: exchange.ExchangeService.AddDisputeEvidence:output_type -> exchange.DisputeResponse
+	22, // 59: exchange.ExchangeService.ResolveDispute:output_type -> exchange.DisputeResponse
+	22, // 60: exchange.ExchangeService.GetDispute:output_type -> exchange.DisputeResponse
+	33, // 61: exchange.ExchangeService.SendOfferMessage:output_type -> exchange.OfferMessage
+	33, // 62: exchange.ExchangeService.WatchOfferMessages:output_type -> exchange.OfferMessage
+	28, // 63: exchange.ExchangeService.AddWant:output_type -> exchange.WantList
+	28, // 64: exchange.ExchangeService.RemoveWant:output_type -> exchange.WantList
+	28, // 65: exchange.ExchangeService.ListWants:output_type -> exchange.WantList
+	31, // 66: exchange.ExchangeService.FindMatches:output_type -> exchange.MatchList
+	12, // 67: exchange.ExchangeService.CreateOfferFromMatch:output_type -> exchange.OfferResponse
+	12, // 68: exchange.ExchangeService.UpdateOffer:output_type -> exchange.OfferResponse
+	12, // 69: exchange.ExchangeService.AddOfferedBook:output_type -> exchange.OfferResponse
+	12, // 70: exchange.ExchangeService.RemoveOfferedBook:output_type -> exchange.OfferResponse
+	12, // 71: exchange.ExchangeService.AddRequestedBook:output_type -> exchange.OfferResponse
+	12, // 72: exchange.ExchangeService.RemoveRequestedBook:output_type -> exchange.OfferResponse
+	12, // 73: exchange.ExchangeService.PatchOffer:output_type -> exchange.OfferResponse
+	13, // 74: exchange.ExchangeService.ListAllOffers:output_type -> exchange.OfferList
+	13, // 75: exchange.ExchangeService.ListOffersByStatus:output_type -> exchange.OfferList
+	44, // [44:76] is the sub-list for method output_type
+	12, // [12:44] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_exchange_proto_init() }
@@ -719,7 +2548,7 @@ func file_exchange_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_exchange_proto_rawDesc), len(file_exchange_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ExchangeService_CreateOffer_FullMethodName        = "/exchange.ExchangeService/CreateOffer"
-	ExchangeService_GetOffer_FullMethodName           = "/exchange.ExchangeService/GetOffer"
-	ExchangeService_ListOffersByUser_FullMethodName   = "/exchange.ExchangeService/ListOffersByUser"
-	ExchangeService_ListPendingOffers_FullMethodName  = "/exchange.ExchangeService/ListPendingOffers"
-	ExchangeService_AcceptOffer_FullMethodName        = "/exchange.ExchangeService/AcceptOffer"
-	ExchangeService_DeclineOffer_FullMethodName       = "/exchange.ExchangeService/DeclineOffer"
-	ExchangeService_DeleteOffer_FullMethodName        = "/exchange.ExchangeService/DeleteOffer"
-	ExchangeService_UpdateOffer_FullMethodName        = "/exchange.ExchangeService/UpdateOffer"
-	ExchangeService_AddOfferedBook_FullMethodName     = "/exchange.ExchangeService/AddOfferedBook"
-	ExchangeService_RemoveOfferedBook_FullMethodName  = "/exchange.ExchangeService/RemoveOfferedBook"
-	ExchangeService_ListAllOffers_FullMethodName      = "/exchange.ExchangeService/ListAllOffers"
-	ExchangeService_ListOffersByStatus_FullMethodName = "/exchange.ExchangeService/ListOffersByStatus"
+	ExchangeService_CreateOffer_FullMethodName          = "/exchange.ExchangeService/CreateOffer"
+	ExchangeService_GetOffer_FullMethodName             = "/exchange.ExchangeService/GetOffer"
+	ExchangeService_ListOffersByUser_FullMethodName     = "/exchange.ExchangeService/ListOffersByUser"
+	ExchangeService_ListPendingOffers_FullMethodName    = "/exchange.ExchangeService/ListPendingOffers"
+	ExchangeService_AcceptOffer_FullMethodName          = "/exchange.ExchangeService/AcceptOffer"
+	ExchangeService_DeclineOffer_FullMethodName         = "/exchange.ExchangeService/DeclineOffer"
+	ExchangeService_DeleteOffer_FullMethodName          = "/exchange.ExchangeService/DeleteOffer"
+	ExchangeService_CancelOffer_FullMethodName          = "/exchange.ExchangeService/CancelOffer"
+	ExchangeService_CompleteOffer_FullMethodName        = "/exchange.ExchangeService/CompleteOffer"
+	ExchangeService_CounterOffer_FullMethodName         = "/exchange.ExchangeService/CounterOffer"
+	ExchangeService_GetNegotiation_FullMethodName       = "/exchange.ExchangeService/GetNegotiation"
+	ExchangeService_RateExchangePartner_FullMethodName  = "/exchange.ExchangeService/RateExchangePartner"
+	ExchangeService_GetUserReputation_FullMethodName    = "/exchange.ExchangeService/GetUserReputation"
+	ExchangeService_OpenDispute_FullMethodName          = "/exchange.ExchangeService/OpenDispute"
+	ExchangeService_AddDisputeEvidence_FullMethodName   = "/exchange.ExchangeService/AddDisputeEvidence"
+	ExchangeService_ResolveDispute_FullMethodName       = "/exchange.ExchangeService/ResolveDispute"
+	ExchangeService_GetDispute_FullMethodName           = "/exchange.ExchangeService/GetDispute"
+	ExchangeService_SendOfferMessage_FullMethodName     = "/exchange.ExchangeService/SendOfferMessage"
+	ExchangeService_WatchOfferMessages_FullMethodName   = "/exchange.ExchangeService/WatchOfferMessages"
+	ExchangeService_AddWant_FullMethodName              = "/exchange.ExchangeService/AddWant"
+	ExchangeService_RemoveWant_FullMethodName           = "/exchange.ExchangeService/RemoveWant"
+	ExchangeService_ListWants_FullMethodName            = "/exchange.ExchangeService/ListWants"
+	ExchangeService_FindMatches_FullMethodName          = "/exchange.ExchangeService/FindMatches"
+	ExchangeService_CreateOfferFromMatch_FullMethodName = "/exchange.ExchangeService/CreateOfferFromMatch"
+	ExchangeService_UpdateOffer_FullMethodName          = "/exchange.ExchangeService/UpdateOffer"
+	ExchangeService_AddOfferedBook_FullMethodName       = "/exchange.ExchangeService/AddOfferedBook"
+	ExchangeService_RemoveOfferedBook_FullMethodName    = "/exchange.ExchangeService/RemoveOfferedBook"
+	ExchangeService_AddRequestedBook_FullMethodName     = "/exchange.ExchangeService/AddRequestedBook"
+	ExchangeService_RemoveRequestedBook_FullMethodName  = "/exchange.ExchangeService/RemoveRequestedBook"
+	ExchangeService_PatchOffer_FullMethodName           = "/exchange.ExchangeService/PatchOffer"
+	ExchangeService_ListAllOffers_FullMethodName        = "/exchange.ExchangeService/ListAllOffers"
+	ExchangeService_ListOffersByStatus_FullMethodName   = "/exchange.ExchangeService/ListOffersByStatus"
 )
 
 // ExchangeServiceClient is the client API for ExchangeService service.
@@ -40,14 +60,34 @@ type ExchangeServiceClient interface {
 	CreateOffer(ctx context.Context, in *CreateOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
 	GetOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error)
 	ListOffersByUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*OfferList, error)
-	ListPendingOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferList, error)
+	ListPendingOffers(ctx context.Context, in *ListPendingRequest, opts ...grpc.CallOption) (*OfferList, error)
 	AcceptOffer(ctx context.Context, in *AcceptOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
 	DeclineOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error)
 	DeleteOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*Empty, error)
+	CancelOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error)
+	CompleteOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error)
+	CounterOffer(ctx context.Context, in *CounterOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
+	GetNegotiation(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferList, error)
+	RateExchangePartner(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*Rating, error)
+	GetUserReputation(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Reputation, error)
+	OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*DisputeResponse, error)
+	AddDisputeEvidence(ctx context.Context, in *DisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeResponse, error)
+	ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*DisputeResponse, error)
+	GetDispute(ctx context.Context, in *DisputeID, opts ...grpc.CallOption) (*DisputeResponse, error)
+	SendOfferMessage(ctx context.Context, in *SendOfferMessageRequest, opts ...grpc.CallOption) (*OfferMessage, error)
+	WatchOfferMessages(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OfferMessage], error)
+	AddWant(ctx context.Context, in *WantRequest, opts ...grpc.CallOption) (*WantList, error)
+	RemoveWant(ctx context.Context, in *WantRequest, opts ...grpc.CallOption) (*WantList, error)
+	ListWants(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*WantList, error)
+	FindMatches(ctx context.Context, in *FindMatchesRequest, opts ...grpc.CallOption) (*MatchList, error)
+	CreateOfferFromMatch(ctx context.Context, in *MatchOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
 	UpdateOffer(ctx context.Context, in *UpdateOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
 	AddOfferedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error)
 	RemoveOfferedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error)
-	ListAllOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferList, error)
+	AddRequestedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error)
+	RemoveRequestedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error)
+	PatchOffer(ctx context.Context, in *PatchOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error)
+	ListAllOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*OfferList, error)
 	ListOffersByStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*OfferList, error)
 }
 
@@ -89,7 +129,7 @@ func (c *exchangeServiceClient) ListOffersByUser(ctx context.Context, in *UserID
 	return out, nil
 }
 
-func (c *exchangeServiceClient) ListPendingOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferList, error) {
+func (c *exchangeServiceClient) ListPendingOffers(ctx context.Context, in *ListPendingRequest, opts ...grpc.CallOption) (*OfferList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OfferList)
 	err := c.cc.Invoke(ctx, ExchangeService_ListPendingOffers_FullMethodName, in, out, cOpts...)
@@ -129,6 +169,185 @@ func (c *exchangeServiceClient) DeleteOffer(ctx context.Context, in *OfferID, op
 	return out, nil
 }
 
+func (c *exchangeServiceClient) CancelOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CancelOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CompleteOffer(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CompleteOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CounterOffer(ctx context.Context, in *CounterOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CounterOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetNegotiation(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (*OfferList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferList)
+	err := c.cc.Invoke(ctx, ExchangeService_GetNegotiation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RateExchangePartner(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*Rating, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rating)
+	err := c.cc.Invoke(ctx, ExchangeService_RateExchangePartner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetUserReputation(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Reputation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reputation)
+	err := c.cc.Invoke(ctx, ExchangeService_GetUserReputation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*DisputeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisputeResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_OpenDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) AddDisputeEvidence(ctx context.Context, in *DisputeEvidenceRequest, opts ...grpc.CallOption) (*DisputeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisputeResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_AddDisputeEvidence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*DisputeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisputeResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ResolveDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetDispute(ctx context.Context, in *DisputeID, opts ...grpc.CallOption) (*DisputeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisputeResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_GetDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) SendOfferMessage(ctx context.Context, in *SendOfferMessageRequest, opts ...grpc.CallOption) (*OfferMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferMessage)
+	err := c.cc.Invoke(ctx, ExchangeService_SendOfferMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) WatchOfferMessages(ctx context.Context, in *OfferID, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OfferMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExchangeService_ServiceDesc.Streams[0], ExchangeService_WatchOfferMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OfferID, OfferMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExchangeService_WatchOfferMessagesClient = grpc.ServerStreamingClient[OfferMessage]
+
+func (c *exchangeServiceClient) AddWant(ctx context.Context, in *WantRequest, opts ...grpc.CallOption) (*WantList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WantList)
+	err := c.cc.Invoke(ctx, ExchangeService_AddWant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RemoveWant(ctx context.Context, in *WantRequest, opts ...grpc.CallOption) (*WantList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WantList)
+	err := c.cc.Invoke(ctx, ExchangeService_RemoveWant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListWants(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*WantList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WantList)
+	err := c.cc.Invoke(ctx, ExchangeService_ListWants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) FindMatches(ctx context.Context, in *FindMatchesRequest, opts ...grpc.CallOption) (*MatchList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchList)
+	err := c.cc.Invoke(ctx, ExchangeService_FindMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CreateOfferFromMatch(ctx context.Context, in *MatchOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CreateOfferFromMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *exchangeServiceClient) UpdateOffer(ctx context.Context, in *UpdateOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OfferResponse)
@@ -159,7 +378,37 @@ func (c *exchangeServiceClient) RemoveOfferedBook(ctx context.Context, in *BookO
 	return out, nil
 }
 
-func (c *exchangeServiceClient) ListAllOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferList, error) {
+func (c *exchangeServiceClient) AddRequestedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_AddRequestedBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RemoveRequestedBook(ctx context.Context, in *BookOpRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RemoveRequestedBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) PatchOffer(ctx context.Context, in *PatchOfferRequest, opts ...grpc.CallOption) (*OfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_PatchOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListAllOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*OfferList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OfferList)
 	err := c.cc.Invoke(ctx, ExchangeService_ListAllOffers_FullMethodName, in, out, cOpts...)
@@ -186,14 +435,34 @@ type ExchangeServiceServer interface {
 	CreateOffer(context.Context, *CreateOfferRequest) (*OfferResponse, error)
 	GetOffer(context.Context, *OfferID) (*OfferResponse, error)
 	ListOffersByUser(context.Context, *UserID) (*OfferList, error)
-	ListPendingOffers(context.Context, *Empty) (*OfferList, error)
+	ListPendingOffers(context.Context, *ListPendingRequest) (*OfferList, error)
 	AcceptOffer(context.Context, *AcceptOfferRequest) (*OfferResponse, error)
 	DeclineOffer(context.Context, *OfferID) (*OfferResponse, error)
 	DeleteOffer(context.Context, *OfferID) (*Empty, error)
+	CancelOffer(context.Context, *OfferID) (*OfferResponse, error)
+	CompleteOffer(context.Context, *OfferID) (*OfferResponse, error)
+	CounterOffer(context.Context, *CounterOfferRequest) (*OfferResponse, error)
+	GetNegotiation(context.Context, *OfferID) (*OfferList, error)
+	RateExchangePartner(context.Context, *RateRequest) (*Rating, error)
+	GetUserReputation(context.Context, *UserID) (*Reputation, error)
+	OpenDispute(context.Context, *OpenDisputeRequest) (*DisputeResponse, error)
+	AddDisputeEvidence(context.Context, *DisputeEvidenceRequest) (*DisputeResponse, error)
+	ResolveDispute(context.Context, *ResolveDisputeRequest) (*DisputeResponse, error)
+	GetDispute(context.Context, *DisputeID) (*DisputeResponse, error)
+	SendOfferMessage(context.Context, *SendOfferMessageRequest) (*OfferMessage, error)
+	WatchOfferMessages(*OfferID, grpc.ServerStreamingServer[OfferMessage]) error
+	AddWant(context.Context, *WantRequest) (*WantList, error)
+	RemoveWant(context.Context, *WantRequest) (*WantList, error)
+	ListWants(context.Context, *UserID) (*WantList, error)
+	FindMatches(context.Context, *FindMatchesRequest) (*MatchList, error)
+	CreateOfferFromMatch(context.Context, *MatchOfferRequest) (*OfferResponse, error)
 	UpdateOffer(context.Context, *UpdateOfferRequest) (*OfferResponse, error)
 	AddOfferedBook(context.Context, *BookOpRequest) (*OfferResponse, error)
 	RemoveOfferedBook(context.Context, *BookOpRequest) (*OfferResponse, error)
-	ListAllOffers(context.Context, *Empty) (*OfferList, error)
+	AddRequestedBook(context.Context, *BookOpRequest) (*OfferResponse, error)
+	RemoveRequestedBook(context.Context, *BookOpRequest) (*OfferResponse, error)
+	PatchOffer(context.Context, *PatchOfferRequest) (*OfferResponse, error)
+	ListAllOffers(context.Context, *ListOffersRequest) (*OfferList, error)
 	ListOffersByStatus(context.Context, *StatusRequest) (*OfferList, error)
 	mustEmbedUnimplementedExchangeServiceServer()
 }
@@ -214,7 +483,7 @@ func (UnimplementedExchangeServiceServer) GetOffer(context.Context, *OfferID) (*
 func (UnimplementedExchangeServiceServer) ListOffersByUser(context.Context, *UserID) (*OfferList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListOffersByUser not implemented")
 }
-func (UnimplementedExchangeServiceServer) ListPendingOffers(context.Context, *Empty) (*OfferList, error) {
+func (UnimplementedExchangeServiceServer) ListPendingOffers(context.Context, *ListPendingRequest) (*OfferList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPendingOffers not implemented")
 }
 func (UnimplementedExchangeServiceServer) AcceptOffer(context.Context, *AcceptOfferRequest) (*OfferResponse, error) {
@@ -226,6 +495,57 @@ func (UnimplementedExchangeServiceServer) DeclineOffer(context.Context, *OfferID
 func (UnimplementedExchangeServiceServer) DeleteOffer(context.Context, *OfferID) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteOffer not implemented")
 }
+func (UnimplementedExchangeServiceServer) CancelOffer(context.Context, *OfferID) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOffer not implemented")
+}
+func (UnimplementedExchangeServiceServer) CompleteOffer(context.Context, *OfferID) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteOffer not implemented")
+}
+func (UnimplementedExchangeServiceServer) CounterOffer(context.Context, *CounterOfferRequest) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CounterOffer not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetNegotiation(context.Context, *OfferID) (*OfferList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNegotiation not implemented")
+}
+func (UnimplementedExchangeServiceServer) RateExchangePartner(context.Context, *RateRequest) (*Rating, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateExchangePartner not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetUserReputation(context.Context, *UserID) (*Reputation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserReputation not implemented")
+}
+func (UnimplementedExchangeServiceServer) OpenDispute(context.Context, *OpenDisputeRequest) (*DisputeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenDispute not implemented")
+}
+func (UnimplementedExchangeServiceServer) AddDisputeEvidence(context.Context, *DisputeEvidenceRequest) (*DisputeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDisputeEvidence not implemented")
+}
+func (UnimplementedExchangeServiceServer) ResolveDispute(context.Context, *ResolveDisputeRequest) (*DisputeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDispute not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetDispute(context.Context, *DisputeID) (*DisputeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispute not implemented")
+}
+func (UnimplementedExchangeServiceServer) SendOfferMessage(context.Context, *SendOfferMessageRequest) (*OfferMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendOfferMessage not implemented")
+}
+func (UnimplementedExchangeServiceServer) WatchOfferMessages(*OfferID, grpc.ServerStreamingServer[OfferMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOfferMessages not implemented")
+}
+func (UnimplementedExchangeServiceServer) AddWant(context.Context, *WantRequest) (*WantList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWant not implemented")
+}
+func (UnimplementedExchangeServiceServer) RemoveWant(context.Context, *WantRequest) (*WantList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWant not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListWants(context.Context, *UserID) (*WantList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWants not implemented")
+}
+func (UnimplementedExchangeServiceServer) FindMatches(context.Context, *FindMatchesRequest) (*MatchList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMatches not implemented")
+}
+func (UnimplementedExchangeServiceServer) CreateOfferFromMatch(context.Context, *MatchOfferRequest) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOfferFromMatch not implemented")
+}
 func (UnimplementedExchangeServiceServer) UpdateOffer(context.Context, *UpdateOfferRequest) (*OfferResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateOffer not implemented")
 }
@@ -235,7 +555,16 @@ func (UnimplementedExchangeServiceServer) AddOfferedBook(context.Context, *BookO
 func (UnimplementedExchangeServiceServer) RemoveOfferedBook(context.Context, *BookOpRequest) (*OfferResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveOfferedBook not implemented")
 }
-func (UnimplementedExchangeServiceServer) ListAllOffers(context.Context, *Empty) (*OfferList, error) {
+func (UnimplementedExchangeServiceServer) AddRequestedBook(context.Context, *BookOpRequest) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRequestedBook not implemented")
+}
+func (UnimplementedExchangeServiceServer) RemoveRequestedBook(context.Context, *BookOpRequest) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRequestedBook not implemented")
+}
+func (UnimplementedExchangeServiceServer) PatchOffer(context.Context, *PatchOfferRequest) (*OfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchOffer not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListAllOffers(context.Context, *ListOffersRequest) (*OfferList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllOffers not implemented")
 }
 func (UnimplementedExchangeServiceServer) ListOffersByStatus(context.Context, *StatusRequest) (*OfferList, error) {
@@ -317,7 +646,7 @@ func _ExchangeService_ListOffersByUser_Handler(srv interface{}, ctx context.Cont
 }
 
 func _ExchangeService_ListPendingOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListPendingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -329,7 +658,7 @@ func _ExchangeService_ListPendingOffers_Handler(srv interface{}, ctx context.Con
 		FullMethod: ExchangeService_ListPendingOffers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).ListPendingOffers(ctx, req.(*Empty))
+		return srv.(ExchangeServiceServer).ListPendingOffers(ctx, req.(*ListPendingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -388,6 +717,305 @@ func _ExchangeService_DeleteOffer_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExchangeService_CancelOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CancelOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CancelOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CancelOffer(ctx, req.(*OfferID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_CompleteOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CompleteOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CompleteOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CompleteOffer(ctx, req.(*OfferID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_CounterOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CounterOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CounterOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CounterOffer(ctx, req.(*CounterOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetNegotiation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetNegotiation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetNegotiation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetNegotiation(ctx, req.(*OfferID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RateExchangePartner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RateExchangePartner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RateExchangePartner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RateExchangePartner(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetUserReputation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetUserReputation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetUserReputation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetUserReputation(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_OpenDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).OpenDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_OpenDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).OpenDispute(ctx, req.(*OpenDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_AddDisputeEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).AddDisputeEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_AddDisputeEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).AddDisputeEvidence(ctx, req.(*DisputeEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ResolveDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ResolveDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ResolveDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ResolveDispute(ctx, req.(*ResolveDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetDispute(ctx, req.(*DisputeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_SendOfferMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOfferMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).SendOfferMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_SendOfferMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).SendOfferMessage(ctx, req.(*SendOfferMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_WatchOfferMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OfferID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExchangeServiceServer).WatchOfferMessages(m, &grpc.GenericServerStream[OfferID, OfferMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExchangeService_WatchOfferMessagesServer = grpc.ServerStreamingServer[OfferMessage]
+
+func _ExchangeService_AddWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).AddWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_AddWant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).AddWant(ctx, req.(*WantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RemoveWant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RemoveWant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RemoveWant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RemoveWant(ctx, req.(*WantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListWants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListWants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListWants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListWants(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_FindMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).FindMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_FindMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).FindMatches(ctx, req.(*FindMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_CreateOfferFromMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CreateOfferFromMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CreateOfferFromMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CreateOfferFromMatch(ctx, req.(*MatchOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExchangeService_UpdateOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateOfferRequest)
 	if err := dec(in); err != nil {
@@ -442,8 +1070,62 @@ func _ExchangeService_RemoveOfferedBook_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExchangeService_AddRequestedBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookOpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).AddRequestedBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_AddRequestedBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).AddRequestedBook(ctx, req.(*BookOpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RemoveRequestedBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookOpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RemoveRequestedBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RemoveRequestedBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RemoveRequestedBook(ctx, req.(*BookOpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_PatchOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).PatchOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_PatchOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).PatchOffer(ctx, req.(*PatchOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExchangeService_ListAllOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListOffersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -455,7 +1137,7 @@ func _ExchangeService_ListAllOffers_Handler(srv interface{}, ctx context.Context
 		FullMethod: ExchangeService_ListAllOffers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).ListAllOffers(ctx, req.(*Empty))
+		return srv.(ExchangeServiceServer).ListAllOffers(ctx, req.(*ListOffersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -513,6 +1195,70 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteOffer",
 			Handler:    _ExchangeService_DeleteOffer_Handler,
 		},
+		{
+			MethodName: "CancelOffer",
+			Handler:    _ExchangeService_CancelOffer_Handler,
+		},
+		{
+			MethodName: "CompleteOffer",
+			Handler:    _ExchangeService_CompleteOffer_Handler,
+		},
+		{
+			MethodName: "CounterOffer",
+			Handler:    _ExchangeService_CounterOffer_Handler,
+		},
+		{
+			MethodName: "GetNegotiation",
+			Handler:    _ExchangeService_GetNegotiation_Handler,
+		},
+		{
+			MethodName: "RateExchangePartner",
+			Handler:    _ExchangeService_RateExchangePartner_Handler,
+		},
+		{
+			MethodName: "GetUserReputation",
+			Handler:    _ExchangeService_GetUserReputation_Handler,
+		},
+		{
+			MethodName: "OpenDispute",
+			Handler:    _ExchangeService_OpenDispute_Handler,
+		},
+		{
+			MethodName: "AddDisputeEvidence",
+			Handler:    _ExchangeService_AddDisputeEvidence_Handler,
+		},
+		{
+			MethodName: "ResolveDispute",
+			Handler:    _ExchangeService_ResolveDispute_Handler,
+		},
+		{
+			MethodName: "GetDispute",
+			Handler:    _ExchangeService_GetDispute_Handler,
+		},
+		{
+			MethodName: "SendOfferMessage",
+			Handler:    _ExchangeService_SendOfferMessage_Handler,
+		},
+		{
+			MethodName: "AddWant",
+			Handler:    _ExchangeService_AddWant_Handler,
+		},
+		{
+			MethodName: "RemoveWant",
+			Handler:    _ExchangeService_RemoveWant_Handler,
+		},
+		{
+			MethodName: "ListWants",
+			Handler:    _ExchangeService_ListWants_Handler,
+		},
+		{
+			MethodName: "FindMatches",
+			Handler:    _ExchangeService_FindMatches_Handler,
+		},
+		{
+			MethodName: "CreateOfferFromMatch",
+			Handler:    _ExchangeService_CreateOfferFromMatch_Handler,
+		},
 		{
 			MethodName: "UpdateOffer",
 			Handler:    _ExchangeService_UpdateOffer_Handler,
@@ -525,6 +1271,18 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveOfferedBook",
 			Handler:    _ExchangeService_RemoveOfferedBook_Handler,
 		},
+		{
+			MethodName: "AddRequestedBook",
+			Handler:    _ExchangeService_AddRequestedBook_Handler,
+		},
+		{
+			MethodName: "RemoveRequestedBook",
+			Handler:    _ExchangeService_RemoveRequestedBook_Handler,
+		},
+		{
+			MethodName: "PatchOffer",
+			Handler:    _ExchangeService_PatchOffer_Handler,
+		},
 		{
 			MethodName: "ListAllOffers",
 			Handler:    _ExchangeService_ListAllOffers_Handler,
@@ -534,6 +1292,12 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ExchangeService_ListOffersByStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOfferMessages",
+			Handler:       _ExchangeService_WatchOfferMessages_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "exchange.proto",
 }
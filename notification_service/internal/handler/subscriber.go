@@ -2,127 +2,42 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"errors"
 
-	"github.com/OshakbayAigerim/read_space/notification_service/internal/domain" // <- сюда
 	"github.com/OshakbayAigerim/read_space/notification_service/internal/usecase"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
 	"github.com/nats-io/nats.go"
 )
 
 func SubscribeAll(nc *nats.Conn, notifier *usecase.Notifier) error {
-	if _, err := nc.Subscribe("orders.created", func(m *nats.Msg) {
-		var evt domain.OrderCreatedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf(" unmarshal orders.created: %v", err)
-			return
-		}
-		notifier.SendOrderConfirmation(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("user.created", func(m *nats.Msg) {
-		var evt domain.UserCreatedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf("unmarshal user.created: %v", err)
-			return
-		}
-		notifier.SendWelcome(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("order.completed", func(m *nats.Msg) {
-		var evt domain.OrderCompletedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf("unmarshal order.completed: %v", err)
-			return
-		}
-		notifier.SendOrderCompleted(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("order.deleted", func(m *nats.Msg) {
-		var evt domain.OrderDeletedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf("unmarshal order.deleted: %v", err)
-			return
-		}
-		notifier.SendOrderDeleted(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("exchange.offered", func(m *nats.Msg) {
-		var evt domain.OfferCreatedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf(" unmarshal exchange.offered: %v", err)
-			return
-		}
-		notifier.SendOfferCreated(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("exchange.accepted", func(m *nats.Msg) {
-		var evt domain.OfferAcceptedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf(" unmarshal exchange.accepted: %v", err)
-			return
-		}
-		notifier.SendOfferAccepted(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("exchange.declined", func(m *nats.Msg) {
-		var evt domain.OfferDeclinedEvent
-		if err := json.Unmarshal(m.Data, &evt); err != nil {
-			log.Printf("unmarshal exchange.declined: %v", err)
-			return
-		}
-		notifier.SendOfferDeclined(context.Background(), evt)
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("userlibrary.book.assigned", func(m *nats.Msg) {
-		var evt domain.BookAssignedEvent
-		if err := json.Unmarshal(m.Data, &evt); err == nil {
-			notifier.SendBookAssigned(context.Background(), evt)
-		}
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("userlibrary.book.unassigned", func(m *nats.Msg) {
-		var evt domain.BookUnassignedEvent
-		if err := json.Unmarshal(m.Data, &evt); err == nil {
-			notifier.SendBookUnassigned(context.Background(), evt)
-		}
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("userlibrary.entry.deleted", func(m *nats.Msg) {
-		var evt domain.EntryDeletedEvent
-		if err := json.Unmarshal(m.Data, &evt); err == nil {
-			notifier.SendEntryDeleted(context.Background(), evt)
-		}
-	}); err != nil {
-		return err
-	}
-
-	if _, err := nc.Subscribe("userlibrary.entry.updated", func(m *nats.Msg) {
-		var evt domain.EntryUpdatedEvent
-		if err := json.Unmarshal(m.Data, &evt); err == nil {
-			notifier.SendEntryUpdated(context.Background(), evt)
-		}
-	}); err != nil {
-		return err
-	}
-
-	return nil
+	ctx := context.Background()
+	var errs []error
+	sub := func(_ *nats.Subscription, err error) { errs = append(errs, err) }
+
+	sub(events.Subscribe(nc, events.UserCreated, func(e events.UserCreatedEvent) { notifier.SendWelcome(ctx, e) }))
+
+	sub(events.Subscribe(nc, events.OrderCreated, func(e events.OrderEvent) { notifier.SendOrderConfirmation(ctx, e) }))
+	sub(events.Subscribe(nc, events.OrderUpdated, func(e events.OrderEvent) { notifier.SendOrderUpdated(ctx, e) }))
+	sub(events.Subscribe(nc, events.OrderCancelled, func(e events.OrderEvent) { notifier.SendOrderCancelled(ctx, e) }))
+	sub(events.Subscribe(nc, events.OrderCompleted, func(e events.OrderEvent) { notifier.SendOrderCompleted(ctx, e) }))
+	sub(events.Subscribe(nc, events.OrderDeleted, func(e events.OrderEvent) { notifier.SendOrderDeleted(ctx, e) }))
+
+	sub(events.Subscribe(nc, events.ExchangeOffered, func(e events.OfferEvent) { notifier.SendOfferCreated(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeUpdated, func(e events.OfferEvent) { notifier.SendOfferUpdated(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeAccepted, func(e events.OfferEvent) { notifier.SendOfferAccepted(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeDeclined, func(e events.OfferEvent) { notifier.SendOfferDeclined(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeCancelled, func(e events.OfferEvent) { notifier.SendOfferCancelled(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeCountered, func(e events.OfferEvent) { notifier.SendOfferCountered(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeExpired, func(e events.OfferEvent) { notifier.SendOfferExpired(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeCompleted, func(e events.OfferEvent) { notifier.SendOfferCompleted(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeDisputeOpened, func(e events.DisputeEvent) { notifier.SendDisputeOpened(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeDisputeEvidence, func(e events.DisputeEvent) { notifier.SendDisputeEvidence(ctx, e) }))
+	sub(events.Subscribe(nc, events.ExchangeDisputeResolved, func(e events.DisputeEvent) { notifier.SendDisputeResolved(ctx, e) }))
+
+	sub(events.Subscribe(nc, events.LibraryBookAssigned, func(e events.LibraryBookEvent) { notifier.SendBookAssigned(ctx, e) }))
+	sub(events.Subscribe(nc, events.LibraryBookUnassigned, func(e events.LibraryBookEvent) { notifier.SendBookUnassigned(ctx, e) }))
+	sub(events.Subscribe(nc, events.LibraryEntryDeleted, func(e events.LibraryEntryEvent) { notifier.SendEntryDeleted(ctx, e) }))
+	sub(events.Subscribe(nc, events.LibraryEntryUpdated, func(e events.LibraryEntryEvent) { notifier.SendEntryUpdated(ctx, e) }))
+
+	return errors.Join(errs...)
 }
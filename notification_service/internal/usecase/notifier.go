@@ -4,8 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
-	"github.com/OshakbayAigerim/read_space/notification_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
 	userpb "github.com/OshakbayAigerim/read_space/user_service/proto"
 )
 
@@ -28,7 +29,7 @@ func (n *Notifier) getEmail(ctx context.Context, userID string) (string, error)
 	return resp.User.Email, nil
 }
 
-func (n *Notifier) SendOrderConfirmation(ctx context.Context, evt domain.OrderCreatedEvent) {
+func (n *Notifier) SendOrderConfirmation(ctx context.Context, evt events.OrderEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf(" cannot fetch email for %s: %v", evt.UserID, err)
@@ -40,14 +41,38 @@ func (n *Notifier) SendOrderConfirmation(ctx context.Context, evt domain.OrderCr
 	log.Printf(" Email sent to %s", email)
 }
 
-func (n *Notifier) SendWelcome(ctx context.Context, evt domain.UserCreatedEvent) {
+func (n *Notifier) SendWelcome(ctx context.Context, evt events.UserCreatedEvent) {
 	subject := "Добро пожаловать в ReadSpace!"
 	body := fmt.Sprintf("Привет, %s!\n\nСпасибо за регистрацию.", evt.Name)
 	n.sendEmail(evt.Email, subject, body)
 	log.Printf(" Welcome email sent to %s", evt.Email)
 }
 
-func (n *Notifier) SendOrderCompleted(ctx context.Context, evt domain.OrderCompletedEvent) {
+func (n *Notifier) SendOrderUpdated(ctx context.Context, evt events.OrderEvent) {
+	email, err := n.getEmail(ctx, evt.UserID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.UserID, err)
+		return
+	}
+	subject := "Ваш заказ изменён"
+	body := fmt.Sprintf("Заказ %s изменён, книг в заказе: %d.", evt.OrderID, len(evt.BookIDs))
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOrderCancelled(ctx context.Context, evt events.OrderEvent) {
+	email, err := n.getEmail(ctx, evt.UserID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.UserID, err)
+		return
+	}
+	subject := "Ваш заказ отменён"
+	body := fmt.Sprintf("Заказ %s был отменён.", evt.OrderID)
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOrderCompleted(ctx context.Context, evt events.OrderEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf(" cannot fetch email for %s: %v", evt.UserID, err)
@@ -59,7 +84,7 @@ func (n *Notifier) SendOrderCompleted(ctx context.Context, evt domain.OrderCompl
 	log.Printf("Email sent to %s", email)
 }
 
-func (n *Notifier) SendOrderDeleted(ctx context.Context, evt domain.OrderDeletedEvent) {
+func (n *Notifier) SendOrderDeleted(ctx context.Context, evt events.OrderEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf(" cannot fetch email for %s: %v", evt.UserID, err)
@@ -71,19 +96,32 @@ func (n *Notifier) SendOrderDeleted(ctx context.Context, evt domain.OrderDeleted
 	log.Printf(" Email sent to %s", email)
 }
 
-func (n *Notifier) SendOfferCreated(ctx context.Context, evt domain.OfferCreatedEvent) {
-	email, err := n.getEmail(ctx, evt.OwnerID)
+func (n *Notifier) SendOfferCreated(ctx context.Context, evt events.OfferEvent) {
+	email, err := n.getEmail(ctx, evt.CounterpartyID)
 	if err != nil {
-		log.Printf(" cannot fetch email for %s: %v", evt.OwnerID, err)
+		log.Printf(" cannot fetch email for %s: %v", evt.CounterpartyID, err)
 		return
 	}
 	subject := "Поступило новое предложение обмена"
-	body := fmt.Sprintf("Ваше предложение %s создано.", evt.OfferID)
+	body := fmt.Sprintf("Пользователь %s предлагает вам обмен %s.", evt.OwnerID, evt.OfferID)
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOfferUpdated(ctx context.Context, evt events.OfferEvent) {
+	email, err := n.getEmail(ctx, evt.CounterpartyID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.CounterpartyID, err)
+		return
+	}
+	subject := "Предложение обмена изменено"
+	body := fmt.Sprintf("Пользователь %s изменил предложение обмена %s (редакция %d): %s.",
+		evt.OwnerID, evt.OfferID, evt.Revision, strings.Join(evt.Changed, ", "))
 	n.sendEmail(email, subject, body)
 	log.Printf(" Email sent to %s", email)
 }
 
-func (n *Notifier) SendOfferDeclined(ctx context.Context, evt domain.OfferDeclinedEvent) {
+func (n *Notifier) SendOfferDeclined(ctx context.Context, evt events.OfferEvent) {
 	email, err := n.getEmail(ctx, evt.OwnerID)
 	if err != nil {
 		log.Printf(" cannot fetch email for %s: %v", evt.OwnerID, err)
@@ -94,19 +132,61 @@ func (n *Notifier) SendOfferDeclined(ctx context.Context, evt domain.OfferDeclin
 	n.sendEmail(email, subject, body)
 	log.Printf(" Email sent to %s", email)
 }
-func (n *Notifier) SendOfferAccepted(ctx context.Context, evt domain.OfferAcceptedEvent) {
+func (n *Notifier) SendOfferCancelled(ctx context.Context, evt events.OfferEvent) {
+	email, err := n.getEmail(ctx, evt.CounterpartyID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.CounterpartyID, err)
+		return
+	}
+	subject := "Предложение обмена отозвано"
+	body := fmt.Sprintf("Пользователь %s отозвал предложение %s.", evt.OwnerID, evt.OfferID)
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOfferExpired(ctx context.Context, evt events.OfferEvent) {
+	email, err := n.getEmail(ctx, evt.OwnerID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.OwnerID, err)
+		return
+	}
+	subject := "Срок предложения обмена истёк"
+	body := fmt.Sprintf("Предложение %s не было принято вовремя и закрыто.", evt.OfferID)
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOfferAccepted(ctx context.Context, evt events.OfferEvent) {
 	email, err := n.getEmail(ctx, evt.OwnerID)
 	if err != nil {
 		log.Printf("cannot fetch email for %s: %v", evt.OwnerID, err)
 		return
 	}
 	subject := "Ваше предложение обмена принято"
-	body := fmt.Sprintf("Предложение %s принято пользователем %s.", evt.OfferID, evt.Counterparty)
+	body := fmt.Sprintf("Предложение %s принято пользователем %s.", evt.OfferID, evt.CounterpartyID)
+	n.sendEmail(email, subject, body)
+	log.Printf(" Email sent to %s", email)
+}
+
+func (n *Notifier) SendOfferCountered(ctx context.Context, evt events.OfferEvent) {
+	email, err := n.getEmail(ctx, evt.OwnerID)
+	if err != nil {
+		log.Printf(" cannot fetch email for %s: %v", evt.OwnerID, err)
+		return
+	}
+	subject := "На ваше предложение обмена ответили встречным"
+	body := fmt.Sprintf("Пользователь %s ответил встречным предложением на %s; исходное предложение закрыто.", evt.CounterpartyID, evt.OfferID)
 	n.sendEmail(email, subject, body)
 	log.Printf(" Email sent to %s", email)
 }
 
-func (n *Notifier) SendBookAssigned(ctx context.Context, evt domain.BookAssignedEvent) {
+func (n *Notifier) SendOfferCompleted(ctx context.Context, evt events.OfferEvent) {
+	subject := "Обмен завершён"
+	body := fmt.Sprintf("Обмен %s завершён, книги переданы новым владельцам.", evt.OfferID)
+	n.emailParties(ctx, subject, body, evt.OwnerID, evt.CounterpartyID)
+}
+
+func (n *Notifier) SendBookAssigned(ctx context.Context, evt events.LibraryBookEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf("cannot fetch email for %s: %v", evt.UserID, err)
@@ -117,7 +197,7 @@ func (n *Notifier) SendBookAssigned(ctx context.Context, evt domain.BookAssigned
 	n.sendEmail(email, subject, body)
 }
 
-func (n *Notifier) SendBookUnassigned(ctx context.Context, evt domain.BookUnassignedEvent) {
+func (n *Notifier) SendBookUnassigned(ctx context.Context, evt events.LibraryBookEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf("cannot fetch email for %s: %v", evt.UserID, err)
@@ -128,7 +208,7 @@ func (n *Notifier) SendBookUnassigned(ctx context.Context, evt domain.BookUnassi
 	n.sendEmail(email, subject, body)
 }
 
-func (n *Notifier) SendEntryDeleted(ctx context.Context, evt domain.EntryDeletedEvent) {
+func (n *Notifier) SendEntryDeleted(ctx context.Context, evt events.LibraryEntryEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf("cannot fetch email for %s: %v", evt.UserID, err)
@@ -139,7 +219,7 @@ func (n *Notifier) SendEntryDeleted(ctx context.Context, evt domain.EntryDeleted
 	n.sendEmail(email, subject, body)
 }
 
-func (n *Notifier) SendEntryUpdated(ctx context.Context, evt domain.EntryUpdatedEvent) {
+func (n *Notifier) SendEntryUpdated(ctx context.Context, evt events.LibraryEntryEvent) {
 	email, err := n.getEmail(ctx, evt.UserID)
 	if err != nil {
 		log.Printf("cannot fetch email for %s: %v", evt.UserID, err)
@@ -149,3 +229,38 @@ func (n *Notifier) SendEntryUpdated(ctx context.Context, evt domain.EntryUpdated
 	body := fmt.Sprintf("Your library entry %s was updated (new book %s).", evt.EntryID, evt.BookID)
 	n.sendEmail(email, subject, body)
 }
+
+func (n *Notifier) SendDisputeOpened(ctx context.Context, evt events.DisputeEvent) {
+	subject := "Открыт спор по обмену"
+	body := fmt.Sprintf("Пользователь %s открыл спор %s по обмену %s: %s", evt.ActorID, evt.DisputeID, evt.OfferID, evt.Note)
+	n.emailParties(ctx, subject, body, evt.OwnerID, evt.CounterpartyID)
+}
+
+func (n *Notifier) SendDisputeEvidence(ctx context.Context, evt events.DisputeEvent) {
+	subject := "Новые материалы по спору"
+	body := fmt.Sprintf("Пользователь %s добавил материалы к спору %s: %s", evt.ActorID, evt.DisputeID, evt.Note)
+	n.emailParties(ctx, subject, body, evt.OwnerID, evt.CounterpartyID)
+}
+
+func (n *Notifier) SendDisputeResolved(ctx context.Context, evt events.DisputeEvent) {
+	outcome := "обмен остаётся в силе"
+	if evt.Reversed {
+		outcome = "книги возвращены прежним владельцам"
+	}
+	subject := "Спор по обмену решён"
+	body := fmt.Sprintf("Спор %s по обмену %s решён: %s. %s", evt.DisputeID, evt.OfferID, outcome, evt.Note)
+	n.emailParties(ctx, subject, body, evt.OwnerID, evt.CounterpartyID)
+}
+
+// emailParties sends the same message to both sides of an offer.
+func (n *Notifier) emailParties(ctx context.Context, subject, body string, userIDs ...string) {
+	for _, userID := range userIDs {
+		email, err := n.getEmail(ctx, userID)
+		if err != nil {
+			log.Printf(" cannot fetch email for %s: %v", userID, err)
+			continue
+		}
+		n.sendEmail(email, subject, body)
+		log.Printf(" Email sent to %s", email)
+	}
+}
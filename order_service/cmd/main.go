@@ -8,9 +8,11 @@ import (
 	"github.com/OshakbayAigerim/read_space/order_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/config"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/handler"
+	"github.com/OshakbayAigerim/read_space/order_service/internal/migrations"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/repository"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/usecase"
 	pb "github.com/OshakbayAigerim/read_space/order_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -19,6 +21,7 @@ import (
 func main() {
 	client := config.ConnectMongo()
 	db := client.Database("readspace")
+	migrations.CreateOrderIndexes(db)
 
 	redisClient := config.ConnectRedis()
 	defer redisClient.Close()
@@ -45,7 +48,8 @@ func main() {
 	if err != nil {
 		log.Fatalf(" failed to listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+	grpcServer := grpc.NewServer(auth.ServerOptions(tokens)...)
 	pb.RegisterOrderServiceServer(grpcServer, h)
 
 	log.Println("OrderService started on :50053")
@@ -1,12 +1,33 @@
 package domain
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
 
 type Order struct {
-	ID        primitive.ObjectID
-	UserID    primitive.ObjectID
-	BookIDs   []primitive.ObjectID
-	Status    string
-	CreatedAt primitive.DateTime
-	UpdatedAt primitive.DateTime
+	ID        primitive.ObjectID   `bson:"_id"`
+	UserID    primitive.ObjectID   `bson:"user_id"`
+	BookIDs   []primitive.ObjectID `bson:"book_ids"`
+	Status    string               `bson:"status"`
+	CreatedAt primitive.DateTime   `bson:"created_at"`
+	UpdatedAt primitive.DateTime   `bson:"updated_at"`
+}
+
+// OrderSortFields are the sort orders ListAll accepts.
+var OrderSortFields = paging.Fields{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// OrderFilter narrows ListAll; zero fields match everything. The created
+// range is [After, Before).
+type OrderFilter struct {
+	Status        string
+	UserID        primitive.ObjectID
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 }
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"time"
 
 	"github.com/OshakbayAigerim/read_space/order_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/usecase"
 	pb "github.com/OshakbayAigerim/read_space/order_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/nats-io/nats.go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/grpc/codes"
@@ -25,8 +27,17 @@ func NewOrderHandler(u usecase.OrderUseCase, nc *nats.Conn) *OrderHandler {
 }
 
 func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.OrderResponse, error) {
-	if req == nil || req.UserId == "" || len(req.BookIds) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "user_id and book_ids are required")
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || len(req.BookIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "book_ids are required")
+	}
+	if req.UserId == "" {
+		req.UserId = caller
+	} else if req.UserId != caller {
+		return nil, status.Error(codes.PermissionDenied, "cannot create an order for another user")
 	}
 
 	uid, err := primitive.ObjectIDFromHex(req.UserId)
@@ -53,20 +64,7 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 		return nil, status.Errorf(codes.Internal, "cannot create order: %v", err)
 	}
 
-	evt := struct {
-		OrderID string   `json:"order_id"`
-		UserID  string   `json:"user_id"`
-		BookIDs []string `json:"book_ids"`
-	}{
-		OrderID: created.ID.Hex(),
-		UserID:  created.UserID.Hex(),
-		BookIDs: req.BookIds,
-	}
-	if raw, err := json.Marshal(evt); err == nil {
-		if err := h.nc.Publish("orders.created", raw); err != nil {
-			log.Printf("⚠️ publish orders.created: %v", err)
-		}
-	}
+	events.Emit(h.nc, events.OrderCreated, orderEvent(created))
 
 	return &pb.OrderResponse{Order: mapDomain(created)}, nil
 }
@@ -101,6 +99,7 @@ func (h *OrderHandler) CancelOrder(ctx context.Context, req *pb.OrderID) (*pb.Or
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot cancel order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderCancelled, orderEvent(o))
 	return &pb.OrderResponse{Order: mapDomain(o)}, nil
 }
 
@@ -112,6 +111,7 @@ func (h *OrderHandler) ReturnBook(ctx context.Context, req *pb.OrderID) (*pb.Ord
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot return order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderCompleted, orderEvent(o))
 	return &pb.OrderResponse{Order: mapDomain(o)}, nil
 }
 
@@ -119,9 +119,14 @@ func (h *OrderHandler) DeleteOrder(ctx context.Context, req *pb.OrderID) (*pb.Em
 	if req == nil || req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "order id is required")
 	}
+	o, err := h.uc.GetOrderByID(ctx, req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "order not found: %v", err)
+	}
 	if err := h.uc.DeleteOrder(ctx, req.Id); err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot delete order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderDeleted, orderEvent(o))
 	return &pb.Empty{}, nil
 }
 
@@ -156,6 +161,7 @@ func (h *OrderHandler) UpdateOrder(ctx context.Context, req *pb.UpdateOrderReque
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot update order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderUpdated, orderEvent(updated))
 	return &pb.OrderResponse{Order: mapDomain(updated)}, nil
 }
 
@@ -167,6 +173,7 @@ func (h *OrderHandler) AddBookToOrder(ctx context.Context, req *pb.BookOperation
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot add book to order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderUpdated, orderEvent(o))
 	return &pb.OrderResponse{Order: mapDomain(o)}, nil
 }
 
@@ -178,15 +185,45 @@ func (h *OrderHandler) RemoveBookFromOrder(ctx context.Context, req *pb.BookOper
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot remove book from order: %v", err)
 	}
+	events.Emit(h.nc, events.OrderUpdated, orderEvent(o))
 	return &pb.OrderResponse{Order: mapDomain(o)}, nil
 }
 
-func (h *OrderHandler) ListAllOrders(ctx context.Context, _ *pb.Empty) (*pb.OrderList, error) {
-	all, err := h.uc.ListAll(ctx)
+func (h *OrderHandler) ListAllOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.OrderList, error) {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.OrderSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f := domain.OrderFilter{Status: req.GetStatus()}
+	if req.GetUserId() != "" {
+		if f.UserID, err = primitive.ObjectIDFromHex(req.UserId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+	}
+	if f.CreatedAfter, err = parseBound("created_after", req.GetCreatedAfter()); err != nil {
+		return nil, err
+	}
+	if f.CreatedBefore, err = parseBound("created_before", req.GetCreatedBefore()); err != nil {
+		return nil, err
+	}
+
+	all, next, err := h.uc.ListAll(ctx, f, q)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot list all orders: %v", err)
 	}
-	return &pb.OrderList{Orders: mapDomainList(all)}, nil
+	return &pb.OrderList{Orders: mapDomainList(all), NextPageToken: next}, nil
+}
+
+// parseBound parses an optional RFC 3339 range bound.
+func parseBound(name, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 timestamp", name)
+	}
+	return t, nil
 }
 
 func (h *OrderHandler) ListOrdersByStatus(ctx context.Context, req *pb.StatusRequest) (*pb.OrderList, error) {
@@ -215,6 +252,15 @@ func mapDomain(o *domain.Order) *pb.Order {
 	}
 }
 
+func orderEvent(o *domain.Order) events.OrderEvent {
+	return events.OrderEvent{
+		OrderID: o.ID.Hex(),
+		UserID:  o.UserID.Hex(),
+		BookIDs: mapDomain(o).BookIds,
+		Status:  o.Status,
+	}
+}
+
 func mapDomainList(list []*domain.Order) []*pb.Order {
 	var out []*pb.Order
 	for _, o := range list {
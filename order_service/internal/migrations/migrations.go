@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateOrderIndexes backs the keyset pagination of ListAllOrders: every sort
+// order ends in _id, and the filters are indexed ahead of the default order.
+func CreateOrderIndexes(db *mongo.Database) {
+	collection := db.Collection("orders")
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for orders collection")
+}
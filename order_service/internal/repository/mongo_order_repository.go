@@ -6,6 +6,7 @@ import (
 
 	"github.com/OshakbayAigerim/read_space/order_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -202,22 +203,37 @@ func (r *mongoOrderRepo) RemoveBook(ctx context.Context, orderID, bookID string)
 	return &updated, nil
 }
 
-func (r *mongoOrderRepo) ListAll(ctx context.Context) ([]*domain.Order, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+func (r *mongoOrderRepo) ListAll(ctx context.Context, f domain.OrderFilter, q *paging.Query) ([]*domain.Order, string, error) {
+	filter := bson.M{}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if !f.UserID.IsZero() {
+		filter["user_id"] = f.UserID
+	}
+	created := bson.M{}
+	if !f.CreatedAfter.IsZero() {
+		created["$gte"] = primitive.NewDateTimeFromTime(f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		created["$lt"] = primitive.NewDateTimeFromTime(f.CreatedBefore)
+	}
+	if len(created) > 0 {
+		filter["created_at"] = created
+	}
+
+	filter, opts := q.Find(filter)
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer cursor.Close(ctx)
 
 	var orders []*domain.Order
-	for cursor.Next(ctx) {
-		var o domain.Order
-		if err := cursor.Decode(&o); err != nil {
-			return nil, err
-		}
-		orders = append(orders, &o)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, "", err
 	}
-	return orders, nil
+	return paging.Page(q, orders)
 }
 
 func (r *mongoOrderRepo) ListByStatus(ctx context.Context, status string) ([]*domain.Order, error) {
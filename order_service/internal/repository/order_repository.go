@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/OshakbayAigerim/read_space/order_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type OrderRepository interface {
@@ -15,7 +16,9 @@ type OrderRepository interface {
 	Update(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	AddBook(ctx context.Context, orderID, bookID string) (*domain.Order, error)
 	RemoveBook(ctx context.Context, orderID, bookID string) (*domain.Order, error)
-	ListAll(ctx context.Context) ([]*domain.Order, error)
+	// ListAll returns one page of the orders matching f and the token of the
+	// next page.
+	ListAll(ctx context.Context, f domain.OrderFilter, q *paging.Query) ([]*domain.Order, string, error)
 	ListByStatus(ctx context.Context, status string) ([]*domain.Order, error)
 	Delete(ctx context.Context, id string) error
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/OshakbayAigerim/read_space/order_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/order_service/internal/repository"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 )
 
 type OrderUseCase interface {
@@ -17,7 +18,7 @@ type OrderUseCase interface {
 	UpdateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	AddBook(ctx context.Context, orderID, bookID string) (*domain.Order, error)
 	RemoveBook(ctx context.Context, orderID, bookID string) (*domain.Order, error)
-	ListAll(ctx context.Context) ([]*domain.Order, error)
+	ListAll(ctx context.Context, f domain.OrderFilter, q *paging.Query) ([]*domain.Order, string, error)
 	ListByStatus(ctx context.Context, status string) ([]*domain.Order, error)
 }
 
@@ -65,8 +66,8 @@ func (u *orderUseCase) RemoveBook(ctx context.Context, orderID, bookID string) (
 	return u.repo.RemoveBook(ctx, orderID, bookID)
 }
 
-func (u *orderUseCase) ListAll(ctx context.Context) ([]*domain.Order, error) {
-	return u.repo.ListAll(ctx)
+func (u *orderUseCase) ListAll(ctx context.Context, f domain.OrderFilter, q *paging.Query) ([]*domain.Order, string, error) {
+	return u.repo.ListAll(ctx, f, q)
 }
 
 func (u *orderUseCase) ListByStatus(ctx context.Context, status string) ([]*domain.Order, error) {
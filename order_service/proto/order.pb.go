@@ -430,8 +430,10 @@ func (x *ListOrdersByUserRequest) GetUserId() string {
 }
 
 type OrderList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Only set by ListAllOrders; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -473,6 +475,108 @@ func (x *OrderList) GetOrders() []*Order {
 	return nil
 }
 
+func (x *OrderList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ListOrdersRequest pages through all orders. sort is created_at or
+// updated_at, prefixed with "-" for descending order; the default is
+// creation order. created_after/created_before (RFC 3339) bound created_at.
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAfter  string                 `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore string                 `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -481,7 +585,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_order_proto_msgTypes[9]
+	mi := &file_order_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -493,7 +597,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[9]
+	mi := &file_order_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +610,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{9}
+	return file_order_proto_rawDescGZIP(), []int{10}
 }
 
 var File_order_proto protoreflect.FileDescriptor
@@ -538,10 +642,20 @@ const file_order_proto_rawDesc = "" +
 	"\aOrderID\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
 	"\x17ListOrdersByUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"1\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Y\n" +
 	"\tOrderList\x12$\n" +
-	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\"\a\n" +
-	"\x05Empty2\x9a\x05\n" +
+	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe0\x01\n" +
+	"\x11ListOrdersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12#\n" +
+	"\rcreated_after\x18\x06 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\a \x01(\tR\rcreatedBefore\"\a\n" +
+	"\x05Empty2\xa6\x05\n" +
 	"\fOrderService\x12>\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\x120\n" +
 	"\bGetOrder\x12\x0e.order.OrderID\x1a\x14.order.OrderResponse\x12D\n" +
@@ -552,8 +666,8 @@ const file_order_proto_rawDesc = "" +
 	"\vDeleteOrder\x12\x0e.order.OrderID\x1a\f.order.Empty\x12>\n" +
 	"\vUpdateOrder\x12\x19.order.UpdateOrderRequest\x1a\x14.order.OrderResponse\x12C\n" +
 	"\x0eAddBookToOrder\x12\x1b.order.BookOperationRequest\x1a\x14.order.OrderResponse\x12H\n" +
-	"\x13RemoveBookFromOrder\x12\x1b.order.BookOperationRequest\x1a\x14.order.OrderResponse\x12/\n" +
-	"\rListAllOrders\x12\f.order.Empty\x1a\x10.order.OrderList\x12<\n" +
+	"\x13RemoveBookFromOrder\x12\x1b.order.BookOperationRequest\x1a\x14.order.OrderResponse\x12;\n" +
+	"\rListAllOrders\x12\x18.order.ListOrdersRequest\x1a\x10.order.OrderList\x12<\n" +
 	"\x12ListOrdersByStatus\x12\x14.order.StatusRequest\x1a\x10.order.OrderListBJZHgithub.com/OshakbayAigerim/readspace/order_service/proto/orderpb;orderpbb\x06proto3"
 
 var (
@@ -568,7 +682,7 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_order_proto_goTypes = []any{
 	(*Order)(nil),                   // 0: order.Order
 	(*CreateOrderRequest)(nil),      // 1: order.CreateOrderRequest
@@ -579,7 +693,8 @@ var file_order_proto_goTypes = []any{
 	(*OrderID)(nil),                 // 6: order.OrderID
 	(*ListOrdersByUserRequest)(nil), // 7: order.ListOrdersByUserRequest
 	(*OrderList)(nil),               // 8: order.OrderList
-	(*Empty)(nil),                   // 9: order.Empty
+	(*ListOrdersRequest)(nil),       // 9: order.ListOrdersRequest
+	(*Empty)(nil),                   // 10: order.Empty
 }
 var file_order_proto_depIdxs = []int32{
 	0,  // 0: order.UpdateOrderRequest.order:type_name -> order.Order
@@ -594,14 +709,14 @@ var file_order_proto_depIdxs = []int32{
 	2,  // 9: order.OrderService.UpdateOrder:input_type -> order.UpdateOrderRequest
 	3,  // 10: order.OrderService.AddBookToOrder:input_type -> order.BookOperationRequest
 	3,  // 11: order.OrderService.RemoveBookFromOrder:input_type -> order.BookOperationRequest
-	9,  // 12: order.OrderService.ListAllOrders:input_type -> order.Empty
+	9,  // 12: order.OrderService.ListAllOrders:input_type -> order.ListOrdersRequest
 	4,  // 13: order.OrderService.ListOrdersByStatus:input_type -> order.StatusRequest
 	5,  // 14: order.OrderService.CreateOrder:output_type -> order.OrderResponse
 	5,  // 15: order.OrderService.GetOrder:output_type -> order.OrderResponse
 	8,  // 16: order.OrderService.ListOrdersByUser:output_type -> order.OrderList
 	5,  // 17: order.OrderService.CancelOrder:output_type -> order.OrderResponse
 	5,  // 18: order.OrderService.ReturnBook:output_type -> order.OrderResponse
-	9,  // 19: order.OrderService.DeleteOrder:output_type -> order.Empty
+	10, // 19: order.OrderService.DeleteOrder:output_type -> order.Empty
 	5,  // 20: order.OrderService.UpdateOrder:output_type -> order.OrderResponse
 	5,  // 21: order.OrderService.AddBookToOrder:output_type -> order.OrderResponse
 	5,  // 22: order.OrderService.RemoveBookFromOrder:output_type -> order.OrderResponse
@@ -625,7 +740,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -45,7 +45,7 @@ type OrderServiceClient interface {
 	UpdateOrder(ctx context.Context, in *UpdateOrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	AddBookToOrder(ctx context.Context, in *BookOperationRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	RemoveBookFromOrder(ctx context.Context, in *BookOperationRequest, opts ...grpc.CallOption) (*OrderResponse, error)
-	ListAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error)
+	ListAllOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*OrderList, error)
 	ListOrdersByStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*OrderList, error)
 }
 
@@ -147,7 +147,7 @@ func (c *orderServiceClient) RemoveBookFromOrder(ctx context.Context, in *BookOp
 	return out, nil
 }
 
-func (c *orderServiceClient) ListAllOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderList, error) {
+func (c *orderServiceClient) ListAllOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*OrderList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OrderList)
 	err := c.cc.Invoke(ctx, OrderService_ListAllOrders_FullMethodName, in, out, cOpts...)
@@ -180,7 +180,7 @@ type OrderServiceServer interface {
 	UpdateOrder(context.Context, *UpdateOrderRequest) (*OrderResponse, error)
 	AddBookToOrder(context.Context, *BookOperationRequest) (*OrderResponse, error)
 	RemoveBookFromOrder(context.Context, *BookOperationRequest) (*OrderResponse, error)
-	ListAllOrders(context.Context, *Empty) (*OrderList, error)
+	ListAllOrders(context.Context, *ListOrdersRequest) (*OrderList, error)
 	ListOrdersByStatus(context.Context, *StatusRequest) (*OrderList, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
@@ -219,7 +219,7 @@ func (UnimplementedOrderServiceServer) AddBookToOrder(context.Context, *BookOper
 func (UnimplementedOrderServiceServer) RemoveBookFromOrder(context.Context, *BookOperationRequest) (*OrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveBookFromOrder not implemented")
 }
-func (UnimplementedOrderServiceServer) ListAllOrders(context.Context, *Empty) (*OrderList, error) {
+func (UnimplementedOrderServiceServer) ListAllOrders(context.Context, *ListOrdersRequest) (*OrderList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllOrders not implemented")
 }
 func (UnimplementedOrderServiceServer) ListOrdersByStatus(context.Context, *StatusRequest) (*OrderList, error) {
@@ -409,7 +409,7 @@ func _OrderService_RemoveBookFromOrder_Handler(srv interface{}, ctx context.Cont
 }
 
 func _OrderService_ListAllOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListOrdersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -421,7 +421,7 @@ func _OrderService_ListAllOrders_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: OrderService_ListAllOrders_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OrderServiceServer).ListAllOrders(ctx, req.(*Empty))
+		return srv.(OrderServiceServer).ListAllOrders(ctx, req.(*ListOrdersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate_TrustsOnlyVerifiedTokens(t *testing.T) {
+	m := NewManager("secret", time.Minute, time.Hour)
+	admin, _ := m.Issue("user-1", RoleAdmin)
+	incoming := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+
+	ctx, err := m.authenticate(incoming(TokenKey, "Bearer "+admin.AccessToken))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if id, err := RequireAdmin(ctx); err != nil || id != "user-1" {
+		t.Errorf("expected admin user-1, got %q, %v", id, err)
+	}
+
+	ctx, err = m.authenticate(incoming("x-user-id", "user-2", "x-user-role", RoleAdmin))
+	if err != nil {
+		t.Fatalf("anonymous call rejected: %v", err)
+	}
+	if _, err := RequireUserID(ctx); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("plain metadata was trusted: %v", err)
+	}
+
+	forged, _ := NewManager("other", time.Minute, time.Hour).Issue("user-2", RoleAdmin)
+	for _, v := range []string{"Bearer " + forged.AccessToken, "Bearer " + admin.RefreshToken, admin.AccessToken} {
+		if _, err := m.authenticate(incoming(TokenKey, v)); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected %q to fail with Unauthenticated, got %v", v, err)
+		}
+	}
+}
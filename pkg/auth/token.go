@@ -0,0 +1,117 @@
+// Package auth issues and validates the JWTs shared by user_service and the
+// API gateway, and carries the authenticated user ID between services.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+
+	defaultSecret = "readspace-dev-secret"
+)
+
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// RoleAdmin marks users allowed to moderate, e.g. resolve exchange disputes.
+const RoleAdmin = "admin"
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Type TokenType `json:"typ"`
+	Role string    `json:"role,omitempty"`
+}
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+type Manager struct {
+	secret     []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewManager(secret string, accessTTL, refreshTTL time.Duration) *Manager {
+	return &Manager{secret: []byte(secret), accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+// SecretFromEnv returns JWT_SECRET, falling back to a development secret.
+func SecretFromEnv() string {
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		return s
+	}
+	log.Println("⚠ JWT_SECRET is not set, using the development secret")
+	return defaultSecret
+}
+
+// Issue signs a fresh access/refresh token pair for userID; role may be empty.
+func (m *Manager) Issue(userID, role string) (*TokenPair, error) {
+	access, err := m.sign(userID, role, AccessToken, m.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.sign(userID, role, RefreshToken, m.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{AccessToken: access, RefreshToken: refresh, ExpiresIn: m.accessTTL}, nil
+}
+
+// Parse validates the token signature, expiry and type and returns its subject.
+func (m *Manager) Parse(token string, typ TokenType) (string, error) {
+	claims, err := m.ParseClaims(token, typ)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseClaims is Parse returning every claim, including the role.
+func (m *Manager) ParseClaims(token string, typ TokenType) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Type != typ {
+		return nil, fmt.Errorf("%w: expected %s token", ErrInvalidToken, typ)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+func (m *Manager) sign(userID, role string, typ TokenType, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Type: typ,
+		Role: role,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
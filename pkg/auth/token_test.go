@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	m := NewManager("secret", time.Minute, time.Hour)
+	pair, err := m.Issue("user-1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := m.Parse(pair.AccessToken, AccessToken)
+	if err != nil || id != "user-1" {
+		t.Fatalf("access token: got %q, %v", id, err)
+	}
+	id, err = m.Parse(pair.RefreshToken, RefreshToken)
+	if err != nil || id != "user-1" {
+		t.Fatalf("refresh token: got %q, %v", id, err)
+	}
+
+	admin, err := m.Issue("user-2", RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := m.ParseClaims(admin.AccessToken, AccessToken)
+	if err != nil || claims.Subject != "user-2" || claims.Role != RoleAdmin {
+		t.Fatalf("admin token: got %+v, %v", claims, err)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	m := NewManager("secret", time.Minute, time.Hour)
+	pair, _ := m.Issue("user-1", "")
+
+	if _, err := m.Parse(pair.RefreshToken, AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("refresh token accepted as access token: %v", err)
+	}
+	other := NewManager("other", time.Minute, time.Hour)
+	if _, err := other.Parse(pair.AccessToken, AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token with wrong signature accepted: %v", err)
+	}
+	expired := NewManager("secret", -time.Minute, time.Hour)
+	old, _ := expired.Issue("user-1", "")
+	if _, err := m.Parse(old.AccessToken, AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired token accepted: %v", err)
+	}
+}
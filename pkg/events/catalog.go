@@ -0,0 +1,128 @@
+package events
+
+// User service.
+const UserCreated Subject[UserCreatedEvent] = "user.created"
+
+type UserCreatedEvent struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Book service. book.deleted only carries the ID.
+const (
+	BookCreated Subject[BookEvent] = "book.created"
+	BookUpdated Subject[BookEvent] = "book.updated"
+	BookDeleted Subject[BookEvent] = "book.deleted"
+)
+
+type BookEvent struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
+
+// Book reviews: a review was posted, edited or deleted. Rating and
+// RatingCount are the book's after the change; Stars is the review's own
+// rating and is zero when it was deleted.
+const BookReviewed Subject[BookReviewedEvent] = "book.reviewed"
+
+type BookReviewedEvent struct {
+	BookID      string  `json:"book_id"`
+	ReviewID    string  `json:"review_id"`
+	UserID      string  `json:"user_id"`
+	Stars       int     `json:"stars,omitempty"`
+	Rating      float32 `json:"rating"`
+	RatingCount int     `json:"rating_count"`
+}
+
+// Order service. ReturnBook completes an order.
+const (
+	OrderCreated   Subject[OrderEvent] = "order.created"
+	OrderUpdated   Subject[OrderEvent] = "order.updated"
+	OrderCancelled Subject[OrderEvent] = "order.cancelled"
+	OrderCompleted Subject[OrderEvent] = "order.completed"
+	OrderDeleted   Subject[OrderEvent] = "order.deleted"
+)
+
+type OrderEvent struct {
+	OrderID string   `json:"order_id"`
+	UserID  string   `json:"user_id"`
+	BookIDs []string `json:"book_ids"`
+	Status  string   `json:"status"`
+}
+
+// Exchange service.
+const (
+	ExchangeOffered   Subject[OfferEvent] = "exchange.offered"
+	ExchangeUpdated   Subject[OfferEvent] = "exchange.updated"
+	ExchangeAccepted  Subject[OfferEvent] = "exchange.accepted"
+	ExchangeDeclined  Subject[OfferEvent] = "exchange.declined"
+	ExchangeCancelled Subject[OfferEvent] = "exchange.cancelled"
+	ExchangeCountered Subject[OfferEvent] = "exchange.countered"
+	ExchangeExpired   Subject[OfferEvent] = "exchange.expired"
+	ExchangeCompleted Subject[OfferEvent] = "exchange.completed"
+	ExchangeDeleted   Subject[OfferEvent] = "exchange.deleted"
+)
+
+// OfferEvent describes an offer after a change. Revision, EditorID and
+// Changed (the edited field names) are only set on exchange.updated.
+type OfferEvent struct {
+	OfferID        string   `json:"offer_id"`
+	OwnerID        string   `json:"owner_id"`
+	CounterpartyID string   `json:"counterparty_id"`
+	Status         string   `json:"status"`
+	Revision       int      `json:"revision,omitempty"`
+	EditorID       string   `json:"editor_id,omitempty"`
+	Changed        []string `json:"changed,omitempty"`
+}
+
+// Exchange disputes. Note is the reason, the new evidence or the resolution.
+const (
+	ExchangeDisputeOpened   Subject[DisputeEvent] = "exchange.dispute.opened"
+	ExchangeDisputeEvidence Subject[DisputeEvent] = "exchange.dispute.evidence"
+	ExchangeDisputeResolved Subject[DisputeEvent] = "exchange.dispute.resolved"
+)
+
+type DisputeEvent struct {
+	DisputeID      string `json:"dispute_id"`
+	OfferID        string `json:"offer_id"`
+	OwnerID        string `json:"owner_id"`
+	CounterpartyID string `json:"counterparty_id"`
+	ActorID        string `json:"actor_id"`
+	Status         string `json:"status"`
+	Note           string `json:"note,omitempty"`
+	Reversed       bool   `json:"reversed"`
+}
+
+// Offer chat. Watchers of a thread are fed from this subject, so every
+// exchange_service instance sees messages posted through the others.
+const ExchangeMessageSent Subject[OfferMessageEvent] = "exchange.message.sent"
+
+type OfferMessageEvent struct {
+	MessageID   string `json:"message_id"`
+	OfferID     string `json:"offer_id"`
+	SenderID    string `json:"sender_id"`
+	RecipientID string `json:"recipient_id"`
+	Body        string `json:"body"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// User library service.
+const (
+	LibraryBookAssigned   Subject[LibraryBookEvent]  = "userlibrary.book.assigned"
+	LibraryBookUnassigned Subject[LibraryBookEvent]  = "userlibrary.book.unassigned"
+	LibraryEntryUpdated   Subject[LibraryEntryEvent] = "userlibrary.entry.updated"
+	LibraryEntryDeleted   Subject[LibraryEntryEvent] = "userlibrary.entry.deleted"
+)
+
+type LibraryBookEvent struct {
+	UserID string `json:"user_id"`
+	BookID string `json:"book_id"`
+}
+
+type LibraryEntryEvent struct {
+	EntryID string `json:"id"`
+	UserID  string `json:"user_id"`
+	BookID  string `json:"book_id,omitempty"`
+}
@@ -0,0 +1,46 @@
+// Package events is the catalog of NATS subjects exchanged between the
+// services together with their JSON payloads. Every publisher and subscriber
+// goes through Publish and Subscribe, so a subject can only ever carry the
+// payload type it is declared with.
+package events
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Subject is a NATS subject whose messages carry a T encoded as JSON.
+type Subject[T any] string
+
+func (s Subject[T]) String() string { return string(s) }
+
+// Publish encodes payload and publishes it on subject.
+func Publish[T any](nc *nats.Conn, subject Subject[T], payload T) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return nc.Publish(string(subject), data)
+}
+
+// Emit is Publish for fire-and-forget events: failures are only logged.
+func Emit[T any](nc *nats.Conn, subject Subject[T], payload T) {
+	if err := Publish(nc, subject, payload); err != nil {
+		log.Printf("⚠ NATS publish error (%s): %v", subject, err)
+	}
+}
+
+// Subscribe decodes every message on subject and passes it to handle.
+// Messages that cannot be decoded are logged and dropped.
+func Subscribe[T any](nc *nats.Conn, subject Subject[T], handle func(T)) (*nats.Subscription, error) {
+	return nc.Subscribe(string(subject), func(m *nats.Msg) {
+		var payload T
+		if err := json.Unmarshal(m.Data, &payload); err != nil {
+			log.Printf("unmarshal %s: %v", subject, err)
+			return
+		}
+		handle(payload)
+	})
+}
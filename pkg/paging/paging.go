@@ -0,0 +1,217 @@
+// Package paging implements the keyset (cursor) pagination shared by the
+// List RPCs. A page is fetched with a range query on the sort field and _id,
+// so every page costs one index scan no matter how deep the client pages.
+// The page token encodes where the previous page ended and the sort it was
+// issued for.
+package paging
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultSize = 50
+	MaxSize     = 500
+
+	// TrailerKey carries the next page token of server-streaming List RPCs,
+	// which have no response message to put it in.
+	TrailerKey = "x-next-page-token"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid page token")
+	ErrInvalidSort  = errors.New("unsupported sort field")
+	ErrInvalidSize  = errors.New("page_size must not be negative")
+)
+
+// Fields maps the sort names a List RPC accepts to the stored field names.
+type Fields map[string]string
+
+// Query is a validated page request.
+type Query struct {
+	Size  int
+	Field string // stored field the page is sorted by; _id by default
+	Desc  bool
+	after *cursor
+}
+
+// cursor is the position of the last item of the previous page.
+type cursor struct {
+	Field string             `bson:"f"`
+	Desc  bool               `bson:"d"`
+	Value bson.RawValue      `bson:"v"`
+	ID    primitive.ObjectID `bson:"id"`
+}
+
+// New validates a page request. sort is one of fields, optionally prefixed
+// with "-" for descending order; an empty sort pages in _id (insertion)
+// order. A zero size means DefaultSize and sizes above MaxSize are capped.
+// A token only continues the sort it was issued for.
+func New(size int32, token, sort string, fields Fields) (*Query, error) {
+	if size < 0 {
+		return nil, ErrInvalidSize
+	}
+	q := &Query{Size: int(size), Field: "_id"}
+	switch {
+	case q.Size == 0:
+		q.Size = DefaultSize
+	case q.Size > MaxSize:
+		q.Size = MaxSize
+	}
+
+	if name, desc := strings.CutPrefix(sort, "-"); name != "" {
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSort, name)
+		}
+		q.Field, q.Desc = field, desc
+	} else {
+		q.Desc = desc
+	}
+
+	if token != "" {
+		c, err := decode(token)
+		if err != nil || c.Field != q.Field || c.Desc != q.Desc {
+			return nil, ErrInvalidToken
+		}
+		q.after = c
+	}
+	return q, nil
+}
+
+// Find narrows filter to the documents after the cursor and returns the
+// options that sort them and fetch one item more than the page, which tells
+// Page whether another page follows.
+func (q *Query) Find(filter bson.M) (bson.M, *options.FindOptions) {
+	opts := options.Find().SetSort(q.sort()).SetLimit(q.limit())
+
+	resume := q.resume()
+	if resume == nil {
+		return filter, opts
+	}
+	if len(filter) == 0 {
+		return resume, opts
+	}
+	return bson.M{"$and": bson.A{filter, resume}}, opts
+}
+
+// Stages is Find for aggregations, where the sort field may be computed by
+// earlier stages (a text score, for instance): it returns the stages that
+// skip to the cursor, sort and fetch one item more than the page.
+func (q *Query) Stages() mongo.Pipeline {
+	var stages mongo.Pipeline
+	if resume := q.resume(); resume != nil {
+		stages = append(stages, bson.D{{Key: "$match", Value: resume}})
+	}
+	return append(stages,
+		bson.D{{Key: "$sort", Value: q.sort()}},
+		bson.D{{Key: "$limit", Value: q.limit()}},
+	)
+}
+
+func (q *Query) sort() bson.D {
+	dir := 1
+	if q.Desc {
+		dir = -1
+	}
+	sort := bson.D{{Key: q.Field, Value: dir}}
+	if q.Field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: dir})
+	}
+	return sort
+}
+
+func (q *Query) limit() int64 {
+	return int64(q.Size) + 1
+}
+
+// resume matches the documents after the cursor, or is nil on the first page.
+func (q *Query) resume() bson.M {
+	if q.after == nil {
+		return nil
+	}
+	cmp := "$gt"
+	if q.Desc {
+		cmp = "$lt"
+	}
+	if q.Field == "_id" {
+		return bson.M{"_id": bson.M{cmp: q.after.ID}}
+	}
+	// Documents missing the sort field sort as null, before every value:
+	// first in ascending order, last in descending order. $gt and $lt never
+	// match them, so they are resumed by equality with null.
+	if q.after.Value.Type == bsontype.Null {
+		tie := bson.M{q.Field: nil, "_id": bson.M{cmp: q.after.ID}}
+		if q.Desc {
+			return tie
+		}
+		return bson.M{"$or": bson.A{tie, bson.M{q.Field: bson.M{"$ne": nil}}}}
+	}
+	after := bson.A{
+		bson.M{q.Field: bson.M{cmp: q.after.Value}},
+		bson.M{q.Field: q.after.Value, "_id": bson.M{cmp: q.after.ID}},
+	}
+	if q.Desc {
+		after = append(after, bson.M{q.Field: nil})
+	}
+	return bson.M{"$or": after}
+}
+
+// Page trims the items fetched with Find to the page size and returns the
+// token of the next page, or "" after the last page. Items must encode to
+// documents carrying _id; a missing sort field is taken as null.
+func Page[T any](q *Query, items []T) ([]T, string, error) {
+	if len(items) <= q.Size {
+		return items, "", nil
+	}
+	items = items[:q.Size]
+	raw, err := bson.Marshal(items[len(items)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	doc := bson.Raw(raw)
+	id, ok := doc.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return nil, "", errors.New("paging: item has no ObjectID _id")
+	}
+	c := cursor{Field: q.Field, Desc: q.Desc, ID: id, Value: bson.RawValue{Type: bsontype.Null}}
+	if q.Field != "_id" {
+		if v, err := doc.LookupErr(q.Field); err == nil {
+			c.Value = v
+		}
+	}
+	token, err := encode(&c)
+	if err != nil {
+		return nil, "", err
+	}
+	return items, token, nil
+}
+
+func encode(c *cursor) (string, error) {
+	data, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decode(token string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c cursor
+	if err := bson.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,209 @@
+package paging
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type item struct {
+	ID    primitive.ObjectID `bson:"_id"`
+	Title string             `bson:"title"`
+}
+
+var fields = Fields{"title": "title"}
+
+func TestPage_TokenResumesAfterLastItem(t *testing.T) {
+	q, err := New(2, "", "-title", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Field != "title" || !q.Desc || q.Size != 2 {
+		t.Fatalf("unexpected query %+v", q)
+	}
+	items := []item{{primitive.NewObjectID(), "c"}, {primitive.NewObjectID(), "b"}, {primitive.NewObjectID(), "a"}}
+	page, token, err := Page(q, items)
+	if err != nil || len(page) != 2 || token == "" {
+		t.Fatalf("got %d items, token %q, %v", len(page), token, err)
+	}
+
+	next, err := New(2, token, "-title", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, _ := next.Find(bson.M{"genre": "poetry"})
+	resume := filter["$and"].(bson.A)[1].(bson.M)["$or"].(bson.A)
+	if v := resume[0].(bson.M)["title"].(bson.M)["$lt"].(bson.RawValue); v.StringValue() != "b" {
+		t.Errorf("expected to resume below %q, got %v", "b", v)
+	}
+
+	if _, token, _ := Page(next, items[:2]); token != "" {
+		t.Errorf("last page must not return a token, got %q", token)
+	}
+}
+
+func TestStages_ResumeBeforeSort(t *testing.T) {
+	q, _ := New(2, "", "-score", Fields{"score": "score"})
+	if stages := q.Stages(); len(stages) != 2 || stages[0][0].Key != "$sort" {
+		t.Fatalf("first page must only sort and limit, got %v", stages)
+	}
+
+	_, token, _ := Page(q, []bson.M{
+		{"_id": primitive.NewObjectID(), "score": 2.5},
+		{"_id": primitive.NewObjectID(), "score": 1.5},
+		{"_id": primitive.NewObjectID(), "score": 0.5},
+	})
+	next, err := New(2, token, "-score", Fields{"score": "score"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stages := next.Stages()
+	if len(stages) != 3 || stages[0][0].Key != "$match" || stages[1][0].Key != "$sort" {
+		t.Fatalf("next page must match the cursor before sorting, got %v", stages)
+	}
+	if limit := stages[2][0].Value.(int64); limit != 3 {
+		t.Errorf("expected a limit of one item more than the page, got %d", limit)
+	}
+}
+
+func TestNew_Rejects(t *testing.T) {
+	q, _ := New(1, "", "title", fields)
+	_, token, _ := Page(q, []item{{primitive.NewObjectID(), "a"}, {primitive.NewObjectID(), "b"}})
+
+	if _, err := New(1, token, "-title", fields); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token reused with another sort: expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := New(1, "not-a-token", "", fields); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("garbage token: expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := New(1, "", "price", fields); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("unknown sort: expected ErrInvalidSort, got %v", err)
+	}
+	if q, _ := New(10000, "", "", fields); q.Size != MaxSize {
+		t.Errorf("size must be capped at %d, got %d", MaxSize, q.Size)
+	}
+}
+
+// scalar returns v as a string, or nil for null and missing values; the
+// test documents only hold strings and ObjectIDs.
+func scalar(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bson.RawValue:
+		if s, ok := v.StringValueOK(); ok {
+			return s
+		}
+		return nil
+	case primitive.ObjectID:
+		return v.Hex()
+	case string:
+		return v
+	}
+	return nil
+}
+
+// matches evaluates the subset of Mongo filters resume builds, with Mongo's
+// type bracketing: $gt and $lt never match null or missing values.
+func matches(doc bson.M, filter bson.M) bool {
+	for key, cond := range filter {
+		switch key {
+		case "$or":
+			if !slices.ContainsFunc(cond.(bson.A), func(f interface{}) bool { return matches(doc, f.(bson.M)) }) {
+				return false
+			}
+			continue
+		case "$and":
+			for _, f := range cond.(bson.A) {
+				if !matches(doc, f.(bson.M)) {
+					return false
+				}
+			}
+			continue
+		}
+		v := scalar(doc[key])
+		ops, ok := cond.(bson.M)
+		if !ok {
+			ops = bson.M{"$eq": cond}
+		}
+		for op, arg := range ops {
+			want := scalar(arg)
+			var ok bool
+			switch op {
+			case "$eq":
+				ok = v == want
+			case "$ne":
+				ok = v != want
+			case "$gt":
+				ok = v != nil && want != nil && v.(string) > want.(string)
+			case "$lt":
+				ok = v != nil && want != nil && v.(string) < want.(string)
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestPage_ResumesOverMissingSortField(t *testing.T) {
+	var docs []bson.M
+	for _, title := range []string{"b", "", "a", "", "c"} {
+		doc := bson.M{"_id": primitive.NewObjectID()}
+		if title != "" {
+			doc["title"] = title
+		}
+		docs = append(docs, doc)
+	}
+	// find sorts like Mongo: missing titles first, ties by _id.
+	find := func(q *Query) []bson.M {
+		filter, _ := q.Find(bson.M{})
+		var out []bson.M
+		for _, d := range docs {
+			if matches(d, filter) {
+				out = append(out, d)
+			}
+		}
+		key := func(d bson.M) string {
+			t, _ := d["title"].(string)
+			return t + "/" + d["_id"].(primitive.ObjectID).Hex()
+		}
+		sort.Slice(out, func(i, j int) bool { return (key(out[i]) < key(out[j])) != q.Desc })
+		if len(out) > int(q.limit()) {
+			out = out[:q.limit()]
+		}
+		return out
+	}
+
+	for _, order := range []string{"title", "-title"} {
+		var titles []string
+		token := ""
+		for range docs {
+			q, err := New(1, token, order, fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			page, next, err := Page(q, find(q))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range page {
+				title, _ := d["title"].(string)
+				titles = append(titles, title)
+			}
+			if token = next; token == "" {
+				break
+			}
+		}
+		want := []string{"", "", "a", "b", "c"}
+		if order == "-title" {
+			want = []string{"c", "b", "a", "", ""}
+		}
+		if !slices.Equal(titles, want) {
+			t.Errorf("sort %s: paged through %q, want %q", order, titles, want)
+		}
+	}
+}
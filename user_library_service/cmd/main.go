@@ -11,11 +11,16 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"google.golang.org/grpc"
 
+	bookpb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/config"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/handler"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/migrations"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/recommend"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/repository"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/usecase"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/worker"
 	userpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
 )
 
@@ -32,6 +37,9 @@ func main() {
 	log.Printf("🟢 [DEBUG] user_books collection has %d documents", count)
 	// —————————————————————————————————————————————————————
 
+	migrations.CreateReservationIndexes(db)
+	migrations.CreateEntryIndexes(db)
+
 	// ——— Подключаемся к Redis ———
 	rdb := redis.NewClient(&redis.Options{
 		Addr: "localhost:6379",
@@ -51,18 +59,41 @@ func main() {
 	defer nc.Close()
 	log.Println("🟢 Connected to NATS")
 
+	// ——— Подключаемся к BookService ———
+	bookConn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("🔴 cannot dial BookService: %v", err)
+	}
+	defer bookConn.Close()
+	bookClient := bookpb.NewBookServiceClient(bookConn)
+
 	// ——— Инициализируем слои ———
 	repo := repository.NewMongoUserBookRepo(db)
+	reservations := repository.NewMongoReservationRepo(db)
 	redisCache := cache.NewRedisUserLibraryCache(repo, rdb, 5*time.Minute)
-	uc := usecase.NewUserLibraryUseCase(repo, redisCache)
-	h := handler.NewUserLibraryHandler(uc, nc)
+	uc := usecase.NewUserLibraryUseCase(repo, reservations, redisCache)
+
+	// ——— Матрица совместного владения для рекомендаций ———
+	matrix := recommend.New()
+	if err := matrix.Follow(nc); err != nil {
+		log.Fatalf("🔴 cannot follow library events: %v", err)
+	}
+	if err := matrix.Load(context.Background(), repo); err != nil {
+		log.Fatalf("🔴 cannot load co-ownership matrix: %v", err)
+	}
+	rs := config.LoadRecommendSettings()
+	go worker.NewMatrixRefresher(matrix, repo, rs.RefreshInterval).Run(context.Background())
+
+	recUC := usecase.NewRecommendUseCase(matrix, redisCache, bookClient)
+	h := handler.NewUserLibraryHandler(uc, recUC, nc)
 
 	// ——— Запускаем gRPC-сервер ———
 	lis, err := net.Listen("tcp", ":50055")
 	if err != nil {
 		log.Fatalf("🔴 failed to listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+	grpcServer := grpc.NewServer(auth.ServerOptions(tokens)...)
 	userpb.RegisterUserLibraryServiceServer(grpcServer, h)
 
 	log.Println("🟢 UserLibraryService listening on :50055")
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+const DefaultRefreshInterval = 15 * time.Minute
+
+// RecommendSettings controls how often the co-ownership matrix is rebuilt
+// from Mongo.
+type RecommendSettings struct {
+	RefreshInterval time.Duration
+}
+
+// LoadRecommendSettings reads RECOMMEND_REFRESH_INTERVAL (a Go duration such
+// as "1h"), falling back to the default.
+func LoadRecommendSettings() RecommendSettings {
+	return RecommendSettings{
+		RefreshInterval: durationEnv("RECOMMEND_REFRESH_INTERVAL", DefaultRefreshInterval),
+	}
+}
+
+func durationEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("⚠ invalid %s=%q, using %s", key, v, def)
+		return def
+	}
+	return d
+}
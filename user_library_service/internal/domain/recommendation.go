@@ -0,0 +1,14 @@
+package domain
+
+// Where a recommended book comes from.
+const (
+	SourceCoOwned  = "co_owned"
+	SourceSimilar  = "similar"
+	SourceTopRated = "top_rated"
+)
+
+type Recommendation struct {
+	BookID string
+	Score  float64
+	Source string
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Reservation holds a book in a user's library for a pending exchange offer.
+type Reservation struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	BookID    primitive.ObjectID `bson:"book_id"`
+	OfferID   primitive.ObjectID `bson:"offer_id"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
@@ -1,30 +1,25 @@
 package domain
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
-type UserBook struct {
-	ID     primitive.ObjectID
-	UserID primitive.ObjectID
-	BookID primitive.ObjectID
-}
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
 
-type BookAssignedEvent struct {
-	UserID string `json:"user_id"`
-	BookID string `json:"book_id"`
-}
-
-type BookUnassignedEvent struct {
-	UserID string `json:"user_id"`
-	BookID string `json:"book_id"`
+type UserBook struct {
+	ID     primitive.ObjectID `bson:"_id"`
+	UserID primitive.ObjectID `bson:"user_id"`
+	BookID primitive.ObjectID `bson:"book_id"`
 }
 
-type EntryDeletedEvent struct {
-	EntryID string `json:"id"`
-	UserID  string `json:"user_id"`
+// EntrySortFields are the sort orders ListAllEntries accepts.
+var EntrySortFields = paging.Fields{
+	"user_id": "user_id",
+	"book_id": "book_id",
 }
 
-type EntryUpdatedEvent struct {
-	EntryID string `json:"id"`
-	UserID  string `json:"user_id"`
-	BookID  string `json:"book_id"`
+// EntryFilter narrows ListAllEntries; zero fields match everything.
+type EntryFilter struct {
+	UserID primitive.ObjectID
+	BookID primitive.ObjectID
 }
@@ -2,14 +2,17 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 
 	"github.com/nats-io/nats.go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/usecase"
 	userpb "github.com/OshakbayAigerim/read_space/user_library_service/proto"
@@ -17,17 +20,13 @@ import (
 
 type UserLibraryHandler struct {
 	userpb.UnimplementedUserLibraryServiceServer
-	uc usecase.UserLibraryUseCase
-	nc *nats.Conn
+	uc        usecase.UserLibraryUseCase
+	recommend usecase.RecommendUseCase
+	nc        *nats.Conn
 }
 
-func NewUserLibraryHandler(uc usecase.UserLibraryUseCase, nc *nats.Conn) *UserLibraryHandler {
-	return &UserLibraryHandler{uc: uc, nc: nc}
-}
-
-func mustMarshal(v interface{}) []byte {
-	b, _ := json.Marshal(v)
-	return b
+func NewUserLibraryHandler(uc usecase.UserLibraryUseCase, recommend usecase.RecommendUseCase, nc *nats.Conn) *UserLibraryHandler {
+	return &UserLibraryHandler{uc: uc, recommend: recommend, nc: nc}
 }
 
 func toProto(u *domain.UserBook) *userpb.UserBook {
@@ -54,8 +53,7 @@ func (h *UserLibraryHandler) AssignBook(ctx context.Context, req *userpb.AssignB
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot assign book: %v", err)
 	}
-	evt := domain.BookAssignedEvent{UserID: req.UserId, BookID: req.BookId}
-	h.nc.Publish("userlibrary.book.assigned", mustMarshal(evt))
+	events.Emit(h.nc, events.LibraryBookAssigned, events.LibraryBookEvent{UserID: req.UserId, BookID: req.BookId})
 	return &userpb.AssignBookResponse{Entry: toProto(entry)}, nil
 }
 
@@ -63,11 +61,17 @@ func (h *UserLibraryHandler) UnassignBook(ctx context.Context, req *userpb.Unass
 	if req.UserId == "" || req.BookId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id and book_id are required")
 	}
-	if err := h.uc.UnassignBook(ctx, req.UserId, req.BookId); err != nil {
+	if err := h.uc.UnassignBook(ctx, req.UserId, req.BookId, req.OfferId); err != nil {
+		var re *usecase.ReservedError
+		if errors.As(err, &re) {
+			return nil, reservedStatus(re)
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Error(codes.NotFound, "user does not own this book")
+		}
 		return nil, status.Errorf(codes.Internal, "cannot unassign book: %v", err)
 	}
-	evt := domain.BookUnassignedEvent{UserID: req.UserId, BookID: req.BookId}
-	h.nc.Publish("userlibrary.book.unassigned", mustMarshal(evt))
+	events.Emit(h.nc, events.LibraryBookUnassigned, events.LibraryBookEvent{UserID: req.UserId, BookID: req.BookId})
 	return &userpb.UnassignBookResponse{Success: true}, nil
 }
 
@@ -103,10 +107,13 @@ func (h *UserLibraryHandler) DeleteEntry(ctx context.Context, req *userpb.Delete
 		return nil, status.Errorf(codes.NotFound, "entry not found: %v", err)
 	}
 	if err := h.uc.DeleteEntry(ctx, req.Id); err != nil {
+		var re *usecase.ReservedError
+		if errors.As(err, &re) {
+			return nil, reservedStatus(re)
+		}
 		return nil, status.Errorf(codes.Internal, "cannot delete entry: %v", err)
 	}
-	evt := domain.EntryDeletedEvent{EntryID: req.Id, UserID: e.UserID.Hex()}
-	h.nc.Publish("userlibrary.entry.deleted", mustMarshal(evt))
+	events.Emit(h.nc, events.LibraryEntryDeleted, events.LibraryEntryEvent{EntryID: req.Id, UserID: e.UserID.Hex()})
 	return &userpb.UnassignBookResponse{Success: true}, nil
 }
 
@@ -129,19 +136,38 @@ func (h *UserLibraryHandler) UpdateEntry(ctx context.Context, req *userpb.Update
 	dom := &domain.UserBook{ID: oid, UserID: uo, BookID: bo}
 	updated, err := h.uc.UpdateEntry(ctx, dom)
 	if err != nil {
+		var re *usecase.ReservedError
+		if errors.As(err, &re) {
+			return nil, reservedStatus(re)
+		}
 		return nil, status.Errorf(codes.Internal, "cannot update entry: %v", err)
 	}
-	evt := domain.EntryUpdatedEvent{EntryID: req.Entry.Id, UserID: req.Entry.UserId, BookID: req.Entry.BookId}
-	h.nc.Publish("userlibrary.entry.updated", mustMarshal(evt))
+	events.Emit(h.nc, events.LibraryEntryUpdated, events.LibraryEntryEvent{EntryID: req.Entry.Id, UserID: req.Entry.UserId, BookID: req.Entry.BookId})
 	return &userpb.AssignBookResponse{Entry: toProto(updated)}, nil
 }
 
-func (h *UserLibraryHandler) ListAllEntries(ctx context.Context, _ *emptypb.Empty) (*userpb.ListUserBooksResponse, error) {
-	all, err := h.uc.ListAllEntries(ctx)
+func (h *UserLibraryHandler) ListAllEntries(ctx context.Context, req *userpb.ListEntriesRequest) (*userpb.ListUserBooksResponse, error) {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.EntrySortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var f domain.EntryFilter
+	if req.GetUserId() != "" {
+		if f.UserID, err = primitive.ObjectIDFromHex(req.UserId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+	}
+	if req.GetBookId() != "" {
+		if f.BookID, err = primitive.ObjectIDFromHex(req.BookId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid book_id")
+		}
+	}
+
+	all, next, err := h.uc.ListAllEntries(ctx, f, q)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot list all entries: %v", err)
 	}
-	return &userpb.ListUserBooksResponse{Entries: toProtoList(all)}, nil
+	return &userpb.ListUserBooksResponse{Entries: toProtoList(all), NextPageToken: next}, nil
 }
 
 func (h *UserLibraryHandler) ListByBook(ctx context.Context, req *userpb.ListByBookRequest) (*userpb.ListUserBooksResponse, error) {
@@ -154,3 +180,69 @@ func (h *UserLibraryHandler) ListByBook(ctx context.Context, req *userpb.ListByB
 	}
 	return &userpb.ListUserBooksResponse{Entries: toProtoList(list)}, nil
 }
+
+func (h *UserLibraryHandler) ReserveBooks(ctx context.Context, req *userpb.ReserveBooksRequest) (*userpb.ReserveBooksResponse, error) {
+	if req.UserId == "" || req.OfferId == "" || len(req.BookIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id, offer_id and book_ids are required")
+	}
+	conflicts, err := h.uc.ReserveBooks(ctx, req.UserId, req.OfferId, req.BookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot reserve books: %v", err)
+	}
+	out := make([]*userpb.Reservation, len(conflicts))
+	for i, r := range conflicts {
+		out[i] = &userpb.Reservation{
+			UserId:    r.UserID.Hex(),
+			BookId:    r.BookID.Hex(),
+			OfferId:   r.OfferID.Hex(),
+			CreatedAt: r.CreatedAt.Time().String(),
+		}
+	}
+	return &userpb.ReserveBooksResponse{Conflicts: out}, nil
+}
+
+func (h *UserLibraryHandler) ReleaseBooks(ctx context.Context, req *userpb.ReleaseBooksRequest) (*userpb.ReleaseBooksResponse, error) {
+	if req.OfferId == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	n, err := h.uc.ReleaseBooks(ctx, req.OfferId, req.BookIds)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot release books: %v", err)
+	}
+	return &userpb.ReleaseBooksResponse{Released: n}, nil
+}
+
+func (h *UserLibraryHandler) RecommendForUser(ctx context.Context, req *userpb.RecommendForUserRequest) (*userpb.RecommendForUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if !primitive.IsValidObjectID(req.UserId) {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	recs, err := h.recommend.RecommendForUser(ctx, req.UserId, int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot recommend books: %v", err)
+	}
+	out := make([]*userpb.BookRecommendation, len(recs))
+	for i, r := range recs {
+		out[i] = &userpb.BookRecommendation{BookId: r.BookID, Score: r.Score, Source: r.Source}
+	}
+	return &userpb.RecommendForUserResponse{Books: out}, nil
+}
+
+// reservedStatus reports a reserved book as FailedPrecondition naming the
+// offer that holds it.
+func reservedStatus(re *usecase.ReservedError) error {
+	st := status.New(codes.FailedPrecondition, re.Error())
+	pf := &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "RESERVED",
+			Subject:     "book:" + re.Reservation.BookID.Hex(),
+			Description: "book is reserved by exchange offer " + re.Reservation.OfferID.Hex(),
+		}},
+	}
+	if withDetails, err := st.WithDetails(pf); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
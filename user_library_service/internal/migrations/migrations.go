@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateReservationIndexes lets a book in a user's library be held by at most
+// one offer, and lets an offer release its books in one query.
+func CreateReservationIndexes(db *mongo.Database) {
+	collection := db.Collection("reservations")
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "book_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "offer_id", Value: 1}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for reservations collection")
+}
+
+// CreateEntryIndexes backs the keyset pagination of ListAllEntries: each
+// sort and filter field is indexed together with _id.
+func CreateEntryIndexes(db *mongo.Database) {
+	collection := db.Collection("user_books")
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "book_id", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	log.Println("Created indexes for user_books collection")
+}
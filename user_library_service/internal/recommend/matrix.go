@@ -0,0 +1,249 @@
+// Package recommend scores books by how often they are owned together.
+// Every user_library_service replica keeps its own Matrix: it is loaded from
+// the user_books collection at start, kept current from the
+// userlibrary.book.assigned and userlibrary.book.unassigned events, and
+// rebuilt periodically to pick up entries changed any other way.
+package recommend
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
+)
+
+// Scored is a book recommended to a user. Score is the summed cosine
+// similarity between the book and each of the user's books, measured over
+// their owners.
+type Scored struct {
+	BookID string
+	Score  float64
+}
+
+type Matrix struct {
+	mu sync.RWMutex
+	state
+	// While Load builds a new state, changes are recorded in pending as well
+	// and replayed onto it before it replaces the current one.
+	loading bool
+	pending []change
+
+	load sync.Mutex // serializes Load
+}
+
+type change struct {
+	user, book string
+	added      bool
+}
+
+type state struct {
+	owned  map[string]map[string]int // user -> book -> copies
+	owners map[string]int            // book -> users owning it
+	co     map[string]map[string]int // book -> book -> users owning both
+}
+
+func newState() state {
+	return state{
+		owned:  make(map[string]map[string]int),
+		owners: make(map[string]int),
+		co:     make(map[string]map[string]int),
+	}
+}
+
+func New() *Matrix {
+	return &Matrix{state: newState()}
+}
+
+// Lister is the part of repository.UserBookRepo that Load needs.
+type Lister interface {
+	ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error)
+}
+
+// Load replaces the matrix with one built from every stored entry. Changes
+// made while it runs are applied to the new matrix too; one the scan has
+// already read is counted twice, an extra copy the next Load drops.
+func (m *Matrix) Load(ctx context.Context, repo Lister) error {
+	m.load.Lock()
+	defer m.load.Unlock()
+
+	m.mu.Lock()
+	m.loading = true
+	m.mu.Unlock()
+
+	s, err := build(ctx, repo)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := m.pending
+	m.loading, m.pending = false, nil
+	if err != nil {
+		return err
+	}
+	for _, c := range pending {
+		if c.added {
+			s.add(c.user, c.book)
+		} else {
+			s.remove(c.user, c.book)
+		}
+	}
+	m.state = s
+	return nil
+}
+
+func build(ctx context.Context, repo Lister) (state, error) {
+	s := newState()
+	token := ""
+	for {
+		q, err := paging.New(paging.MaxSize, token, "", nil)
+		if err != nil {
+			return s, err
+		}
+		entries, next, err := repo.ListAllEntries(ctx, domain.EntryFilter{}, q)
+		if err != nil {
+			return s, err
+		}
+		for _, e := range entries {
+			s.add(e.UserID.Hex(), e.BookID.Hex())
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	return s, nil
+}
+
+// Follow keeps the matrix current from the library events of every replica.
+func (m *Matrix) Follow(nc *nats.Conn) error {
+	if _, err := events.Subscribe(nc, events.LibraryBookAssigned, func(e events.LibraryBookEvent) {
+		m.Add(e.UserID, e.BookID)
+	}); err != nil {
+		return err
+	}
+	_, err := events.Subscribe(nc, events.LibraryBookUnassigned, func(e events.LibraryBookEvent) {
+		m.Remove(e.UserID, e.BookID)
+	})
+	return err
+}
+
+// Add records one more copy of book in the library of user.
+func (m *Matrix) Add(user, book string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.add(user, book)
+	if m.loading {
+		m.pending = append(m.pending, change{user: user, book: book, added: true})
+	}
+}
+
+// Remove drops one copy of book from the library of user.
+func (m *Matrix) Remove(user, book string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(user, book)
+	if m.loading {
+		m.pending = append(m.pending, change{user: user, book: book})
+	}
+}
+
+func (s *state) add(user, book string) {
+	books := s.owned[user]
+	if books == nil {
+		books = make(map[string]int)
+		s.owned[user] = books
+	}
+	books[book]++
+	if books[book] > 1 {
+		return
+	}
+	s.owners[book]++
+	for other := range books {
+		if other != book {
+			s.link(book, other, 1)
+			s.link(other, book, 1)
+		}
+	}
+}
+
+func (s *state) remove(user, book string) {
+	books := s.owned[user]
+	if books[book] == 0 {
+		return
+	}
+	books[book]--
+	if books[book] > 0 {
+		return
+	}
+	delete(books, book)
+	if len(books) == 0 {
+		delete(s.owned, user)
+	}
+	if s.owners[book]--; s.owners[book] == 0 {
+		delete(s.owners, book)
+	}
+	for other := range books {
+		s.link(book, other, -1)
+		s.link(other, book, -1)
+	}
+}
+
+func (s *state) link(a, b string, delta int) {
+	row := s.co[a]
+	if row == nil {
+		row = make(map[string]int)
+		s.co[a] = row
+	}
+	row[b] += delta
+	if row[b] == 0 {
+		delete(row, b)
+		if len(row) == 0 {
+			delete(s.co, a)
+		}
+	}
+}
+
+// Owns reports whether user has at least one copy of book.
+func (m *Matrix) Owns(user, book string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.owned[user][book] > 0
+}
+
+// Recommend returns up to limit books owned together with the books of user
+// and not owned by user, best first.
+func (m *Matrix) Recommend(user string, limit int) []Scored {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	books := m.owned[user]
+	scores := make(map[string]float64)
+	for b := range books {
+		for c, n := range m.co[b] {
+			if books[c] > 0 {
+				continue
+			}
+			scores[c] += float64(n) / math.Sqrt(float64(m.owners[b]*m.owners[c]))
+		}
+	}
+
+	out := make([]Scored, 0, len(scores))
+	for id, s := range scores {
+		out = append(out, Scored{BookID: id, Score: s})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].BookID < out[j].BookID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package recommend
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
+)
+
+func library() *Matrix {
+	m := New()
+	m.Add("ann", "hobbit")
+	m.Add("ann", "silmarillion")
+	m.Add("bob", "hobbit")
+	m.Add("bob", "silmarillion")
+	m.Add("bob", "eragon")
+	m.Add("cat", "hobbit")
+	m.Add("cat", "eragon")
+	m.Add("cat", "cookbook")
+	m.Add("dan", "hobbit")
+	return m
+}
+
+func ids(s []Scored) []string {
+	var out []string
+	for _, r := range s {
+		out = append(out, r.BookID)
+	}
+	return out
+}
+
+func TestRecommend_RanksCoOwnedBooksTheUserLacks(t *testing.T) {
+	m := library()
+
+	got := m.Recommend("ann", 10)
+	if len(got) != 2 || got[0].BookID != "eragon" || got[1].BookID != "cookbook" {
+		t.Fatalf("expected eragon, then cookbook, got %v", ids(got))
+	}
+	if got[0].Score <= got[1].Score {
+		t.Errorf("expected descending scores, got %+v", got)
+	}
+	if got := m.Recommend("dan", 1); len(got) != 1 {
+		t.Errorf("expected the limit to apply, got %v", ids(got))
+	}
+	if got := m.Recommend("nobody", 10); len(got) != 0 {
+		t.Errorf("a user without books gets no co-owned books, got %v", ids(got))
+	}
+}
+
+func TestRemove_KeepsBookUntilLastCopyIsGone(t *testing.T) {
+	m := library()
+	m.Add("ann", "eragon")
+	m.Add("ann", "eragon")
+
+	m.Remove("ann", "eragon")
+	if !m.Owns("ann", "eragon") {
+		t.Fatal("a second copy must keep the book owned")
+	}
+	m.Remove("ann", "eragon")
+	if m.Owns("ann", "eragon") {
+		t.Fatal("the last copy removed must drop the book")
+	}
+	if got := m.Recommend("ann", 10); len(got) != 2 || got[0].BookID != "eragon" {
+		t.Errorf("expected eragon to be recommended again, got %v", ids(got))
+	}
+
+	m.Remove("cat", "cookbook")
+	if got := ids(m.Recommend("ann", 10)); len(got) != 1 || got[0] != "eragon" {
+		t.Errorf("a book nobody owns must not be recommended, got %v", got)
+	}
+}
+
+// midScan lists the stored entries, calling during before returning them as
+// if library events arrived while Load was scanning.
+type midScan struct {
+	entries []*domain.UserBook
+	during  func()
+}
+
+func (l *midScan) ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error) {
+	l.during()
+	return l.entries, "", nil
+}
+
+func TestLoad_KeepsChangesMadeWhileScanning(t *testing.T) {
+	ann, bob := primitive.NewObjectID(), primitive.NewObjectID()
+	hobbit, eragon, dune := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	m := New()
+	m.Add(ann.Hex(), hobbit.Hex())
+	m.Add(ann.Hex(), eragon.Hex())
+
+	// The scan sees ann's two books and bob's hobbit; meanwhile bob adds
+	// dune and ann drops eragon.
+	repo := &midScan{
+		entries: []*domain.UserBook{
+			{UserID: ann, BookID: hobbit},
+			{UserID: ann, BookID: eragon},
+			{UserID: bob, BookID: hobbit},
+		},
+		during: func() {
+			m.Add(bob.Hex(), dune.Hex())
+			m.Remove(ann.Hex(), eragon.Hex())
+		},
+	}
+	if err := m.Load(context.Background(), repo); err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Owns(bob.Hex(), dune.Hex()) || !m.Owns(bob.Hex(), hobbit.Hex()) {
+		t.Error("expected bob's scanned and newly added books")
+	}
+	if m.Owns(ann.Hex(), eragon.Hex()) {
+		t.Error("a book removed during the scan must stay removed")
+	}
+	if got := ids(m.Recommend(ann.Hex(), 10)); len(got) != 1 || got[0] != dune.Hex() {
+		t.Errorf("expected dune for ann, got %v", got)
+	}
+
+	if err := m.Load(context.Background(), &midScan{during: func() { m.Add(ann.Hex(), eragon.Hex()) }}); err != nil {
+		t.Fatal(err)
+	}
+	if !m.Owns(ann.Hex(), eragon.Hex()) {
+		t.Error("every Load must record the changes made during it")
+	}
+}
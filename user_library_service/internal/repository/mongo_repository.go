@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -36,8 +37,14 @@ func (r *mongoUserBookRepo) UnassignBook(ctx context.Context, userID, bookID str
 	if err != nil {
 		return err
 	}
-	_, err = r.coll.DeleteOne(ctx, bson.M{"user_id": uo, "book_id": bo})
-	return err
+	res, err := r.coll.DeleteOne(ctx, bson.M{"user_id": uo, "book_id": bo})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
 func (r *mongoUserBookRepo) ListUserBooks(ctx context.Context, userID string) ([]*domain.UserBook, error) {
@@ -103,22 +110,27 @@ func (r *mongoUserBookRepo) UpdateEntry(ctx context.Context, entry *domain.UserB
 	return &updated, nil
 }
 
-func (r *mongoUserBookRepo) ListAllEntries(ctx context.Context) ([]*domain.UserBook, error) {
-	cur, err := r.coll.Find(ctx, bson.M{})
+func (r *mongoUserBookRepo) ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error) {
+	filter := bson.M{}
+	if !f.UserID.IsZero() {
+		filter["user_id"] = f.UserID
+	}
+	if !f.BookID.IsZero() {
+		filter["book_id"] = f.BookID
+	}
+
+	filter, opts := q.Find(filter)
+	cur, err := r.coll.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer cur.Close(ctx)
 
 	var out []*domain.UserBook
-	for cur.Next(ctx) {
-		var u domain.UserBook
-		if err := cur.Decode(&u); err != nil {
-			return nil, err
-		}
-		out = append(out, &u)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, "", err
 	}
-	return out, nil
+	return paging.Page(q, out)
 }
 
 func (r *mongoUserBookRepo) ListByBook(ctx context.Context, bookID string) ([]*domain.UserBook, error) {
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoReservationRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoReservationRepo(db *mongo.Database) ReservationRepo {
+	return &mongoReservationRepo{coll: db.Collection("reservations")}
+}
+
+// Reserve relies on the unique (user_id, book_id) index: the upsert matches
+// the offer's own reservation, and inserting a second one for the book fails.
+func (r *mongoReservationRepo) Reserve(ctx context.Context, res *domain.Reservation) (bool, error) {
+	filter := bson.M{"user_id": res.UserID, "book_id": res.BookID, "offer_id": res.OfferID}
+	update := bson.M{"$setOnInsert": bson.M{"_id": res.ID, "created_at": res.CreatedAt}}
+	out, err := r.coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, ErrAlreadyReserved
+	}
+	if err != nil {
+		return false, err
+	}
+	return out.UpsertedCount > 0, nil
+}
+
+func (r *mongoReservationRepo) Holder(ctx context.Context, userID, bookID primitive.ObjectID) (*domain.Reservation, error) {
+	var res domain.Reservation
+	if err := r.coll.FindOne(ctx, bson.M{"user_id": userID, "book_id": bookID}).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *mongoReservationRepo) Release(ctx context.Context, offerID primitive.ObjectID, bookIDs []primitive.ObjectID) (int64, error) {
+	filter := bson.M{"offer_id": offerID}
+	if len(bookIDs) > 0 {
+		filter["book_id"] = bson.M{"$in": bookIDs}
+	}
+	res, err := r.coll.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
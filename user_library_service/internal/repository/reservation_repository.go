@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrAlreadyReserved is returned by Reserve when another offer holds the book.
+var ErrAlreadyReserved = errors.New("book is already reserved")
+
+type ReservationRepo interface {
+	// Reserve stores r unless the offer already holds the book; created
+	// reports whether a new reservation was written.
+	Reserve(ctx context.Context, r *domain.Reservation) (created bool, err error)
+	Holder(ctx context.Context, userID, bookID primitive.ObjectID) (*domain.Reservation, error)
+	// Release drops the offer's reservations for bookIDs, or all of them
+	// when bookIDs is empty.
+	Release(ctx context.Context, offerID primitive.ObjectID, bookIDs []primitive.ObjectID) (int64, error)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
 )
 
@@ -14,6 +15,8 @@ type UserBookRepo interface {
 	GetEntry(ctx context.Context, id string) (*domain.UserBook, error)
 	DeleteEntry(ctx context.Context, id string) error
 	UpdateEntry(ctx context.Context, entry *domain.UserBook) (*domain.UserBook, error)
-	ListAllEntries(ctx context.Context) ([]*domain.UserBook, error)
+	// ListAllEntries returns one page of the entries matching f and the
+	// token of the next page.
+	ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error)
 	ListByBook(ctx context.Context, bookID string) ([]*domain.UserBook, error)
 }
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	bookpb "github.com/OshakbayAigerim/read_space/book_service/proto"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/cache"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/recommend"
+)
+
+const (
+	DefaultRecommendLimit = 10
+	MaxRecommendLimit     = 50
+
+	// maxSeeds is how many of the user's latest books book_service is asked
+	// for similar books when co-ownership does not fill the list.
+	maxSeeds = 3
+)
+
+// RecommendUseCase suggests books a user does not own yet: first the books
+// other readers own together with theirs, then book_service's content-based
+// picks for their latest books, or its top rated books for a user without
+// any.
+type RecommendUseCase interface {
+	RecommendForUser(ctx context.Context, userID string, limit int) ([]*domain.Recommendation, error)
+}
+
+type recommendUseCase struct {
+	matrix *recommend.Matrix
+	cache  cache.UserLibraryCache
+	books  bookpb.BookServiceClient
+}
+
+func NewRecommendUseCase(m *recommend.Matrix, c cache.UserLibraryCache, bc bookpb.BookServiceClient) RecommendUseCase {
+	return &recommendUseCase{matrix: m, cache: c, books: bc}
+}
+
+func (u *recommendUseCase) RecommendForUser(ctx context.Context, userID string, limit int) ([]*domain.Recommendation, error) {
+	if limit <= 0 {
+		limit = DefaultRecommendLimit
+	} else if limit > MaxRecommendLimit {
+		limit = MaxRecommendLimit
+	}
+
+	// The library itself, not the matrix, decides what the user owns: the
+	// matrix may lag behind until the next event or refresh.
+	owned, err := u.cache.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(owned))
+	for _, e := range owned {
+		seen[e.BookID.Hex()] = true
+	}
+
+	var out []*domain.Recommendation
+	add := func(bookID string, score float64, source string) {
+		if len(out) < limit && !seen[bookID] {
+			seen[bookID] = true
+			out = append(out, &domain.Recommendation{BookID: bookID, Score: score, Source: source})
+		}
+	}
+
+	for _, s := range u.matrix.Recommend(userID, limit+len(owned)) {
+		add(s.BookID, s.Score, domain.SourceCoOwned)
+	}
+	if len(out) == limit {
+		return out, nil
+	}
+
+	if err := u.fill(ctx, owned, add); err != nil {
+		if len(out) == 0 {
+			return nil, err
+		}
+		log.Printf("⚠ content-based recommendations for user %s: %v", userID, err)
+	}
+	return out, nil
+}
+
+// fill passes book_service's picks to add: books similar to the latest
+// maxSeeds books of owned, or the top rated books when owned is empty.
+func (u *recommendUseCase) fill(ctx context.Context, owned []*domain.UserBook, add func(string, float64, string)) error {
+	if len(owned) == 0 {
+		resp, err := u.books.ListTopRatedBooks(ctx, &bookpb.Empty{})
+		if err != nil {
+			return err
+		}
+		for _, b := range resp.Books {
+			add(b.Id, 0, domain.SourceTopRated)
+		}
+		return nil
+	}
+
+	latest := append([]*domain.UserBook(nil), owned...)
+	sort.Slice(latest, func(i, j int) bool { return latest[i].ID.Hex() > latest[j].ID.Hex() })
+	if len(latest) > maxSeeds {
+		latest = latest[:maxSeeds]
+	}
+	for _, e := range latest {
+		resp, err := u.books.RecommendBooks(ctx, &bookpb.BookID{Id: e.BookID.Hex()})
+		if err != nil {
+			return err
+		}
+		for _, b := range resp.Books {
+			add(b.Id, 0, domain.SourceSimilar)
+		}
+	}
+	return nil
+}
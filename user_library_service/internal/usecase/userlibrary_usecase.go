@@ -2,32 +2,52 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/domain"
 	"github.com/OshakbayAigerim/read_space/user_library_service/internal/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// ReservedError is returned when a book cannot leave a library because a
+// pending exchange offer holds it.
+type ReservedError struct {
+	Reservation *domain.Reservation
+}
+
+func (e *ReservedError) Error() string {
+	return fmt.Sprintf("book %s is reserved by offer %s", e.Reservation.BookID.Hex(), e.Reservation.OfferID.Hex())
+}
+
 type UserLibraryUseCase interface {
 	AssignBook(ctx context.Context, userID, bookID string) (*domain.UserBook, error)
-	UnassignBook(ctx context.Context, userID, bookID string) error
+	UnassignBook(ctx context.Context, userID, bookID, offerID string) error
 	ListUserBooks(ctx context.Context, userID string) ([]*domain.UserBook, error)
 
 	GetEntry(ctx context.Context, id string) (*domain.UserBook, error)
 	DeleteEntry(ctx context.Context, id string) error
 	UpdateEntry(ctx context.Context, ub *domain.UserBook) (*domain.UserBook, error)
-	ListAllEntries(ctx context.Context) ([]*domain.UserBook, error)
+	ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error)
 	ListByBook(ctx context.Context, bookID string) ([]*domain.UserBook, error)
+
+	ReserveBooks(ctx context.Context, userID, offerID string, bookIDs []string) ([]*domain.Reservation, error)
+	ReleaseBooks(ctx context.Context, offerID string, bookIDs []string) (int64, error)
 }
 
 type userLibraryUseCase struct {
-	repo  repository.UserBookRepo
-	cache cache.UserLibraryCache
+	repo         repository.UserBookRepo
+	reservations repository.ReservationRepo
+	cache        cache.UserLibraryCache
 }
 
-func NewUserLibraryUseCase(repo repository.UserBookRepo, c cache.UserLibraryCache) UserLibraryUseCase {
-	return &userLibraryUseCase{repo: repo, cache: c}
+func NewUserLibraryUseCase(repo repository.UserBookRepo, reservations repository.ReservationRepo, c cache.UserLibraryCache) UserLibraryUseCase {
+	return &userLibraryUseCase{repo: repo, reservations: reservations, cache: c}
 }
 
 func (uc *userLibraryUseCase) AssignBook(ctx context.Context, userID, bookID string) (*domain.UserBook, error) {
@@ -54,7 +74,20 @@ func (uc *userLibraryUseCase) AssignBook(ctx context.Context, userID, bookID str
 	return assigned, nil
 }
 
-func (uc *userLibraryUseCase) UnassignBook(ctx context.Context, userID, bookID string) error {
+// UnassignBook removes a book from the library. A reserved book can only be
+// removed by the offer that reserved it.
+func (uc *userLibraryUseCase) UnassignBook(ctx context.Context, userID, bookID, offerID string) error {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	bid, err := primitive.ObjectIDFromHex(bookID)
+	if err != nil {
+		return err
+	}
+	if err := uc.checkNotReserved(ctx, uid, bid, offerID); err != nil {
+		return err
+	}
 	if err := uc.repo.UnassignBook(ctx, userID, bookID); err != nil {
 		return err
 	}
@@ -71,10 +104,26 @@ func (uc *userLibraryUseCase) GetEntry(ctx context.Context, id string) (*domain.
 }
 
 func (uc *userLibraryUseCase) DeleteEntry(ctx context.Context, id string) error {
+	e, err := uc.repo.GetEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.checkNotReserved(ctx, e.UserID, e.BookID, ""); err != nil {
+		return err
+	}
 	return uc.repo.DeleteEntry(ctx, id)
 }
 
 func (uc *userLibraryUseCase) UpdateEntry(ctx context.Context, ub *domain.UserBook) (*domain.UserBook, error) {
+	current, err := uc.repo.GetEntry(ctx, ub.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if current.UserID != ub.UserID || current.BookID != ub.BookID {
+		if err := uc.checkNotReserved(ctx, current.UserID, current.BookID, ""); err != nil {
+			return nil, err
+		}
+	}
 	updated, err := uc.repo.UpdateEntry(ctx, ub)
 	if err != nil {
 		return nil, err
@@ -83,10 +132,111 @@ func (uc *userLibraryUseCase) UpdateEntry(ctx context.Context, ub *domain.UserBo
 	return updated, nil
 }
 
-func (uc *userLibraryUseCase) ListAllEntries(ctx context.Context) ([]*domain.UserBook, error) {
-	return uc.repo.ListAllEntries(ctx)
+func (uc *userLibraryUseCase) ListAllEntries(ctx context.Context, f domain.EntryFilter, q *paging.Query) ([]*domain.UserBook, string, error) {
+	return uc.repo.ListAllEntries(ctx, f, q)
 }
 
 func (uc *userLibraryUseCase) ListByBook(ctx context.Context, bookID string) ([]*domain.UserBook, error) {
 	return uc.repo.ListByBook(ctx, bookID)
 }
+
+// ReserveBooks holds the books in userID's library for offerID. It is all or
+// nothing: when any book is held by another offer, the reservations made by
+// this call are dropped and the conflicting ones are returned.
+func (uc *userLibraryUseCase) ReserveBooks(ctx context.Context, userID, offerID string, bookIDs []string) ([]*domain.Reservation, error) {
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	oid, err := primitive.ObjectIDFromHex(offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created, conflicts []*domain.Reservation
+	var failure error
+	for _, b := range bookIDs {
+		bid, err := primitive.ObjectIDFromHex(b)
+		if err != nil {
+			failure = err
+			break
+		}
+		r := &domain.Reservation{
+			ID:        primitive.NewObjectID(),
+			UserID:    uid,
+			BookID:    bid,
+			OfferID:   oid,
+			CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+		}
+		ok, err := uc.reservations.Reserve(ctx, r)
+		if errors.Is(err, repository.ErrAlreadyReserved) {
+			holder, herr := uc.reservations.Holder(ctx, uid, bid)
+			if herr != nil {
+				failure = herr
+				break
+			}
+			conflicts = append(conflicts, holder)
+			continue
+		}
+		if err != nil {
+			failure = err
+			break
+		}
+		if ok {
+			created = append(created, r)
+		}
+	}
+
+	if failure != nil || len(conflicts) > 0 {
+		uc.undoReservations(ctx, oid, created)
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	return conflicts, nil
+}
+
+func (uc *userLibraryUseCase) ReleaseBooks(ctx context.Context, offerID string, bookIDs []string) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(offerID)
+	if err != nil {
+		return 0, err
+	}
+	bids := make([]primitive.ObjectID, 0, len(bookIDs))
+	for _, b := range bookIDs {
+		bid, err := primitive.ObjectIDFromHex(b)
+		if err != nil {
+			return 0, err
+		}
+		bids = append(bids, bid)
+	}
+	return uc.reservations.Release(ctx, oid, bids)
+}
+
+func (uc *userLibraryUseCase) undoReservations(ctx context.Context, offerID primitive.ObjectID, created []*domain.Reservation) {
+	if len(created) == 0 {
+		return
+	}
+	bids := make([]primitive.ObjectID, len(created))
+	for i, r := range created {
+		bids[i] = r.BookID
+	}
+	if _, err := uc.reservations.Release(context.WithoutCancel(ctx), offerID, bids); err != nil {
+		log.Printf("cannot undo reservations of offer %s: %v", offerID.Hex(), err)
+	}
+}
+
+// checkNotReserved fails with ReservedError when the book is held by an
+// offer other than offerID.
+func (uc *userLibraryUseCase) checkNotReserved(ctx context.Context, userID, bookID primitive.ObjectID, offerID string) error {
+	holder, err := uc.reservations.Holder(ctx, userID, bookID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if holder.OfferID.Hex() != offerID {
+		return &ReservedError{Reservation: holder}
+	}
+	return nil
+}
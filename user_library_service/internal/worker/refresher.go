@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/OshakbayAigerim/read_space/user_library_service/internal/recommend"
+)
+
+// MatrixRefresher periodically rebuilds the co-ownership matrix from Mongo,
+// picking up the entries updated or deleted without a library book event.
+type MatrixRefresher struct {
+	matrix   *recommend.Matrix
+	repo     recommend.Lister
+	interval time.Duration
+}
+
+func NewMatrixRefresher(m *recommend.Matrix, repo recommend.Lister, interval time.Duration) *MatrixRefresher {
+	return &MatrixRefresher{matrix: m, repo: repo, interval: interval}
+}
+
+// Run refreshes every interval until ctx is done. The matrix is expected to
+// be loaded already.
+func (r *MatrixRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.matrix.Load(ctx, r.repo); err != nil {
+			log.Printf("⚠ co-ownership refresh: %v", err)
+		}
+	}
+}
@@ -21,12 +21,11 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Запись «пользователь-владеет-книгой»
 type UserBook struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // ID записи
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя
-	BookId        string                 `protobuf:"bytes,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"` // ID книги из каталога
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,7 +81,6 @@ func (x *UserBook) GetBookId() string {
 	return ""
 }
 
-// Запросы
 type AssignBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -136,9 +134,11 @@ func (x *AssignBookRequest) GetBookId() string {
 }
 
 type UnassignBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	// offer_id lets the exchange that reserved the book move it.
+	OfferId       string `protobuf:"bytes,3,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -187,6 +187,13 @@ func (x *UnassignBookRequest) GetBookId() string {
 	return ""
 }
 
+func (x *UnassignBookRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
 type ListUserBooksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -231,7 +238,182 @@ func (x *ListUserBooksRequest) GetUserId() string {
 	return ""
 }
 
-// Ответы
+type GetEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntryRequest) Reset() {
+	*x = GetEntryRequest{}
+	mi := &file_userlibrary_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntryRequest) ProtoMessage() {}
+
+func (x *GetEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEntryRequest) Reset() {
+	*x = DeleteEntryRequest{}
+	mi := &file_userlibrary_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEntryRequest) ProtoMessage() {}
+
+func (x *DeleteEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteEntryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *UserBook              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEntryRequest) Reset() {
+	*x = UpdateEntryRequest{}
+	mi := &file_userlibrary_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntryRequest) ProtoMessage() {}
+
+func (x *UpdateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateEntryRequest) GetEntry() *UserBook {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type ListByBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListByBookRequest) Reset() {
+	*x = ListByBookRequest{}
+	mi := &file_userlibrary_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListByBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListByBookRequest) ProtoMessage() {}
+
+func (x *ListByBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListByBookRequest.ProtoReflect.Descriptor instead.
+func (*ListByBookRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListByBookRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
 type AssignBookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Entry         *UserBook              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
@@ -241,7 +423,7 @@ type AssignBookResponse struct {
 
 func (x *AssignBookResponse) Reset() {
 	*x = AssignBookResponse{}
-	mi := &file_userlibrary_proto_msgTypes[4]
+	mi := &file_userlibrary_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +435,7 @@ func (x *AssignBookResponse) String() string {
 func (*AssignBookResponse) ProtoMessage() {}
 
 func (x *AssignBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_userlibrary_proto_msgTypes[4]
+	mi := &file_userlibrary_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +448,7 @@ func (x *AssignBookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignBookResponse.ProtoReflect.Descriptor instead.
 func (*AssignBookResponse) Descriptor() ([]byte, []int) {
-	return file_userlibrary_proto_rawDescGZIP(), []int{4}
+	return file_userlibrary_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AssignBookResponse) GetEntry() *UserBook {
@@ -285,7 +467,7 @@ type UnassignBookResponse struct {
 
 func (x *UnassignBookResponse) Reset() {
 	*x = UnassignBookResponse{}
-	mi := &file_userlibrary_proto_msgTypes[5]
+	mi := &file_userlibrary_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +479,7 @@ func (x *UnassignBookResponse) String() string {
 func (*UnassignBookResponse) ProtoMessage() {}
 
 func (x *UnassignBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_userlibrary_proto_msgTypes[5]
+	mi := &file_userlibrary_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +492,7 @@ func (x *UnassignBookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnassignBookResponse.ProtoReflect.Descriptor instead.
 func (*UnassignBookResponse) Descriptor() ([]byte, []int) {
-	return file_userlibrary_proto_rawDescGZIP(), []int{5}
+	return file_userlibrary_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UnassignBookResponse) GetSuccess() bool {
@@ -321,15 +503,17 @@ func (x *UnassignBookResponse) GetSuccess() bool {
 }
 
 type ListUserBooksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*UserBook            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*UserBook            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Only set by ListAllEntries; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUserBooksResponse) Reset() {
 	*x = ListUserBooksResponse{}
-	mi := &file_userlibrary_proto_msgTypes[6]
+	mi := &file_userlibrary_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -341,7 +525,7 @@ func (x *ListUserBooksResponse) String() string {
 func (*ListUserBooksResponse) ProtoMessage() {}
 
 func (x *ListUserBooksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_userlibrary_proto_msgTypes[6]
+	mi := &file_userlibrary_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +538,7 @@ func (x *ListUserBooksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUserBooksResponse.ProtoReflect.Descriptor instead.
 func (*ListUserBooksResponse) Descriptor() ([]byte, []int) {
-	return file_userlibrary_proto_rawDescGZIP(), []int{6}
+	return file_userlibrary_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListUserBooksResponse) GetEntries() []*UserBook {
@@ -364,6 +548,523 @@ func (x *ListUserBooksResponse) GetEntries() []*UserBook {
 	return nil
 }
 
+func (x *ListUserBooksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ListEntriesRequest pages through all library entries. sort is user_id or
+// book_id, prefixed with "-" for descending order; the default is the order
+// the books were added in.
+type ListEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,5,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesRequest) Reset() {
+	*x = ListEntriesRequest{}
+	mi := &file_userlibrary_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesRequest) ProtoMessage() {}
+
+func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEntriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListEntriesRequest) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookId        string                 `protobuf:"bytes,2,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,3,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_userlibrary_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Reservation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Reservation) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *Reservation) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *Reservation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ReserveBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BookIds       []string               `protobuf:"bytes,2,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	OfferId       string                 `protobuf:"bytes,3,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveBooksRequest) Reset() {
+	*x = ReserveBooksRequest{}
+	mi := &file_userlibrary_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveBooksRequest) ProtoMessage() {}
+
+func (x *ReserveBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveBooksRequest.ProtoReflect.Descriptor instead.
+func (*ReserveBooksRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReserveBooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReserveBooksRequest) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+func (x *ReserveBooksRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+// Books already held by another offer; when non-empty nothing was reserved.
+type ReserveBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conflicts     []*Reservation         `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveBooksResponse) Reset() {
+	*x = ReserveBooksResponse{}
+	mi := &file_userlibrary_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveBooksResponse) ProtoMessage() {}
+
+func (x *ReserveBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveBooksResponse.ProtoReflect.Descriptor instead.
+func (*ReserveBooksResponse) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReserveBooksResponse) GetConflicts() []*Reservation {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+// An empty book_ids releases every book held by the offer.
+type ReleaseBooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	BookIds       []string               `protobuf:"bytes,2,rep,name=book_ids,json=bookIds,proto3" json:"book_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseBooksRequest) Reset() {
+	*x = ReleaseBooksRequest{}
+	mi := &file_userlibrary_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseBooksRequest) ProtoMessage() {}
+
+func (x *ReleaseBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseBooksRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseBooksRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReleaseBooksRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *ReleaseBooksRequest) GetBookIds() []string {
+	if x != nil {
+		return x.BookIds
+	}
+	return nil
+}
+
+type ReleaseBooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Released      int64                  `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseBooksResponse) Reset() {
+	*x = ReleaseBooksResponse{}
+	mi := &file_userlibrary_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseBooksResponse) ProtoMessage() {}
+
+func (x *ReleaseBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseBooksResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseBooksResponse) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReleaseBooksResponse) GetReleased() int64 {
+	if x != nil {
+		return x.Released
+	}
+	return 0
+}
+
+// RecommendForUserRequest asks for books user_id does not own yet. limit
+// defaults to 10 and is capped at 50.
+type RecommendForUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendForUserRequest) Reset() {
+	*x = RecommendForUserRequest{}
+	mi := &file_userlibrary_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendForUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendForUserRequest) ProtoMessage() {}
+
+func (x *RecommendForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendForUserRequest.ProtoReflect.Descriptor instead.
+func (*RecommendForUserRequest) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecommendForUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecommendForUserRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// source is "co_owned" for books owned together with the user's books,
+// "similar" for book_service's content-based picks and "top_rated" for users
+// without books. Only co_owned results carry a score.
+type BookRecommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BookId        string                 `protobuf:"bytes,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookRecommendation) Reset() {
+	*x = BookRecommendation{}
+	mi := &file_userlibrary_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookRecommendation) ProtoMessage() {}
+
+func (x *BookRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookRecommendation.ProtoReflect.Descriptor instead.
+func (*BookRecommendation) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BookRecommendation) GetBookId() string {
+	if x != nil {
+		return x.BookId
+	}
+	return ""
+}
+
+func (x *BookRecommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *BookRecommendation) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type RecommendForUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Books         []*BookRecommendation  `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendForUserResponse) Reset() {
+	*x = RecommendForUserResponse{}
+	mi := &file_userlibrary_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendForUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendForUserResponse) ProtoMessage() {}
+
+func (x *RecommendForUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userlibrary_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendForUserResponse.ProtoReflect.Descriptor instead.
+func (*RecommendForUserResponse) Descriptor() ([]byte, []int) {
+	return file_userlibrary_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecommendForUserResponse) GetBooks() []*BookRecommendation {
+	if x != nil {
+		return x.Books
+	}
+	return nil
+}
+
 var File_userlibrary_proto protoreflect.FileDescriptor
 
 const file_userlibrary_proto_rawDesc = "" +
@@ -375,23 +1076,75 @@ const file_userlibrary_proto_rawDesc = "" +
 	"\abook_id\x18\x03 \x01(\tR\x06bookId\"E\n" +
 	"\x11AssignBookRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\abook_id\x18\x02 \x01(\tR\x06bookId\"G\n" +
+	"\abook_id\x18\x02 \x01(\tR\x06bookId\"b\n" +
 	"\x13UnassignBookRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
-	"\abook_id\x18\x02 \x01(\tR\x06bookId\"/\n" +
+	"\abook_id\x18\x02 \x01(\tR\x06bookId\x12\x19\n" +
+	"\boffer_id\x18\x03 \x01(\tR\aofferId\"/\n" +
 	"\x14ListUserBooksRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"A\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"!\n" +
+	"\x0fGetEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\x12DeleteEntryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x12UpdateEntryRequest\x12+\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.userlibrary.UserBookR\x05entry\",\n" +
+	"\x11ListByBookRequest\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\"A\n" +
 	"\x12AssignBookResponse\x12+\n" +
 	"\x05entry\x18\x01 \x01(\v2\x15.userlibrary.UserBookR\x05entry\"0\n" +
 	"\x14UnassignBookResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"H\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"p\n" +
 	"\x15ListUserBooksResponse\x12/\n" +
-	"\aentries\x18\x01 \x03(\v2\x15.userlibrary.UserBookR\aentries2\x90\x02\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.userlibrary.UserBookR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x96\x01\n" +
+	"\x12ListEntriesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x17\n" +
+	"\abook_id\x18\x05 \x01(\tR\x06bookId\"y\n" +
+	"\vReservation\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\abook_id\x18\x02 \x01(\tR\x06bookId\x12\x19\n" +
+	"\boffer_id\x18\x03 \x01(\tR\aofferId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"d\n" +
+	"\x13ReserveBooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bbook_ids\x18\x02 \x03(\tR\abookIds\x12\x19\n" +
+	"\boffer_id\x18\x03 \x01(\tR\aofferId\"N\n" +
+	"\x14ReserveBooksResponse\x126\n" +
+	"\tconflicts\x18\x01 \x03(\v2\x18.userlibrary.ReservationR\tconflicts\"K\n" +
+	"\x13ReleaseBooksRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x19\n" +
+	"\bbook_ids\x18\x02 \x03(\tR\abookIds\"2\n" +
+	"\x14ReleaseBooksResponse\x12\x1a\n" +
+	"\breleased\x18\x01 \x01(\x03R\breleased\"H\n" +
+	"\x17RecommendForUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"[\n" +
+	"\x12BookRecommendation\x12\x17\n" +
+	"\abook_id\x18\x01 \x01(\tR\x06bookId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"Q\n" +
+	"\x18RecommendForUserResponse\x125\n" +
+	"\x05books\x18\x01 \x03(\v2\x1f.userlibrary.BookRecommendationR\x05books2\xb3\a\n" +
 	"\x12UserLibraryService\x12M\n" +
 	"\n" +
 	"AssignBook\x12\x1e.userlibrary.AssignBookRequest\x1a\x1f.userlibrary.AssignBookResponse\x12S\n" +
 	"\fUnassignBook\x12 .userlibrary.UnassignBookRequest\x1a!.userlibrary.UnassignBookResponse\x12V\n" +
-	"\rListUserBooks\x12!.userlibrary.ListUserBooksRequest\x1a\".userlibrary.ListUserBooksResponseB^Z\\github.com/OshakbayAigerim/read_space/user_library_service/proto/userlibrarypb;userlibrarypbb\x06proto3"
+	"\rListUserBooks\x12!.userlibrary.ListUserBooksRequest\x1a\".userlibrary.ListUserBooksResponse\x12I\n" +
+	"\bGetEntry\x12\x1c.userlibrary.GetEntryRequest\x1a\x1f.userlibrary.AssignBookResponse\x12Q\n" +
+	"\vDeleteEntry\x12\x1f.userlibrary.DeleteEntryRequest\x1a!.userlibrary.UnassignBookResponse\x12O\n" +
+	"\vUpdateEntry\x12\x1f.userlibrary.UpdateEntryRequest\x1a\x1f.userlibrary.AssignBookResponse\x12U\n" +
+	"\x0eListAllEntries\x12\x1f.userlibrary.ListEntriesRequest\x1a\".userlibrary.ListUserBooksResponse\x12P\n" +
+	"\n" +
+	"ListByBook\x12\x1e.userlibrary.ListByBookRequest\x1a\".userlibrary.ListUserBooksResponse\x12S\n" +
+	"\fReserveBooks\x12 .userlibrary.ReserveBooksRequest\x1a!.userlibrary.ReserveBooksResponse\x12S\n" +
+	"\fReleaseBooks\x12 .userlibrary.ReleaseBooksRequest\x1a!.userlibrary.ReleaseBooksResponse\x12_\n" +
+	"\x10RecommendForUser\x12$.userlibrary.RecommendForUserRequest\x1a%.userlibrary.RecommendForUserResponseB^Z\\github.com/OshakbayAigerim/read_space/user_library_service/proto/userlibrarypb;userlibrarypbb\x06proto3"
 
 var (
 	file_userlibrary_proto_rawDescOnce sync.Once
@@ -405,30 +1158,62 @@ func file_userlibrary_proto_rawDescGZIP() []byte {
 	return file_userlibrary_proto_rawDescData
 }
 
-var file_userlibrary_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_userlibrary_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_userlibrary_proto_goTypes = []any{
-	(*UserBook)(nil),              // 0: userlibrary.UserBook
-	(*AssignBookRequest)(nil),     // 1: userlibrary.AssignBookRequest
-	(*UnassignBookRequest)(nil),   // 2: userlibrary.UnassignBookRequest
-	(*ListUserBooksRequest)(nil),  // 3: userlibrary.ListUserBooksRequest
-	(*AssignBookResponse)(nil),    // 4: userlibrary.AssignBookResponse
-	(*UnassignBookResponse)(nil),  // 5: userlibrary.UnassignBookResponse
-	(*ListUserBooksResponse)(nil), // 6: userlibrary.ListUserBooksResponse
+	(*UserBook)(nil),                 // 0: userlibrary.UserBook
+	(*AssignBookRequest)(nil),        // 1: userlibrary.AssignBookRequest
+	(*UnassignBookRequest)(nil),      // 2: userlibrary.UnassignBookRequest
+	(*ListUserBooksRequest)(nil),     // 3: userlibrary.ListUserBooksRequest
+	(*GetEntryRequest)(nil),          // 4: userlibrary.GetEntryRequest
+	(*DeleteEntryRequest)(nil),       // 5: userlibrary.DeleteEntryRequest
+	(*UpdateEntryRequest)(nil),       // 6: userlibrary.UpdateEntryRequest
+	(*ListByBookRequest)(nil),        // 7: userlibrary.ListByBookRequest
+	(*AssignBookResponse)(nil),       // 8: userlibrary.AssignBookResponse
+	(*UnassignBookResponse)(nil),     // 9: userlibrary.UnassignBookResponse
+	(*ListUserBooksResponse)(nil),    // 10: userlibrary.ListUserBooksResponse
+	(*ListEntriesRequest)(nil),       // 11: userlibrary.ListEntriesRequest
+	(*Reservation)(nil),              // 12: userlibrary.Reservation
+	(*ReserveBooksRequest)(nil),      // 13: userlibrary.ReserveBooksRequest
+	(*ReserveBooksResponse)(nil),     // 14: userlibrary.ReserveBooksResponse
+	(*ReleaseBooksRequest)(nil),      // 15: userlibrary.ReleaseBooksRequest
+	(*ReleaseBooksResponse)(nil),     // 16: userlibrary.ReleaseBooksResponse
+	(*RecommendForUserRequest)(nil),  // 17: userlibrary.RecommendForUserRequest
+	(*BookRecommendation)(nil),       // 18: userlibrary.BookRecommendation
+	(*RecommendForUserResponse)(nil), // 19: userlibrary.RecommendForUserResponse
 }
 var file_userlibrary_proto_depIdxs = []int32{
-	0, // 0: userlibrary.AssignBookResponse.entry:type_name -> userlibrary.UserBook
-	0, // 1: userlibrary.ListUserBooksResponse.entries:type_name -> userlibrary.UserBook
-	1, // 2: userlibrary.UserLibraryService.AssignBook:input_type -> userlibrary.AssignBookRequest
-	2, // 3: userlibrary.UserLibraryService.UnassignBook:input_type -> userlibrary.UnassignBookRequest
-	3, // 4: userlibrary.UserLibraryService.ListUserBooks:input_type -> userlibrary.ListUserBooksRequest
-	4, // 5: userlibrary.UserLibraryService.AssignBook:output_type -> userlibrary.AssignBookResponse
-	5, // 6: userlibrary.UserLibraryService.UnassignBook:output_type -> userlibrary.UnassignBookResponse
-	6, // 7: userlibrary.UserLibraryService.ListUserBooks:output_type -> userlibrary.ListUserBooksResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: userlibrary.UpdateEntryRequest.entry:type_name -> userlibrary.UserBook
+	0,  // 1: userlibrary.AssignBookResponse.entry:type_name -> userlibrary.UserBook
+	0,  // 2: userlibrary.ListUserBooksResponse.entries:type_name -> userlibrary.UserBook
+	12, // 3: userlibrary.ReserveBooksResponse.conflicts:type_name -> userlibrary.Reservation
+	18, // 4: userlibrary.RecommendForUserResponse.books:type_name -> userlibrary.BookRecommendation
+	1,  // 5: userlibrary.UserLibraryService.AssignBook:input_type -> userlibrary.AssignBookRequest
+	2,  // 6: userlibrary.UserLibraryService.UnassignBook:input_type -> userlibrary.UnassignBookRequest
+	3,  // 7: userlibrary.UserLibraryService.ListUserBooks:input_type -> userlibrary.ListUserBooksRequest
+	4,  // 8: userlibrary.UserLibraryService.GetEntry:input_type -> userlibrary.GetEntryRequest
+	5,  // 9: userlibrary.UserLibraryService.DeleteEntry:input_type -> userlibrary.DeleteEntryRequest
+	6,  // 10: userlibrary.UserLibraryService.UpdateEntry:input_type -> userlibrary.UpdateEntryRequest
+	11, // 11: userlibrary.UserLibraryService.ListAllEntries:input_type -> userlibrary.ListEntriesRequest
+	7,  // 12: userlibrary.UserLibraryService.ListByBook:input_type -> userlibrary.ListByBookRequest
+	13, // 13: userlibrary.UserLibraryService.ReserveBooks:input_type -> userlibrary.ReserveBooksRequest
+	15, // 14: userlibrary.UserLibraryService.ReleaseBooks:input_type -> userlibrary.ReleaseBooksRequest
+	17, // 15: userlibrary.UserLibraryService.RecommendForUser:input_type -> userlibrary.RecommendForUserRequest
+	8,  // 16: userlibrary.UserLibraryService.AssignBook:output_type -> userlibrary.AssignBookResponse
+	9,  // 17: userlibrary.UserLibraryService.UnassignBook:output_type -> userlibrary.UnassignBookResponse
+	10, // 18: userlibrary.UserLibraryService.ListUserBooks:output_type -> userlibrary.ListUserBooksResponse
+	8,  // 19: userlibrary.UserLibraryService.GetEntry:output_type -> userlibrary.AssignBookResponse
+	9,  // 20: userlibrary.UserLibraryService.DeleteEntry:output_type -> userlibrary.UnassignBookResponse
+	8,  // 21: userlibrary.UserLibraryService.UpdateEntry:output_type -> userlibrary.AssignBookResponse
+	10, // 22: userlibrary.UserLibraryService.ListAllEntries:output_type -> userlibrary.ListUserBooksResponse
+	10, // 23: userlibrary.UserLibraryService.ListByBook:output_type -> userlibrary.ListUserBooksResponse
+	14, // 24: userlibrary.UserLibraryService.ReserveBooks:output_type -> userlibrary.ReserveBooksResponse
+	16, // 25: userlibrary.UserLibraryService.ReleaseBooks:output_type -> userlibrary.ReleaseBooksResponse
+	19, // 26: userlibrary.UserLibraryService.RecommendForUser:output_type -> userlibrary.RecommendForUserResponse
+	16, // [16:27] is the sub-list for method output_type
+	5,  // [5:16] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_userlibrary_proto_init() }
@@ -442,7 +1227,7 @@ func file_userlibrary_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_userlibrary_proto_rawDesc), len(file_userlibrary_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
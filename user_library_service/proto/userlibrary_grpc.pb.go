@@ -19,20 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserLibraryService_AssignBook_FullMethodName    = "/userlibrary.UserLibraryService/AssignBook"
-	UserLibraryService_UnassignBook_FullMethodName  = "/userlibrary.UserLibraryService/UnassignBook"
-	UserLibraryService_ListUserBooks_FullMethodName = "/userlibrary.UserLibraryService/ListUserBooks"
+	UserLibraryService_AssignBook_FullMethodName       = "/userlibrary.UserLibraryService/AssignBook"
+	UserLibraryService_UnassignBook_FullMethodName     = "/userlibrary.UserLibraryService/UnassignBook"
+	UserLibraryService_ListUserBooks_FullMethodName    = "/userlibrary.UserLibraryService/ListUserBooks"
+	UserLibraryService_GetEntry_FullMethodName         = "/userlibrary.UserLibraryService/GetEntry"
+	UserLibraryService_DeleteEntry_FullMethodName      = "/userlibrary.UserLibraryService/DeleteEntry"
+	UserLibraryService_UpdateEntry_FullMethodName      = "/userlibrary.UserLibraryService/UpdateEntry"
+	UserLibraryService_ListAllEntries_FullMethodName   = "/userlibrary.UserLibraryService/ListAllEntries"
+	UserLibraryService_ListByBook_FullMethodName       = "/userlibrary.UserLibraryService/ListByBook"
+	UserLibraryService_ReserveBooks_FullMethodName     = "/userlibrary.UserLibraryService/ReserveBooks"
+	UserLibraryService_ReleaseBooks_FullMethodName     = "/userlibrary.UserLibraryService/ReleaseBooks"
+	UserLibraryService_RecommendForUser_FullMethodName = "/userlibrary.UserLibraryService/RecommendForUser"
 )
 
 // UserLibraryServiceClient is the client API for UserLibraryService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-//
-// Сервис
 type UserLibraryServiceClient interface {
 	AssignBook(ctx context.Context, in *AssignBookRequest, opts ...grpc.CallOption) (*AssignBookResponse, error)
 	UnassignBook(ctx context.Context, in *UnassignBookRequest, opts ...grpc.CallOption) (*UnassignBookResponse, error)
 	ListUserBooks(ctx context.Context, in *ListUserBooksRequest, opts ...grpc.CallOption) (*ListUserBooksResponse, error)
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*AssignBookResponse, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*UnassignBookResponse, error)
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*AssignBookResponse, error)
+	ListAllEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListUserBooksResponse, error)
+	ListByBook(ctx context.Context, in *ListByBookRequest, opts ...grpc.CallOption) (*ListUserBooksResponse, error)
+	ReserveBooks(ctx context.Context, in *ReserveBooksRequest, opts ...grpc.CallOption) (*ReserveBooksResponse, error)
+	ReleaseBooks(ctx context.Context, in *ReleaseBooksRequest, opts ...grpc.CallOption) (*ReleaseBooksResponse, error)
+	RecommendForUser(ctx context.Context, in *RecommendForUserRequest, opts ...grpc.CallOption) (*RecommendForUserResponse, error)
 }
 
 type userLibraryServiceClient struct {
@@ -73,15 +87,101 @@ func (c *userLibraryServiceClient) ListUserBooks(ctx context.Context, in *ListUs
 	return out, nil
 }
 
+func (c *userLibraryServiceClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*AssignBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignBookResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_GetEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*UnassignBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnassignBookResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_DeleteEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*AssignBookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignBookResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_UpdateEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) ListAllEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListUserBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserBooksResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_ListAllEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) ListByBook(ctx context.Context, in *ListByBookRequest, opts ...grpc.CallOption) (*ListUserBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserBooksResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_ListByBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) ReserveBooks(ctx context.Context, in *ReserveBooksRequest, opts ...grpc.CallOption) (*ReserveBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveBooksResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_ReserveBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) ReleaseBooks(ctx context.Context, in *ReleaseBooksRequest, opts ...grpc.CallOption) (*ReleaseBooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseBooksResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_ReleaseBooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userLibraryServiceClient) RecommendForUser(ctx context.Context, in *RecommendForUserRequest, opts ...grpc.CallOption) (*RecommendForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendForUserResponse)
+	err := c.cc.Invoke(ctx, UserLibraryService_RecommendForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserLibraryServiceServer is the server API for UserLibraryService service.
 // All implementations must embed UnimplementedUserLibraryServiceServer
 // for forward compatibility.
-//
-// Сервис
 type UserLibraryServiceServer interface {
 	AssignBook(context.Context, *AssignBookRequest) (*AssignBookResponse, error)
 	UnassignBook(context.Context, *UnassignBookRequest) (*UnassignBookResponse, error)
 	ListUserBooks(context.Context, *ListUserBooksRequest) (*ListUserBooksResponse, error)
+	GetEntry(context.Context, *GetEntryRequest) (*AssignBookResponse, error)
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*UnassignBookResponse, error)
+	UpdateEntry(context.Context, *UpdateEntryRequest) (*AssignBookResponse, error)
+	ListAllEntries(context.Context, *ListEntriesRequest) (*ListUserBooksResponse, error)
+	ListByBook(context.Context, *ListByBookRequest) (*ListUserBooksResponse, error)
+	ReserveBooks(context.Context, *ReserveBooksRequest) (*ReserveBooksResponse, error)
+	ReleaseBooks(context.Context, *ReleaseBooksRequest) (*ReleaseBooksResponse, error)
+	RecommendForUser(context.Context, *RecommendForUserRequest) (*RecommendForUserResponse, error)
 	mustEmbedUnimplementedUserLibraryServiceServer()
 }
 
@@ -101,6 +201,30 @@ func (UnimplementedUserLibraryServiceServer) UnassignBook(context.Context, *Unas
 func (UnimplementedUserLibraryServiceServer) ListUserBooks(context.Context, *ListUserBooksRequest) (*ListUserBooksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserBooks not implemented")
 }
+func (UnimplementedUserLibraryServiceServer) GetEntry(context.Context, *GetEntryRequest) (*AssignBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntry not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) DeleteEntry(context.Context, *DeleteEntryRequest) (*UnassignBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntry not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) UpdateEntry(context.Context, *UpdateEntryRequest) (*AssignBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntry not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) ListAllEntries(context.Context, *ListEntriesRequest) (*ListUserBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllEntries not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) ListByBook(context.Context, *ListByBookRequest) (*ListUserBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListByBook not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) ReserveBooks(context.Context, *ReserveBooksRequest) (*ReserveBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveBooks not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) ReleaseBooks(context.Context, *ReleaseBooksRequest) (*ReleaseBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseBooks not implemented")
+}
+func (UnimplementedUserLibraryServiceServer) RecommendForUser(context.Context, *RecommendForUserRequest) (*RecommendForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendForUser not implemented")
+}
 func (UnimplementedUserLibraryServiceServer) mustEmbedUnimplementedUserLibraryServiceServer() {}
 func (UnimplementedUserLibraryServiceServer) testEmbeddedByValue()                            {}
 
@@ -176,6 +300,150 @@ func _UserLibraryService_ListUserBooks_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserLibraryService_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_GetEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).DeleteEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_DeleteEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).DeleteEntry(ctx, req.(*DeleteEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).UpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_UpdateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_ListAllEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).ListAllEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_ListAllEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).ListAllEntries(ctx, req.(*ListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_ListByBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListByBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).ListByBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_ListByBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).ListByBook(ctx, req.(*ListByBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_ReserveBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).ReserveBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_ReserveBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).ReserveBooks(ctx, req.(*ReserveBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_ReleaseBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).ReleaseBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_ReleaseBooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).ReleaseBooks(ctx, req.(*ReleaseBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserLibraryService_RecommendForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserLibraryServiceServer).RecommendForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserLibraryService_RecommendForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserLibraryServiceServer).RecommendForUser(ctx, req.(*RecommendForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserLibraryService_ServiceDesc is the grpc.ServiceDesc for UserLibraryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -195,6 +463,38 @@ var UserLibraryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserBooks",
 			Handler:    _UserLibraryService_ListUserBooks_Handler,
 		},
+		{
+			MethodName: "GetEntry",
+			Handler:    _UserLibraryService_GetEntry_Handler,
+		},
+		{
+			MethodName: "DeleteEntry",
+			Handler:    _UserLibraryService_DeleteEntry_Handler,
+		},
+		{
+			MethodName: "UpdateEntry",
+			Handler:    _UserLibraryService_UpdateEntry_Handler,
+		},
+		{
+			MethodName: "ListAllEntries",
+			Handler:    _UserLibraryService_ListAllEntries_Handler,
+		},
+		{
+			MethodName: "ListByBook",
+			Handler:    _UserLibraryService_ListByBook_Handler,
+		},
+		{
+			MethodName: "ReserveBooks",
+			Handler:    _UserLibraryService_ReserveBooks_Handler,
+		},
+		{
+			MethodName: "ReleaseBooks",
+			Handler:    _UserLibraryService_ReleaseBooks_Handler,
+		},
+		{
+			MethodName: "RecommendForUser",
+			Handler:    _UserLibraryService_RecommendForUser_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "userlibrary.proto",
@@ -8,6 +8,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"google.golang.org/grpc"
 
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/config"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/handler"
@@ -21,6 +22,7 @@ func main() {
 	db := client.Database("readspace")
 
 	migrations.CreateUserCollectionIndexes(db)
+	migrations.RehashPlaintextPasswords(db)
 
 	redisClient := config.ConnectRedis()
 	userCache := cache.NewUserCache(redisClient)
@@ -32,14 +34,15 @@ func main() {
 	defer nc.Close()
 
 	userRepo := repository.NewMongoUserRepository(db, userCache)
-	userUC := usecase.NewUserUseCase(userRepo)
+	tokens := auth.NewManager(auth.SecretFromEnv(), auth.AccessTokenTTL, auth.RefreshTokenTTL)
+	userUC := usecase.NewUserUseCase(userRepo, tokens)
 	srv := handler.NewUserHandler(userUC, nc)
 
 	lis, err := net.Listen("tcp", ":50052")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(auth.ServerOptions(tokens)...)
 	pb.RegisterUserServiceServer(grpcServer, srv)
 
 	log.Println("UserService gRPC server started on port 50052")
@@ -1,10 +1,26 @@
 package domain
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
+)
 
 type User struct {
-	ID       primitive.ObjectID
-	Name     string
-	Email    string
-	Password string
+	ID       primitive.ObjectID `bson:"_id"`
+	Name     string             `bson:"name"`
+	Email    string             `bson:"email"`
+	Password string             `bson:"password"`
+	Role     string             `bson:"role,omitempty"` // empty, or auth.RoleAdmin
+}
+
+// UserSortFields are the sort orders ListAllUsers accepts.
+var UserSortFields = paging.Fields{
+	"name":  "name",
+	"email": "email",
+}
+
+// UserFilter narrows ListAllUsers; a zero filter matches everyone.
+type UserFilter struct {
+	Role string
 }
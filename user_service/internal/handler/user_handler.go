@@ -2,14 +2,19 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"errors"
 
 	"github.com/nats-io/nats.go"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/events"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/user_service/internal/password"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/usecase"
 	pb "github.com/OshakbayAigerim/read_space/user_service/proto"
 )
@@ -28,39 +33,32 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	if req == nil || req.User == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
+	if req.User.Email == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
 	user := &domain.User{
 		Name:     req.User.Name,
 		Email:    req.User.Email,
-		Password: req.User.Password,
+		Password: req.Password,
 	}
 	created, err := h.uc.CreateUser(ctx, user)
+	if errors.Is(err, password.ErrTooShort) || errors.Is(err, password.ErrTooLong) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, status.Error(codes.AlreadyExists, "email is already registered")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "cannot create user: %v", err)
 	}
 
-	evt := struct {
-		Id    string `json:"id"`
-		Name  string `json:"name"`
-		Email string `json:"email"`
-	}{
-		Id:    created.ID.Hex(),
+	events.Emit(h.nc, events.UserCreated, events.UserCreatedEvent{
+		ID:    created.ID.Hex(),
 		Name:  created.Name,
 		Email: created.Email,
-	}
-	if data, err := json.Marshal(evt); err == nil {
-		if err := h.nc.Publish("user.created", data); err != nil {
-			log.Printf("⚠ NATS publish error (user.created): %v", err)
-		}
-	}
+	})
 
-	return &pb.UserResponse{
-		User: &pb.User{
-			Id:       created.ID.Hex(),
-			Name:     created.Name,
-			Email:    created.Email,
-			Password: created.Password,
-		},
-	}, nil
+	return &pb.UserResponse{User: toProto(created)}, nil
 }
 
 func (h *UserHandler) GetUser(ctx context.Context, req *pb.UserID) (*pb.UserResponse, error) {
@@ -71,30 +69,96 @@ func (h *UserHandler) GetUser(ctx context.Context, req *pb.UserID) (*pb.UserResp
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
 	}
-	return &pb.UserResponse{
-		User: &pb.User{
-			Id:       user.ID.Hex(),
-			Name:     user.Name,
-			Email:    user.Email,
-			Password: user.Password,
-		},
-	}, nil
+	return &pb.UserResponse{User: toProto(user)}, nil
 }
 
-func (h *UserHandler) ListAllUsers(_ *pb.Empty, stream pb.UserService_ListAllUsersServer) error {
-	users, err := h.uc.ListUsers(stream.Context())
+func (h *UserHandler) ListAllUsers(req *pb.ListUsersRequest, stream pb.UserService_ListAllUsersServer) error {
+	q, err := paging.New(req.GetPageSize(), req.GetPageToken(), req.GetSort(), domain.UserSortFields)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	users, next, err := h.uc.ListUsers(stream.Context(), domain.UserFilter{Role: req.GetRole()}, q)
 	if err != nil {
 		return status.Errorf(codes.Internal, "cannot list users: %v", err)
 	}
+	if next != "" {
+		stream.SetTrailer(metadata.Pairs(paging.TrailerKey, next))
+	}
 	for _, u := range users {
-		if err := stream.Send(&pb.User{
-			Id:       u.ID.Hex(),
-			Name:     u.Name,
-			Email:    u.Email,
-			Password: u.Password,
-		}); err != nil {
+		if err := stream.Send(toProto(u)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	if req == nil || req.Email == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+	pair, user, err := h.uc.Login(ctx, req.Email, req.Password)
+	if errors.Is(err, usecase.ErrInvalidCredentials) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot log in: %v", err)
+	}
+	return toAuthResponse(pair, user.ID.Hex()), nil
+}
+
+func (h *UserHandler) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.AuthResponse, error) {
+	if req == nil || req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+	pair, userID, err := h.uc.Refresh(ctx, req.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidToken) {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot refresh token: %v", err)
+	}
+	return toAuthResponse(pair, userID), nil
+}
+
+func (h *UserHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.Empty, error) {
+	caller, err := auth.RequireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.OldPassword == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "old_password and new_password are required")
+	}
+	if req.UserId == "" {
+		req.UserId = caller
+	} else if req.UserId != caller {
+		return nil, status.Error(codes.PermissionDenied, "cannot change another user's password")
+	}
+	err = h.uc.ChangePassword(ctx, req.UserId, req.OldPassword, req.NewPassword)
+	switch {
+	case errors.Is(err, usecase.ErrWrongPassword):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, password.ErrTooShort), errors.Is(err, password.ErrTooLong):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "cannot change password: %v", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+// toProto never copies the password hash into the response.
+func toProto(u *domain.User) *pb.User {
+	return &pb.User{
+		Id:    u.ID.Hex(),
+		Name:  u.Name,
+		Email: u.Email,
+	}
+}
+
+func toAuthResponse(pair *auth.TokenPair, userID string) *pb.AuthResponse {
+	return &pb.AuthResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    int64(pair.ExpiresIn.Seconds()),
+		UserId:       userID,
+	}
+}
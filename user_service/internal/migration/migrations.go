@@ -8,6 +8,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/user_service/internal/password"
 )
 
 func CreateUserCollectionIndexes(db *mongo.Database) {
@@ -17,6 +19,10 @@ func CreateUserCollectionIndexes(db *mongo.Database) {
 			Keys:    bson.D{{Key: "email", Value: 1}},
 			Options: options.Index().SetUnique(true),
 		},
+		// Keyset pagination of ListAllUsers.
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "email", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "role", Value: 1}, {Key: "_id", Value: 1}}},
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -29,3 +35,52 @@ func CreateUserCollectionIndexes(db *mongo.Database) {
 
 	log.Println("Created indexes for users collection")
 }
+
+// RehashPlaintextPasswords replaces passwords stored in plaintext by earlier
+// versions of the service with their bcrypt hashes. It is safe to run on
+// every start: already hashed records are skipped.
+func RehashPlaintextPasswords(db *mongo.Database) {
+	collection := db.Collection("users")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"password": bson.M{"$not": bson.M{"$regex": `^\$2[aby]\$`}}})
+	if err != nil {
+		log.Fatalf("Failed to scan users for plaintext passwords: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	rehashed, skipped := 0, 0
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID       interface{} `bson:"_id"`
+			Password string      `bson:"password"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			log.Fatalf("Failed to decode user: %v", err)
+		}
+		if password.IsHashed(doc.Password) {
+			continue
+		}
+		hash, err := password.Hash(doc.Password)
+		if err != nil {
+			// Such as a password over bcrypt's 72 bytes; the user has to
+			// reset it, but the service must still start.
+			log.Printf("Cannot hash password of user %v, left as is: %v", doc.ID, err)
+			skipped++
+			continue
+		}
+		// Match on the old value so a concurrent ChangePassword is not overwritten.
+		filter := bson.M{"_id": doc.ID, "password": doc.Password}
+		if _, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"password": hash}}); err != nil {
+			log.Fatalf("Failed to store rehashed password: %v", err)
+		}
+		rehashed++
+	}
+	if err := cursor.Err(); err != nil {
+		log.Fatalf("Failed to scan users for plaintext passwords: %v", err)
+	}
+
+	log.Printf("Rehashed %d plaintext passwords, skipped %d", rehashed, skipped)
+}
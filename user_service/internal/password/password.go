@@ -0,0 +1,50 @@
+// Package password hashes and verifies user passwords with bcrypt.
+package password
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinLength = 8
+	// MaxLength is in bytes: bcrypt rejects longer passwords.
+	MaxLength = 72
+)
+
+var (
+	ErrTooShort = errors.New("password must be at least 8 characters long")
+	ErrTooLong  = errors.New("password must be at most 72 bytes long")
+)
+
+// Validate enforces the password policy for new passwords.
+func Validate(plain string) error {
+	if len(plain) < MinLength {
+		return ErrTooShort
+	}
+	if len(plain) > MaxLength {
+		return ErrTooLong
+	}
+	return nil
+}
+
+func Hash(plain string) (string, error) {
+	h, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// Check reports whether plain matches the stored bcrypt hash.
+func Check(hash, plain string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
+}
+
+// IsHashed reports whether stored already looks like a bcrypt hash rather
+// than a legacy plaintext password.
+func IsHashed(stored string) bool {
+	_, err := bcrypt.Cost([]byte(stored))
+	return err == nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/cache"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
@@ -66,23 +67,55 @@ func (r *mongoUserRepo) GetByID(ctx context.Context, id string) (*domain.User, e
 	return &user, nil
 }
 
-func (r *mongoUserRepo) ListAll(ctx context.Context) ([]*domain.User, error) {
+func (r *mongoUserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var user domain.User
+	if err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *mongoUserRepo) UpdatePassword(ctx context.Context, id, hash string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"password": hash}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	_ = r.cache.Delete(ctx, id)
+	return nil
+}
+
+func (r *mongoUserRepo) ListAll(ctx context.Context, f domain.UserFilter, q *paging.Query) ([]*domain.User, string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	filter := bson.M{}
+	if f.Role != "" {
+		filter["role"] = f.Role
+	}
+
+	filter, opts := q.Find(filter)
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer cursor.Close(ctx)
 
 	var users []*domain.User
-	for cursor.Next(ctx) {
-		var u domain.User
-		if err := cursor.Decode(&u); err != nil {
-			return nil, err
-		}
-		users = append(users, &u)
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, "", err
 	}
-	return users, nil
+	return paging.Page(q, users)
 }
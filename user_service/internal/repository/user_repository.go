@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/domain"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
 	GetByID(ctx context.Context, id string) (*domain.User, error)
-	ListAll(ctx context.Context) ([]*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	// ListAll returns one page of the users matching f and the token of the
+	// next page.
+	ListAll(ctx context.Context, f domain.UserFilter, q *paging.Query) ([]*domain.User, string, error)
+	UpdatePassword(ctx context.Context, id, hash string) error
 }
@@ -2,26 +2,49 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/OshakbayAigerim/read_space/pkg/auth"
+	"github.com/OshakbayAigerim/read_space/pkg/paging"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/domain"
+	"github.com/OshakbayAigerim/read_space/user_service/internal/password"
 	"github.com/OshakbayAigerim/read_space/user_service/internal/repository"
 )
 
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrWrongPassword      = errors.New("old password does not match")
+)
+
 type UserUseCase interface {
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
-	ListUsers(ctx context.Context) ([]*domain.User, error)
+	ListUsers(ctx context.Context, f domain.UserFilter, q *paging.Query) ([]*domain.User, string, error)
+	Login(ctx context.Context, email, plain string) (*auth.TokenPair, *domain.User, error)
+	Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, string, error)
+	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error
 }
 
 type userUseCase struct {
-	repo repository.UserRepository
+	repo   repository.UserRepository
+	tokens *auth.Manager
 }
 
-func NewUserUseCase(r repository.UserRepository) UserUseCase {
-	return &userUseCase{repo: r}
+func NewUserUseCase(r repository.UserRepository, tokens *auth.Manager) UserUseCase {
+	return &userUseCase{repo: r, tokens: tokens}
 }
 
 func (u *userUseCase) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := password.Validate(user.Password); err != nil {
+		return nil, err
+	}
+	hash, err := password.Hash(user.Password)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = hash
 	return u.repo.Create(ctx, user)
 }
 
@@ -29,6 +52,60 @@ func (u *userUseCase) GetUserByID(ctx context.Context, id string) (*domain.User,
 	return u.repo.GetByID(ctx, id)
 }
 
-func (u *userUseCase) ListUsers(ctx context.Context) ([]*domain.User, error) {
-	return u.repo.ListAll(ctx)
+func (u *userUseCase) ListUsers(ctx context.Context, f domain.UserFilter, q *paging.Query) ([]*domain.User, string, error) {
+	return u.repo.ListAll(ctx, f, q)
+}
+
+func (u *userUseCase) Login(ctx context.Context, email, plain string) (*auth.TokenPair, *domain.User, error) {
+	user, err := u.repo.GetByEmail(ctx, email)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !password.Check(user.Password, plain) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	pair, err := u.tokens.Issue(user.ID.Hex(), user.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, user, nil
+}
+
+// Refresh exchanges a valid refresh token for a new token pair, as long as
+// the user still exists. The role is re-read, so role changes apply here.
+func (u *userUseCase) Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, string, error) {
+	userID, err := u.tokens.Parse(refreshToken, auth.RefreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", auth.ErrInvalidToken
+	}
+	pair, err := u.tokens.Issue(userID, user.Role)
+	if err != nil {
+		return nil, "", err
+	}
+	return pair, userID, nil
+}
+
+func (u *userUseCase) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !password.Check(user.Password, oldPassword) {
+		return ErrWrongPassword
+	}
+	if err := password.Validate(newPassword); err != nil {
+		return err
+	}
+	hash, err := password.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	return u.repo.UpdatePassword(ctx, userID, hash)
 }